@@ -29,6 +29,8 @@ type PushMsgReq struct {
 	Keys    []string        `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
 	ProtoOp int32           `protobuf:"varint,3,opt,name=protoOp,proto3" json:"protoOp,omitempty"`
 	Proto   *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	Ack     bool            `protobuf:"varint,4,opt,name=ack,proto3" json:"ack,omitempty"`
+	Id      string          `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (x *PushMsgReq) Reset() {
@@ -84,29 +86,620 @@ func (x *PushMsgReq) GetProto() *protocol.Proto {
 	return nil
 }
 
+func (x *PushMsgReq) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *PushMsgReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type PushMsgReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *PushMsgReply) Reset() {
-	*x = PushMsgReply{}
+func (x *PushMsgReply) Reset() {
+	*x = PushMsgReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMsgReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMsgReply) ProtoMessage() {}
+
+func (x *PushMsgReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMsgReply.ProtoReflect.Descriptor instead.
+func (*PushMsgReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{1}
+}
+
+type BroadcastReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProtoOp int32           `protobuf:"varint,1,opt,name=protoOp,proto3" json:"protoOp,omitempty"`
+	Proto   *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	Speed   int32           `protobuf:"varint,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	Id      string          `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *BroadcastReq) Reset() {
+	*x = BroadcastReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastReq) ProtoMessage() {}
+
+func (x *BroadcastReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastReq.ProtoReflect.Descriptor instead.
+func (*BroadcastReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BroadcastReq) GetProtoOp() int32 {
+	if x != nil {
+		return x.ProtoOp
+	}
+	return 0
+}
+
+func (x *BroadcastReq) GetProto() *protocol.Proto {
+	if x != nil {
+		return x.Proto
+	}
+	return nil
+}
+
+func (x *BroadcastReq) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *BroadcastReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BroadcastReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the broadcast, the push id if any
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *BroadcastReply) Reset() {
+	*x = BroadcastReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastReply) ProtoMessage() {}
+
+func (x *BroadcastReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastReply.ProtoReflect.Descriptor instead.
+func (*BroadcastReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BroadcastReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelBroadcastReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelBroadcastReq) Reset() {
+	*x = CancelBroadcastReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBroadcastReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBroadcastReq) ProtoMessage() {}
+
+func (x *CancelBroadcastReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBroadcastReq.ProtoReflect.Descriptor instead.
+func (*CancelBroadcastReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelBroadcastReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelBroadcastReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// false if the broadcast is unknown or finished
+	Canceled bool `protobuf:"varint,1,opt,name=canceled,proto3" json:"canceled,omitempty"`
+}
+
+func (x *CancelBroadcastReply) Reset() {
+	*x = CancelBroadcastReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBroadcastReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBroadcastReply) ProtoMessage() {}
+
+func (x *CancelBroadcastReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBroadcastReply.ProtoReflect.Descriptor instead.
+func (*CancelBroadcastReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelBroadcastReply) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+type BroadcastsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the broadcast of the id only if not empty
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *BroadcastsReq) Reset() {
+	*x = BroadcastsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastsReq) ProtoMessage() {}
+
+func (x *BroadcastsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastsReq.ProtoReflect.Descriptor instead.
+func (*BroadcastsReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BroadcastsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type BroadcastProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// queued, running, done, canceled or expired
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// msgs per second, unpaced if zero
+	Rate int32 `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	// conns when it started
+	Total     int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Sent      int64 `protobuf:"varint,5,opt,name=sent,proto3" json:"sent,omitempty"`
+	Delivered int64 `protobuf:"varint,6,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Dropped   int64 `protobuf:"varint,7,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// unix seconds
+	Created  int64 `protobuf:"varint,8,opt,name=created,proto3" json:"created,omitempty"`
+	Started  int64 `protobuf:"varint,9,opt,name=started,proto3" json:"started,omitempty"`
+	Finished int64 `protobuf:"varint,10,opt,name=finished,proto3" json:"finished,omitempty"`
+}
+
+func (x *BroadcastProgress) Reset() {
+	*x = BroadcastProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastProgress) ProtoMessage() {}
+
+func (x *BroadcastProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastProgress.ProtoReflect.Descriptor instead.
+func (*BroadcastProgress) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BroadcastProgress) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BroadcastProgress) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *BroadcastProgress) GetRate() int32 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetDelivered() int64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetStarted() int64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *BroadcastProgress) GetFinished() int64 {
+	if x != nil {
+		return x.Finished
+	}
+	return 0
+}
+
+type BroadcastsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Broadcasts []*BroadcastProgress `protobuf:"bytes,1,rep,name=broadcasts,proto3" json:"broadcasts,omitempty"`
+}
+
+func (x *BroadcastsReply) Reset() {
+	*x = BroadcastsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastsReply) ProtoMessage() {}
+
+func (x *BroadcastsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastsReply.ProtoReflect.Descriptor instead.
+func (*BroadcastsReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BroadcastsReply) GetBroadcasts() []*BroadcastProgress {
+	if x != nil {
+		return x.Broadcasts
+	}
+	return nil
+}
+
+type BroadcastRoomReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID string          `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Proto  *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	// ids of the pushes merged in the proto
+	Ids []string `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *BroadcastRoomReq) Reset() {
+	*x = BroadcastRoomReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRoomReq) ProtoMessage() {}
+
+func (x *BroadcastRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRoomReq.ProtoReflect.Descriptor instead.
+func (*BroadcastRoomReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BroadcastRoomReq) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *BroadcastRoomReq) GetProto() *protocol.Proto {
+	if x != nil {
+		return x.Proto
+	}
+	return nil
+}
+
+func (x *BroadcastRoomReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BroadcastRoomReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BroadcastRoomReply) Reset() {
+	*x = BroadcastRoomReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastRoomReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRoomReply) ProtoMessage() {}
+
+func (x *BroadcastRoomReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRoomReply.ProtoReflect.Descriptor instead.
+func (*BroadcastRoomReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{10}
+}
+
+type KickReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys   []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	RoomID string   `protobuf:"bytes,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Reason int32    `protobuf:"varint,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Msg    string   `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *KickReq) Reset() {
+	*x = KickReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[1]
+		mi := &file_comet_comet_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PushMsgReply) String() string {
+func (x *KickReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushMsgReply) ProtoMessage() {}
+func (*KickReq) ProtoMessage() {}
 
-func (x *PushMsgReply) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[1]
+func (x *KickReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,38 +710,62 @@ func (x *PushMsgReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushMsgReply.ProtoReflect.Descriptor instead.
-func (*PushMsgReply) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use KickReq.ProtoReflect.Descriptor instead.
+func (*KickReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{11}
 }
 
-type BroadcastReq struct {
+func (x *KickReq) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *KickReq) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *KickReq) GetReason() int32 {
+	if x != nil {
+		return x.Reason
+	}
+	return 0
+}
+
+func (x *KickReq) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type KickReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	ProtoOp int32           `protobuf:"varint,1,opt,name=protoOp,proto3" json:"protoOp,omitempty"`
-	Proto   *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
-	Speed   int32           `protobuf:"varint,3,opt,name=speed,proto3" json:"speed,omitempty"`
 }
 
-func (x *BroadcastReq) Reset() {
-	*x = BroadcastReq{}
+func (x *KickReply) Reset() {
+	*x = KickReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[2]
+		mi := &file_comet_comet_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BroadcastReq) String() string {
+func (x *KickReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastReq) ProtoMessage() {}
+func (*KickReply) ProtoMessage() {}
 
-func (x *BroadcastReq) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[2]
+func (x *KickReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,55 +776,89 @@ func (x *BroadcastReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastReq.ProtoReflect.Descriptor instead.
-func (*BroadcastReq) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use KickReply.ProtoReflect.Descriptor instead.
+func (*KickReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *BroadcastReq) GetProtoOp() int32 {
-	if x != nil {
-		return x.ProtoOp
+type MigrateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Nodes []string `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *MigrateReq) Reset() {
+	*x = MigrateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_comet_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *BroadcastReq) GetProto() *protocol.Proto {
-	if x != nil {
-		return x.Proto
+func (x *MigrateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateReq) ProtoMessage() {}
+
+func (x *MigrateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BroadcastReq) GetSpeed() int32 {
+// Deprecated: Use MigrateReq.ProtoReflect.Descriptor instead.
+func (*MigrateReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MigrateReq) GetCount() int32 {
 	if x != nil {
-		return x.Speed
+		return x.Count
 	}
 	return 0
 }
 
-type BroadcastReply struct {
+func (x *MigrateReq) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type MigrateReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *BroadcastReply) Reset() {
-	*x = BroadcastReply{}
+func (x *MigrateReply) Reset() {
+	*x = MigrateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[3]
+		mi := &file_comet_comet_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BroadcastReply) String() string {
+func (x *MigrateReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastReply) ProtoMessage() {}
+func (*MigrateReply) ProtoMessage() {}
 
-func (x *BroadcastReply) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[3]
+func (x *MigrateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,37 +869,40 @@ func (x *BroadcastReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastReply.ProtoReflect.Descriptor instead.
-func (*BroadcastReply) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use MigrateReply.ProtoReflect.Descriptor instead.
+func (*MigrateReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{14}
 }
 
-type BroadcastRoomReq struct {
+// TraceReq trace the conns of a mid or key for ttl seconds, the default ttl
+// if zero, a negative ttl stops the trace.
+type TraceReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoomID string          `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	Proto  *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	Mid int64  `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Ttl int64  `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
-func (x *BroadcastRoomReq) Reset() {
-	*x = BroadcastRoomReq{}
+func (x *TraceReq) Reset() {
+	*x = TraceReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[4]
+		mi := &file_comet_comet_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BroadcastRoomReq) String() string {
+func (x *TraceReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastRoomReq) ProtoMessage() {}
+func (*TraceReq) ProtoMessage() {}
 
-func (x *BroadcastRoomReq) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[4]
+func (x *TraceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,48 +913,55 @@ func (x *BroadcastRoomReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastRoomReq.ProtoReflect.Descriptor instead.
-func (*BroadcastRoomReq) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use TraceReq.ProtoReflect.Descriptor instead.
+func (*TraceReq) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *BroadcastRoomReq) GetRoomID() string {
+func (x *TraceReq) GetMid() int64 {
 	if x != nil {
-		return x.RoomID
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *TraceReq) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
 	return ""
 }
 
-func (x *BroadcastRoomReq) GetProto() *protocol.Proto {
+func (x *TraceReq) GetTtl() int64 {
 	if x != nil {
-		return x.Proto
+		return x.Ttl
 	}
-	return nil
+	return 0
 }
 
-type BroadcastRoomReply struct {
+type TraceReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *BroadcastRoomReply) Reset() {
-	*x = BroadcastRoomReply{}
+func (x *TraceReply) Reset() {
+	*x = TraceReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[5]
+		mi := &file_comet_comet_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BroadcastRoomReply) String() string {
+func (x *TraceReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastRoomReply) ProtoMessage() {}
+func (*TraceReply) ProtoMessage() {}
 
-func (x *BroadcastRoomReply) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[5]
+func (x *TraceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_comet_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,9 +972,9 @@ func (x *BroadcastRoomReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastRoomReply.ProtoReflect.Descriptor instead.
-func (*BroadcastRoomReply) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use TraceReply.ProtoReflect.Descriptor instead.
+func (*TraceReply) Descriptor() ([]byte, []int) {
+	return file_comet_comet_proto_rawDescGZIP(), []int{16}
 }
 
 type RoomsReq struct {
@@ -325,7 +986,7 @@ type RoomsReq struct {
 func (x *RoomsReq) Reset() {
 	*x = RoomsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[6]
+		mi := &file_comet_comet_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -338,7 +999,7 @@ func (x *RoomsReq) String() string {
 func (*RoomsReq) ProtoMessage() {}
 
 func (x *RoomsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[6]
+	mi := &file_comet_comet_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +1012,7 @@ func (x *RoomsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomsReq.ProtoReflect.Descriptor instead.
 func (*RoomsReq) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{6}
+	return file_comet_comet_proto_rawDescGZIP(), []int{17}
 }
 
 type RoomsReply struct {
@@ -365,7 +1026,7 @@ type RoomsReply struct {
 func (x *RoomsReply) Reset() {
 	*x = RoomsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_comet_comet_proto_msgTypes[7]
+		mi := &file_comet_comet_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +1039,7 @@ func (x *RoomsReply) String() string {
 func (*RoomsReply) ProtoMessage() {}
 
 func (x *RoomsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_comet_comet_proto_msgTypes[7]
+	mi := &file_comet_comet_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +1052,7 @@ func (x *RoomsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomsReply.ProtoReflect.Descriptor instead.
 func (*RoomsReply) Descriptor() ([]byte, []int) {
-	return file_comet_comet_proto_rawDescGZIP(), []int{7}
+	return file_comet_comet_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RoomsReply) GetRooms() map[string]bool {
@@ -407,59 +1068,131 @@ var file_comet_comet_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
 	0x1a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x67, 0x0a, 0x0a, 0x50, 0x75, 0x73,
-	0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x6b, 0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52,
-	0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x12, 0x2b, 0x0a, 0x05,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x68,
-	0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65,
-	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22,
-	0x10, 0x0a, 0x0e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x57, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f,
-	0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x2b, 0x0a,
-	0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63,
-	0x68, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x42, 0x72,
-	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x0a, 0x0a, 0x08, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x22, 0x80, 0x01, 0x0a,
-	0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a, 0x05, 0x72,
-	0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x68, 0x69,
-	0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
-	0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32,
-	0x95, 0x02, 0x0a, 0x05, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x75, 0x73,
-	0x68, 0x4d, 0x73, 0x67, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d,
-	0x65, 0x74, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e,
-	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x50, 0x75, 0x73, 0x68,
-	0x4d, 0x73, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x43, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f,
-	0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x1a, 0x1b, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42,
-	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4f, 0x0a,
-	0x0d, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1d,
-	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f,
-	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89, 0x01, 0x0a, 0x0a, 0x50, 0x75,
+	0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x61, 0x63, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x7b, 0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4f, 0x70, 0x12,
+	0x2b, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x65,
+	0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x20, 0x0a, 0x0e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x24, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x32, 0x0a, 0x14, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x22, 0x1f,
+	0x0a, 0x0d, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0xff, 0x01, 0x0a, 0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x22, 0x51, 0x0a, 0x0f, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x0a, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
+	0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0a, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x73, 0x22, 0x69, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
+	0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
+	0x12, 0x2b, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a,
+	0x03, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22,
+	0x14, 0x0a, 0x12, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x5f, 0x0a, 0x07, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x0b, 0x0a, 0x09, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x38, 0x0a, 0x0a, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x0e, 0x0a,
+	0x0c, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x40, 0x0a,
+	0x08, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22,
+	0x0c, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0a, 0x0a,
+	0x08, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x22, 0x80, 0x01, 0x0a, 0x0a, 0x52, 0x6f,
+	0x6f, 0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
+	0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x6f, 0x6f,
+	0x6d, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xe2, 0x04, 0x0a,
+	0x05, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73,
+	0x67, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e,
+	0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x69,
+	0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x43, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
+	0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e,
 	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37,
-	0x0a, 0x05, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
-	0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17,
-	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x52, 0x6f, 0x6f,
-	0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x63, 0x61, 0x71, 0x72, 0x6c, 0x2f, 0x63, 0x68, 0x69,
-	0x6d, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x3b, 0x63, 0x6f, 0x6d,
-	0x65, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x55, 0x0a, 0x0f, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x1f, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x21,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x46, 0x0a, 0x0a, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x12,
+	0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68,
+	0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4f, 0x0a, 0x0d, 0x42, 0x72, 0x6f,
+	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1d, 0x2e, 0x63, 0x68, 0x69,
+	0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x63, 0x68, 0x69, 0x6d,
+	0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73,
+	0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x04, 0x4b, 0x69,
+	0x63, 0x6b, 0x12, 0x14, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
+	0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
+	0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x3d, 0x0a, 0x07, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x68,
+	0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d,
+	0x65, 0x74, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x37, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
+	0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x1a,
+	0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x52, 0x6f, 0x6f, 0x6d,
+	0x73, 0x12, 0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
+	0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x77, 0x63, 0x61, 0x71, 0x72, 0x6c, 0x2f, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x3b, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -474,37 +1207,59 @@ func file_comet_comet_proto_rawDescGZIP() []byte {
 	return file_comet_comet_proto_rawDescData
 }
 
-var file_comet_comet_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_comet_comet_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_comet_comet_proto_goTypes = []interface{}{
-	(*PushMsgReq)(nil),         // 0: chime.comet.PushMsgReq
-	(*PushMsgReply)(nil),       // 1: chime.comet.PushMsgReply
-	(*BroadcastReq)(nil),       // 2: chime.comet.BroadcastReq
-	(*BroadcastReply)(nil),     // 3: chime.comet.BroadcastReply
-	(*BroadcastRoomReq)(nil),   // 4: chime.comet.BroadcastRoomReq
-	(*BroadcastRoomReply)(nil), // 5: chime.comet.BroadcastRoomReply
-	(*RoomsReq)(nil),           // 6: chime.comet.RoomsReq
-	(*RoomsReply)(nil),         // 7: chime.comet.RoomsReply
-	nil,                        // 8: chime.comet.RoomsReply.RoomsEntry
-	(*protocol.Proto)(nil),     // 9: chime.protocol.Proto
+	(*PushMsgReq)(nil),           // 0: chime.comet.PushMsgReq
+	(*PushMsgReply)(nil),         // 1: chime.comet.PushMsgReply
+	(*BroadcastReq)(nil),         // 2: chime.comet.BroadcastReq
+	(*BroadcastReply)(nil),       // 3: chime.comet.BroadcastReply
+	(*CancelBroadcastReq)(nil),   // 4: chime.comet.CancelBroadcastReq
+	(*CancelBroadcastReply)(nil), // 5: chime.comet.CancelBroadcastReply
+	(*BroadcastsReq)(nil),        // 6: chime.comet.BroadcastsReq
+	(*BroadcastProgress)(nil),    // 7: chime.comet.BroadcastProgress
+	(*BroadcastsReply)(nil),      // 8: chime.comet.BroadcastsReply
+	(*BroadcastRoomReq)(nil),     // 9: chime.comet.BroadcastRoomReq
+	(*BroadcastRoomReply)(nil),   // 10: chime.comet.BroadcastRoomReply
+	(*KickReq)(nil),              // 11: chime.comet.KickReq
+	(*KickReply)(nil),            // 12: chime.comet.KickReply
+	(*MigrateReq)(nil),           // 13: chime.comet.MigrateReq
+	(*MigrateReply)(nil),         // 14: chime.comet.MigrateReply
+	(*TraceReq)(nil),             // 15: chime.comet.TraceReq
+	(*TraceReply)(nil),           // 16: chime.comet.TraceReply
+	(*RoomsReq)(nil),             // 17: chime.comet.RoomsReq
+	(*RoomsReply)(nil),           // 18: chime.comet.RoomsReply
+	nil,                          // 19: chime.comet.RoomsReply.RoomsEntry
+	(*protocol.Proto)(nil),       // 20: chime.protocol.Proto
 }
 var file_comet_comet_proto_depIdxs = []int32{
-	9, // 0: chime.comet.PushMsgReq.proto:type_name -> chime.protocol.Proto
-	9, // 1: chime.comet.BroadcastReq.proto:type_name -> chime.protocol.Proto
-	9, // 2: chime.comet.BroadcastRoomReq.proto:type_name -> chime.protocol.Proto
-	8, // 3: chime.comet.RoomsReply.rooms:type_name -> chime.comet.RoomsReply.RoomsEntry
-	0, // 4: chime.comet.Comet.PushMsg:input_type -> chime.comet.PushMsgReq
-	2, // 5: chime.comet.Comet.Broadcast:input_type -> chime.comet.BroadcastReq
-	4, // 6: chime.comet.Comet.BroadcastRoom:input_type -> chime.comet.BroadcastRoomReq
-	6, // 7: chime.comet.Comet.Rooms:input_type -> chime.comet.RoomsReq
-	1, // 8: chime.comet.Comet.PushMsg:output_type -> chime.comet.PushMsgReply
-	3, // 9: chime.comet.Comet.Broadcast:output_type -> chime.comet.BroadcastReply
-	5, // 10: chime.comet.Comet.BroadcastRoom:output_type -> chime.comet.BroadcastRoomReply
-	7, // 11: chime.comet.Comet.Rooms:output_type -> chime.comet.RoomsReply
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	20, // 0: chime.comet.PushMsgReq.proto:type_name -> chime.protocol.Proto
+	20, // 1: chime.comet.BroadcastReq.proto:type_name -> chime.protocol.Proto
+	7,  // 2: chime.comet.BroadcastsReply.broadcasts:type_name -> chime.comet.BroadcastProgress
+	20, // 3: chime.comet.BroadcastRoomReq.proto:type_name -> chime.protocol.Proto
+	19, // 4: chime.comet.RoomsReply.rooms:type_name -> chime.comet.RoomsReply.RoomsEntry
+	0,  // 5: chime.comet.Comet.PushMsg:input_type -> chime.comet.PushMsgReq
+	2,  // 6: chime.comet.Comet.Broadcast:input_type -> chime.comet.BroadcastReq
+	4,  // 7: chime.comet.Comet.CancelBroadcast:input_type -> chime.comet.CancelBroadcastReq
+	6,  // 8: chime.comet.Comet.Broadcasts:input_type -> chime.comet.BroadcastsReq
+	9,  // 9: chime.comet.Comet.BroadcastRoom:input_type -> chime.comet.BroadcastRoomReq
+	11, // 10: chime.comet.Comet.Kick:input_type -> chime.comet.KickReq
+	13, // 11: chime.comet.Comet.Migrate:input_type -> chime.comet.MigrateReq
+	15, // 12: chime.comet.Comet.Trace:input_type -> chime.comet.TraceReq
+	17, // 13: chime.comet.Comet.Rooms:input_type -> chime.comet.RoomsReq
+	1,  // 14: chime.comet.Comet.PushMsg:output_type -> chime.comet.PushMsgReply
+	3,  // 15: chime.comet.Comet.Broadcast:output_type -> chime.comet.BroadcastReply
+	5,  // 16: chime.comet.Comet.CancelBroadcast:output_type -> chime.comet.CancelBroadcastReply
+	8,  // 17: chime.comet.Comet.Broadcasts:output_type -> chime.comet.BroadcastsReply
+	10, // 18: chime.comet.Comet.BroadcastRoom:output_type -> chime.comet.BroadcastRoomReply
+	12, // 19: chime.comet.Comet.Kick:output_type -> chime.comet.KickReply
+	14, // 20: chime.comet.Comet.Migrate:output_type -> chime.comet.MigrateReply
+	16, // 21: chime.comet.Comet.Trace:output_type -> chime.comet.TraceReply
+	18, // 22: chime.comet.Comet.Rooms:output_type -> chime.comet.RoomsReply
+	14, // [14:23] is the sub-list for method output_type
+	5,  // [5:14] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_comet_comet_proto_init() }
@@ -562,7 +1317,7 @@ func file_comet_comet_proto_init() {
 			}
 		}
 		file_comet_comet_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastRoomReq); i {
+			switch v := v.(*CancelBroadcastReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -574,7 +1329,7 @@ func file_comet_comet_proto_init() {
 			}
 		}
 		file_comet_comet_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastRoomReply); i {
+			switch v := v.(*CancelBroadcastReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -586,7 +1341,7 @@ func file_comet_comet_proto_init() {
 			}
 		}
 		file_comet_comet_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomsReq); i {
+			switch v := v.(*BroadcastsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -598,6 +1353,138 @@ func file_comet_comet_proto_init() {
 			}
 		}
 		file_comet_comet_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastRoomReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastRoomReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigrateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigrateReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_comet_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RoomsReply); i {
 			case 0:
 				return &v.state
@@ -616,7 +1503,7 @@ func file_comet_comet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_comet_comet_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
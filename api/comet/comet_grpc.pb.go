@@ -26,8 +26,18 @@ type CometClient interface {
 	PushMsg(ctx context.Context, in *PushMsgReq, opts ...grpc.CallOption) (*PushMsgReply, error)
 	// Broadcast send to every entry
 	Broadcast(ctx context.Context, in *BroadcastReq, opts ...grpc.CallOption) (*BroadcastReply, error)
+	// CancelBroadcast cancel a queued or running broadcast
+	CancelBroadcast(ctx context.Context, in *CancelBroadcastReq, opts ...grpc.CallOption) (*CancelBroadcastReply, error)
+	// Broadcasts get the progress of the queued, running and recent broadcasts
+	Broadcasts(ctx context.Context, in *BroadcastsReq, opts ...grpc.CallOption) (*BroadcastsReply, error)
 	// BroadcastRoom broadcast to one room
 	BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...grpc.CallOption) (*BroadcastRoomReply, error)
+	// Kick disconnect the keys or every entry of a room
+	Kick(ctx context.Context, in *KickReq, opts ...grpc.CallOption) (*KickReply, error)
+	// Migrate ask a number of entries to reconnect to other nodes
+	Migrate(ctx context.Context, in *MigrateReq, opts ...grpc.CallOption) (*MigrateReply, error)
+	// Trace trace the conns of a mid or key
+	Trace(ctx context.Context, in *TraceReq, opts ...grpc.CallOption) (*TraceReply, error)
 	// Rooms get all rooms
 	Rooms(ctx context.Context, in *RoomsReq, opts ...grpc.CallOption) (*RoomsReply, error)
 }
@@ -58,6 +68,24 @@ func (c *cometClient) Broadcast(ctx context.Context, in *BroadcastReq, opts ...g
 	return out, nil
 }
 
+func (c *cometClient) CancelBroadcast(ctx context.Context, in *CancelBroadcastReq, opts ...grpc.CallOption) (*CancelBroadcastReply, error) {
+	out := new(CancelBroadcastReply)
+	err := c.cc.Invoke(ctx, "/chime.comet.Comet/CancelBroadcast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cometClient) Broadcasts(ctx context.Context, in *BroadcastsReq, opts ...grpc.CallOption) (*BroadcastsReply, error) {
+	out := new(BroadcastsReply)
+	err := c.cc.Invoke(ctx, "/chime.comet.Comet/Broadcasts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cometClient) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, opts ...grpc.CallOption) (*BroadcastRoomReply, error) {
 	out := new(BroadcastRoomReply)
 	err := c.cc.Invoke(ctx, "/chime.comet.Comet/BroadcastRoom", in, out, opts...)
@@ -67,6 +95,33 @@ func (c *cometClient) BroadcastRoom(ctx context.Context, in *BroadcastRoomReq, o
 	return out, nil
 }
 
+func (c *cometClient) Kick(ctx context.Context, in *KickReq, opts ...grpc.CallOption) (*KickReply, error) {
+	out := new(KickReply)
+	err := c.cc.Invoke(ctx, "/chime.comet.Comet/Kick", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cometClient) Migrate(ctx context.Context, in *MigrateReq, opts ...grpc.CallOption) (*MigrateReply, error) {
+	out := new(MigrateReply)
+	err := c.cc.Invoke(ctx, "/chime.comet.Comet/Migrate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cometClient) Trace(ctx context.Context, in *TraceReq, opts ...grpc.CallOption) (*TraceReply, error) {
+	out := new(TraceReply)
+	err := c.cc.Invoke(ctx, "/chime.comet.Comet/Trace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cometClient) Rooms(ctx context.Context, in *RoomsReq, opts ...grpc.CallOption) (*RoomsReply, error) {
 	out := new(RoomsReply)
 	err := c.cc.Invoke(ctx, "/chime.comet.Comet/Rooms", in, out, opts...)
@@ -84,8 +139,18 @@ type CometServer interface {
 	PushMsg(context.Context, *PushMsgReq) (*PushMsgReply, error)
 	// Broadcast send to every entry
 	Broadcast(context.Context, *BroadcastReq) (*BroadcastReply, error)
+	// CancelBroadcast cancel a queued or running broadcast
+	CancelBroadcast(context.Context, *CancelBroadcastReq) (*CancelBroadcastReply, error)
+	// Broadcasts get the progress of the queued, running and recent broadcasts
+	Broadcasts(context.Context, *BroadcastsReq) (*BroadcastsReply, error)
 	// BroadcastRoom broadcast to one room
 	BroadcastRoom(context.Context, *BroadcastRoomReq) (*BroadcastRoomReply, error)
+	// Kick disconnect the keys or every entry of a room
+	Kick(context.Context, *KickReq) (*KickReply, error)
+	// Migrate ask a number of entries to reconnect to other nodes
+	Migrate(context.Context, *MigrateReq) (*MigrateReply, error)
+	// Trace trace the conns of a mid or key
+	Trace(context.Context, *TraceReq) (*TraceReply, error)
 	// Rooms get all rooms
 	Rooms(context.Context, *RoomsReq) (*RoomsReply, error)
 }
@@ -100,9 +165,24 @@ func (UnimplementedCometServer) PushMsg(context.Context, *PushMsgReq) (*PushMsgR
 func (UnimplementedCometServer) Broadcast(context.Context, *BroadcastReq) (*BroadcastReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
 }
+func (UnimplementedCometServer) CancelBroadcast(context.Context, *CancelBroadcastReq) (*CancelBroadcastReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBroadcast not implemented")
+}
+func (UnimplementedCometServer) Broadcasts(context.Context, *BroadcastsReq) (*BroadcastsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcasts not implemented")
+}
 func (UnimplementedCometServer) BroadcastRoom(context.Context, *BroadcastRoomReq) (*BroadcastRoomReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BroadcastRoom not implemented")
 }
+func (UnimplementedCometServer) Kick(context.Context, *KickReq) (*KickReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Kick not implemented")
+}
+func (UnimplementedCometServer) Migrate(context.Context, *MigrateReq) (*MigrateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Migrate not implemented")
+}
+func (UnimplementedCometServer) Trace(context.Context, *TraceReq) (*TraceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trace not implemented")
+}
 func (UnimplementedCometServer) Rooms(context.Context, *RoomsReq) (*RoomsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rooms not implemented")
 }
@@ -154,6 +234,42 @@ func _Comet_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Comet_CancelBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CometServer).CancelBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.comet.Comet/CancelBroadcast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CometServer).CancelBroadcast(ctx, req.(*CancelBroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comet_Broadcasts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CometServer).Broadcasts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.comet.Comet/Broadcasts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CometServer).Broadcasts(ctx, req.(*BroadcastsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Comet_BroadcastRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BroadcastRoomReq)
 	if err := dec(in); err != nil {
@@ -172,6 +288,60 @@ func _Comet_BroadcastRoom_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Comet_Kick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CometServer).Kick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.comet.Comet/Kick",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CometServer).Kick(ctx, req.(*KickReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comet_Migrate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CometServer).Migrate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.comet.Comet/Migrate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CometServer).Migrate(ctx, req.(*MigrateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comet_Trace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CometServer).Trace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.comet.Comet/Trace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CometServer).Trace(ctx, req.(*TraceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Comet_Rooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RoomsReq)
 	if err := dec(in); err != nil {
@@ -205,10 +375,30 @@ var Comet_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Broadcast",
 			Handler:    _Comet_Broadcast_Handler,
 		},
+		{
+			MethodName: "CancelBroadcast",
+			Handler:    _Comet_CancelBroadcast_Handler,
+		},
+		{
+			MethodName: "Broadcasts",
+			Handler:    _Comet_Broadcasts_Handler,
+		},
 		{
 			MethodName: "BroadcastRoom",
 			Handler:    _Comet_BroadcastRoom_Handler,
 		},
+		{
+			MethodName: "Kick",
+			Handler:    _Comet_Kick_Handler,
+		},
+		{
+			MethodName: "Migrate",
+			Handler:    _Comet_Migrate_Handler,
+		},
+		{
+			MethodName: "Trace",
+			Handler:    _Comet_Trace_Handler,
+		},
 		{
 			MethodName: "Rooms",
 			Handler:    _Comet_Rooms_Handler,
@@ -27,6 +27,9 @@ const (
 	PushMsg_PUSH      PushMsg_Type = 0
 	PushMsg_ROOM      PushMsg_Type = 1
 	PushMsg_BROADCAST PushMsg_Type = 2
+	PushMsg_KICK      PushMsg_Type = 3
+	PushMsg_MIGRATE   PushMsg_Type = 4
+	PushMsg_TRACE     PushMsg_Type = 5
 )
 
 // Enum value maps for PushMsg_Type.
@@ -35,11 +38,17 @@ var (
 		0: "PUSH",
 		1: "ROOM",
 		2: "BROADCAST",
+		3: "KICK",
+		4: "MIGRATE",
+		5: "TRACE",
 	}
 	PushMsg_Type_value = map[string]int32{
 		"PUSH":      0,
 		"ROOM":      1,
 		"BROADCAST": 2,
+		"KICK":      3,
+		"MIGRATE":   4,
+		"TRACE":     5,
 	}
 )
 
@@ -70,6 +79,57 @@ func (PushMsg_Type) EnumDescriptor() ([]byte, []int) {
 	return file_logic_logic_proto_rawDescGZIP(), []int{0, 0}
 }
 
+// the lane of a push, the bulk ones are queued behind the realtime ones
+type PushMsg_Priority int32
+
+const (
+	// bulk for a broadcast to all, realtime otherwise
+	PushMsg_DEFAULT  PushMsg_Priority = 0
+	PushMsg_REALTIME PushMsg_Priority = 1
+	PushMsg_BULK     PushMsg_Priority = 2
+)
+
+// Enum value maps for PushMsg_Priority.
+var (
+	PushMsg_Priority_name = map[int32]string{
+		0: "DEFAULT",
+		1: "REALTIME",
+		2: "BULK",
+	}
+	PushMsg_Priority_value = map[string]int32{
+		"DEFAULT":  0,
+		"REALTIME": 1,
+		"BULK":     2,
+	}
+)
+
+func (x PushMsg_Priority) Enum() *PushMsg_Priority {
+	p := new(PushMsg_Priority)
+	*p = x
+	return p
+}
+
+func (x PushMsg_Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushMsg_Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_logic_logic_proto_enumTypes[1].Descriptor()
+}
+
+func (PushMsg_Priority) Type() protoreflect.EnumType {
+	return &file_logic_logic_proto_enumTypes[1]
+}
+
+func (x PushMsg_Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushMsg_Priority.Descriptor instead.
+func (PushMsg_Priority) EnumDescriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{0, 1}
+}
+
 type PushMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -82,6 +142,24 @@ type PushMsg struct {
 	Room      string       `protobuf:"bytes,5,opt,name=room,proto3" json:"room,omitempty"`
 	Keys      []string     `protobuf:"bytes,6,rep,name=keys,proto3" json:"keys,omitempty"`
 	Msg       []byte       `protobuf:"bytes,7,opt,name=msg,proto3" json:"msg,omitempty"`
+	Ack       bool         `protobuf:"varint,8,opt,name=ack,proto3" json:"ack,omitempty"`
+	Seq       int32        `protobuf:"varint,9,opt,name=seq,proto3" json:"seq,omitempty"`
+	Reason    int32        `protobuf:"varint,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	Count     int32        `protobuf:"varint,11,opt,name=count,proto3" json:"count,omitempty"`
+	Nodes     []string     `protobuf:"bytes,12,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Mid       int64        `protobuf:"varint,13,opt,name=mid,proto3" json:"mid,omitempty"`
+	Ttl       int64        `protobuf:"varint,14,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// w3c trace context of the push
+	Traceparent string `protobuf:"bytes,15,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	// id of the push, the comets report its deliveries
+	Id string `protobuf:"bytes,16,opt,name=id,proto3" json:"id,omitempty"`
+	// unix seconds the push is held until by job
+	NotBefore int64 `protobuf:"varint,17,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// unix seconds the push is discarded at, never if zero
+	ExpireAt int64            `protobuf:"varint,18,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Priority PushMsg_Priority `protobuf:"varint,19,opt,name=priority,proto3,enum=chime.logic.PushMsg_Priority" json:"priority,omitempty"`
+	// the mids of a delayed push, job resolves them and the keys when it is sent
+	Mids []int64 `protobuf:"varint,20,rep,packed,name=mids,proto3" json:"mids,omitempty"`
 }
 
 func (x *PushMsg) Reset() {
@@ -165,6 +243,176 @@ func (x *PushMsg) GetMsg() []byte {
 	return nil
 }
 
+func (x *PushMsg) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *PushMsg) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PushMsg) GetReason() int32 {
+	if x != nil {
+		return x.Reason
+	}
+	return 0
+}
+
+func (x *PushMsg) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PushMsg) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *PushMsg) GetMid() int64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *PushMsg) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *PushMsg) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
+	}
+	return ""
+}
+
+func (x *PushMsg) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PushMsg) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *PushMsg) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *PushMsg) GetPriority() PushMsg_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return PushMsg_DEFAULT
+}
+
+func (x *PushMsg) GetMids() []int64 {
+	if x != nil {
+		return x.Mids
+	}
+	return nil
+}
+
+type DeadLetterMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg       *PushMsg `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Server    string   `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Reason    string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Attempts  int32    `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Timestamp int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *DeadLetterMsg) Reset() {
+	*x = DeadLetterMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLetterMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterMsg) ProtoMessage() {}
+
+func (x *DeadLetterMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterMsg.ProtoReflect.Descriptor instead.
+func (*DeadLetterMsg) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeadLetterMsg) GetMsg() *PushMsg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *DeadLetterMsg) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *DeadLetterMsg) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetterMsg) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetterMsg) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type ConnectReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -173,12 +421,16 @@ type ConnectReq struct {
 	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Cookie string `protobuf:"bytes,2,opt,name=cookie,proto3" json:"cookie,omitempty"`
 	Token  []byte `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// the last offline seq the client received, the offline msgs up to it are dropped
+	LastSeq int64 `protobuf:"varint,4,opt,name=lastSeq,proto3" json:"lastSeq,omitempty"`
+	// the max and ttl of the resume slot opened for the session, none if nil
+	Resume *ResumeSlot `protobuf:"bytes,5,opt,name=resume,proto3" json:"resume,omitempty"`
 }
 
 func (x *ConnectReq) Reset() {
 	*x = ConnectReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[1]
+		mi := &file_logic_logic_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -191,7 +443,7 @@ func (x *ConnectReq) String() string {
 func (*ConnectReq) ProtoMessage() {}
 
 func (x *ConnectReq) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[1]
+	mi := &file_logic_logic_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +456,7 @@ func (x *ConnectReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectReq.ProtoReflect.Descriptor instead.
 func (*ConnectReq) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{1}
+	return file_logic_logic_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ConnectReq) GetServer() string {
@@ -228,6 +480,20 @@ func (x *ConnectReq) GetToken() []byte {
 	return nil
 }
 
+func (x *ConnectReq) GetLastSeq() int64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+func (x *ConnectReq) GetResume() *ResumeSlot {
+	if x != nil {
+		return x.Resume
+	}
+	return nil
+}
+
 type ConnectReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -238,12 +504,18 @@ type ConnectReply struct {
 	RoomID    string  `protobuf:"bytes,3,opt,name=roomID,proto3" json:"roomID,omitempty"`
 	Accepts   []int32 `protobuf:"varint,4,rep,packed,name=accepts,proto3" json:"accepts,omitempty"`
 	Heartbeat int64   `protobuf:"varint,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	// the token of the resume slot of the session, empty if none
+	ResumeToken string `protobuf:"bytes,6,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"`
+	// the frames of the offline msgs of the mid, pushed once the key is registered
+	Offline []byte `protobuf:"bytes,7,opt,name=offline,proto3" json:"offline,omitempty"`
+	// the seq of the last push of the key by its former conns, the key pushes go on after it
+	KeySeq int32 `protobuf:"varint,8,opt,name=keySeq,proto3" json:"keySeq,omitempty"`
 }
 
 func (x *ConnectReply) Reset() {
 	*x = ConnectReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[2]
+		mi := &file_logic_logic_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -256,7 +528,7 @@ func (x *ConnectReply) String() string {
 func (*ConnectReply) ProtoMessage() {}
 
 func (x *ConnectReply) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[2]
+	mi := &file_logic_logic_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +541,7 @@ func (x *ConnectReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectReply.ProtoReflect.Descriptor instead.
 func (*ConnectReply) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{2}
+	return file_logic_logic_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ConnectReply) GetMid() int64 {
@@ -307,20 +579,44 @@ func (x *ConnectReply) GetHeartbeat() int64 {
 	return 0
 }
 
+func (x *ConnectReply) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *ConnectReply) GetOffline() []byte {
+	if x != nil {
+		return x.Offline
+	}
+	return nil
+}
+
+func (x *ConnectReply) GetKeySeq() int32 {
+	if x != nil {
+		return x.KeySeq
+	}
+	return 0
+}
+
 type DisconnectReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Mid    int64  `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
-	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Server string `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	Mid    int64       `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Key    string      `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Server string      `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	Slot   *ResumeSlot `protobuf:"bytes,4,opt,name=slot,proto3" json:"slot,omitempty"`
+	// the seq of the last push of the key
+	KeySeq int32 `protobuf:"varint,5,opt,name=keySeq,proto3" json:"keySeq,omitempty"`
 }
 
 func (x *DisconnectReq) Reset() {
 	*x = DisconnectReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[3]
+		mi := &file_logic_logic_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -333,7 +629,7 @@ func (x *DisconnectReq) String() string {
 func (*DisconnectReq) ProtoMessage() {}
 
 func (x *DisconnectReq) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[3]
+	mi := &file_logic_logic_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -346,7 +642,7 @@ func (x *DisconnectReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectReq.ProtoReflect.Descriptor instead.
 func (*DisconnectReq) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{3}
+	return file_logic_logic_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DisconnectReq) GetMid() int64 {
@@ -370,31 +666,46 @@ func (x *DisconnectReq) GetServer() string {
 	return ""
 }
 
-type DisconnectReply struct {
+func (x *DisconnectReq) GetSlot() *ResumeSlot {
+	if x != nil {
+		return x.Slot
+	}
+	return nil
+}
+
+func (x *DisconnectReq) GetKeySeq() int32 {
+	if x != nil {
+		return x.KeySeq
+	}
+	return 0
+}
+
+type ResumeEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Has bool `protobuf:"varint,1,opt,name=has,proto3" json:"has,omitempty"`
+	Seq  int64  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *DisconnectReply) Reset() {
-	*x = DisconnectReply{}
+func (x *ResumeEntry) Reset() {
+	*x = ResumeEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[4]
+		mi := &file_logic_logic_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DisconnectReply) String() string {
+func (x *ResumeEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisconnectReply) ProtoMessage() {}
+func (*ResumeEntry) ProtoMessage() {}
 
-func (x *DisconnectReply) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[4]
+func (x *ResumeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,45 +716,58 @@ func (x *DisconnectReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisconnectReply.ProtoReflect.Descriptor instead.
-func (*DisconnectReply) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ResumeEntry.ProtoReflect.Descriptor instead.
+func (*ResumeEntry) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DisconnectReply) GetHas() bool {
+func (x *ResumeEntry) GetSeq() int64 {
 	if x != nil {
-		return x.Has
+		return x.Seq
 	}
-	return false
+	return 0
 }
 
-type HeartbeatReq struct {
+func (x *ResumeEntry) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ResumeSlot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Mid    int64  `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
-	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Server string `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	Token   string         `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Room    string         `protobuf:"bytes,2,opt,name=room,proto3" json:"room,omitempty"`
+	Accepts []int32        `protobuf:"varint,3,rep,packed,name=accepts,proto3" json:"accepts,omitempty"`
+	Seq     int64          `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	Entries []*ResumeEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	Ttl     int64          `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Max     int32          `protobuf:"varint,7,opt,name=max,proto3" json:"max,omitempty"`
+	// the seq of the last push of the key
+	KeySeq int32 `protobuf:"varint,8,opt,name=keySeq,proto3" json:"keySeq,omitempty"`
 }
 
-func (x *HeartbeatReq) Reset() {
-	*x = HeartbeatReq{}
+func (x *ResumeSlot) Reset() {
+	*x = ResumeSlot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[5]
+		mi := &file_logic_logic_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HeartbeatReq) String() string {
+func (x *ResumeSlot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeartbeatReq) ProtoMessage() {}
+func (*ResumeSlot) ProtoMessage() {}
 
-func (x *HeartbeatReq) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[5]
+func (x *ResumeSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -454,81 +778,82 @@ func (x *HeartbeatReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeartbeatReq.ProtoReflect.Descriptor instead.
-func (*HeartbeatReq) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ResumeSlot.ProtoReflect.Descriptor instead.
+func (*ResumeSlot) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *HeartbeatReq) GetMid() int64 {
+func (x *ResumeSlot) GetToken() string {
 	if x != nil {
-		return x.Mid
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-func (x *HeartbeatReq) GetKey() string {
+func (x *ResumeSlot) GetRoom() string {
 	if x != nil {
-		return x.Key
+		return x.Room
 	}
 	return ""
 }
 
-func (x *HeartbeatReq) GetServer() string {
+func (x *ResumeSlot) GetAccepts() []int32 {
 	if x != nil {
-		return x.Server
+		return x.Accepts
 	}
-	return ""
+	return nil
 }
 
-type HeartbeatReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ResumeSlot) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
 }
 
-func (x *HeartbeatReply) Reset() {
-	*x = HeartbeatReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ResumeSlot) GetEntries() []*ResumeEntry {
+	if x != nil {
+		return x.Entries
 	}
+	return nil
 }
 
-func (x *HeartbeatReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ResumeSlot) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
 }
 
-func (*HeartbeatReply) ProtoMessage() {}
-
-func (x *HeartbeatReply) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ResumeSlot) GetMax() int32 {
+	if x != nil {
+		return x.Max
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use HeartbeatReply.ProtoReflect.Descriptor instead.
-func (*HeartbeatReply) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{6}
+func (x *ResumeSlot) GetKeySeq() int32 {
+	if x != nil {
+		return x.KeySeq
+	}
+	return 0
 }
 
-type OnlineReq struct {
+type ResumeReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Server    string           `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
-	RoomCount map[string]int32 `protobuf:"bytes,2,rep,name=roomCount,proto3" json:"roomCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Token  string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Seq    int64  `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	// the max and ttl of the resume slot opened for the resumed session
+	Resume *ResumeSlot `protobuf:"bytes,5,opt,name=resume,proto3" json:"resume,omitempty"`
 }
 
-func (x *OnlineReq) Reset() {
-	*x = OnlineReq{}
+func (x *ResumeReq) Reset() {
+	*x = ResumeReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_logic_logic_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -536,13 +861,13 @@ func (x *OnlineReq) Reset() {
 	}
 }
 
-func (x *OnlineReq) String() string {
+func (x *ResumeReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OnlineReq) ProtoMessage() {}
+func (*ResumeReq) ProtoMessage() {}
 
-func (x *OnlineReq) ProtoReflect() protoreflect.Message {
+func (x *ResumeReq) ProtoReflect() protoreflect.Message {
 	mi := &file_logic_logic_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -554,35 +879,69 @@ func (x *OnlineReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OnlineReq.ProtoReflect.Descriptor instead.
-func (*OnlineReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResumeReq.ProtoReflect.Descriptor instead.
+func (*ResumeReq) Descriptor() ([]byte, []int) {
 	return file_logic_logic_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *OnlineReq) GetServer() string {
+func (x *ResumeReq) GetServer() string {
 	if x != nil {
 		return x.Server
 	}
 	return ""
 }
 
-func (x *OnlineReq) GetRoomCount() map[string]int32 {
+func (x *ResumeReq) GetKey() string {
 	if x != nil {
-		return x.RoomCount
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ResumeReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResumeReq) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ResumeReq) GetResume() *ResumeSlot {
+	if x != nil {
+		return x.Resume
 	}
 	return nil
 }
 
-type OnlineReply struct {
+type ResumeReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AllRoomCount map[string]int32 `protobuf:"bytes,1,rep,name=allRoomCount,proto3" json:"allRoomCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Mid       int64    `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Key       string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	RoomID    string   `protobuf:"bytes,3,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Accepts   []int32  `protobuf:"varint,4,rep,packed,name=accepts,proto3" json:"accepts,omitempty"`
+	Heartbeat int64    `protobuf:"varint,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Seq       int64    `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+	Data      [][]byte `protobuf:"bytes,7,rep,name=data,proto3" json:"data,omitempty"`
+	// the token of the new resume slot of the session
+	Token string `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"`
+	// the seq of the last push of the key, the key pushes go on after it
+	KeySeq int32 `protobuf:"varint,9,opt,name=keySeq,proto3" json:"keySeq,omitempty"`
+	// the seq before the first replayed proto, seq if none, a gap if it is
+	// after the seq of the client as the slot lost the protos between
+	First int64 `protobuf:"varint,10,opt,name=first,proto3" json:"first,omitempty"`
 }
 
-func (x *OnlineReply) Reset() {
-	*x = OnlineReply{}
+func (x *ResumeReply) Reset() {
+	*x = ResumeReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_logic_logic_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -590,13 +949,13 @@ func (x *OnlineReply) Reset() {
 	}
 }
 
-func (x *OnlineReply) String() string {
+func (x *ResumeReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OnlineReply) ProtoMessage() {}
+func (*ResumeReply) ProtoMessage() {}
 
-func (x *OnlineReply) ProtoReflect() protoreflect.Message {
+func (x *ResumeReply) ProtoReflect() protoreflect.Message {
 	mi := &file_logic_logic_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -608,96 +967,106 @@ func (x *OnlineReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OnlineReply.ProtoReflect.Descriptor instead.
-func (*OnlineReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResumeReply.ProtoReflect.Descriptor instead.
+func (*ResumeReply) Descriptor() ([]byte, []int) {
 	return file_logic_logic_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *OnlineReply) GetAllRoomCount() map[string]int32 {
+func (x *ResumeReply) GetMid() int64 {
 	if x != nil {
-		return x.AllRoomCount
+		return x.Mid
 	}
-	return nil
+	return 0
 }
 
-type ReceiveReq struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ResumeReply) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
 
-	Mid   int64           `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
-	Proto *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+func (x *ResumeReply) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
 }
 
-func (x *ReceiveReq) Reset() {
-	*x = ReceiveReq{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ResumeReply) GetAccepts() []int32 {
+	if x != nil {
+		return x.Accepts
 	}
+	return nil
 }
 
-func (x *ReceiveReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ResumeReply) GetHeartbeat() int64 {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return 0
 }
 
-func (*ReceiveReq) ProtoMessage() {}
+func (x *ResumeReply) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
 
-func (x *ReceiveReq) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ResumeReply) GetData() [][]byte {
+	if x != nil {
+		return x.Data
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ReceiveReq.ProtoReflect.Descriptor instead.
-func (*ReceiveReq) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{9}
+func (x *ResumeReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
 }
 
-func (x *ReceiveReq) GetMid() int64 {
+func (x *ResumeReply) GetKeySeq() int32 {
 	if x != nil {
-		return x.Mid
+		return x.KeySeq
 	}
 	return 0
 }
 
-func (x *ReceiveReq) GetProto() *protocol.Proto {
+func (x *ResumeReply) GetFirst() int64 {
 	if x != nil {
-		return x.Proto
+		return x.First
 	}
-	return nil
+	return 0
 }
 
-type ReceiveReply struct {
+type DisconnectReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Has bool `protobuf:"varint,1,opt,name=has,proto3" json:"has,omitempty"`
 }
 
-func (x *ReceiveReply) Reset() {
-	*x = ReceiveReply{}
+func (x *DisconnectReply) Reset() {
+	*x = DisconnectReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[10]
+		mi := &file_logic_logic_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReceiveReply) String() string {
+func (x *DisconnectReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReceiveReply) ProtoMessage() {}
+func (*DisconnectReply) ProtoMessage() {}
 
-func (x *ReceiveReply) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[10]
+func (x *DisconnectReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,37 +1077,47 @@ func (x *ReceiveReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReceiveReply.ProtoReflect.Descriptor instead.
-func (*ReceiveReply) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DisconnectReply.ProtoReflect.Descriptor instead.
+func (*DisconnectReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{9}
 }
 
-type NodesReq struct {
+func (x *DisconnectReply) GetHas() bool {
+	if x != nil {
+		return x.Has
+	}
+	return false
+}
+
+type HeartbeatReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Platform string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
-	ClientIP string `protobuf:"bytes,2,opt,name=clientIP,proto3" json:"clientIP,omitempty"`
+	Mid    int64  `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Server string `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	// the seq of the last push of the key
+	KeySeq int32 `protobuf:"varint,4,opt,name=keySeq,proto3" json:"keySeq,omitempty"`
 }
 
-func (x *NodesReq) Reset() {
-	*x = NodesReq{}
+func (x *HeartbeatReq) Reset() {
+	*x = HeartbeatReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[11]
+		mi := &file_logic_logic_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NodesReq) String() string {
+func (x *HeartbeatReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodesReq) ProtoMessage() {}
+func (*HeartbeatReq) ProtoMessage() {}
 
-func (x *NodesReq) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[11]
+func (x *HeartbeatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -749,57 +1128,62 @@ func (x *NodesReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodesReq.ProtoReflect.Descriptor instead.
-func (*NodesReq) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use HeartbeatReq.ProtoReflect.Descriptor instead.
+func (*HeartbeatReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *NodesReq) GetPlatform() string {
+func (x *HeartbeatReq) GetMid() int64 {
 	if x != nil {
-		return x.Platform
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *HeartbeatReq) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
 	return ""
 }
 
-func (x *NodesReq) GetClientIP() string {
+func (x *HeartbeatReq) GetServer() string {
 	if x != nil {
-		return x.ClientIP
+		return x.Server
 	}
 	return ""
 }
 
-type NodesReply struct {
+func (x *HeartbeatReq) GetKeySeq() int32 {
+	if x != nil {
+		return x.KeySeq
+	}
+	return 0
+}
+
+type HeartbeatReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Domain       string   `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
-	TcpPort      int32    `protobuf:"varint,2,opt,name=tcp_port,json=tcpPort,proto3" json:"tcp_port,omitempty"`
-	WsPort       int32    `protobuf:"varint,3,opt,name=ws_port,json=wsPort,proto3" json:"ws_port,omitempty"`
-	WssPort      int32    `protobuf:"varint,4,opt,name=wss_port,json=wssPort,proto3" json:"wss_port,omitempty"`
-	Heartbeat    int32    `protobuf:"varint,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
-	Nodes        []string `protobuf:"bytes,6,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Backoff      *Backoff `protobuf:"bytes,7,opt,name=backoff,proto3" json:"backoff,omitempty"`
-	HeartbeatMax int32    `protobuf:"varint,8,opt,name=heartbeat_max,json=heartbeatMax,proto3" json:"heartbeat_max,omitempty"`
 }
 
-func (x *NodesReply) Reset() {
-	*x = NodesReply{}
+func (x *HeartbeatReply) Reset() {
+	*x = HeartbeatReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[12]
+		mi := &file_logic_logic_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NodesReply) String() string {
+func (x *HeartbeatReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodesReply) ProtoMessage() {}
+func (*HeartbeatReply) ProtoMessage() {}
 
-func (x *NodesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[12]
+func (x *HeartbeatReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -810,338 +1194,3189 @@ func (x *NodesReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodesReply.ProtoReflect.Descriptor instead.
-func (*NodesReply) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use HeartbeatReply.ProtoReflect.Descriptor instead.
+func (*HeartbeatReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *NodesReply) GetDomain() string {
-	if x != nil {
-		return x.Domain
+type OnlineReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server    string           `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	RoomCount map[string]int32 `protobuf:"bytes,2,rep,name=roomCount,proto3" json:"roomCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *OnlineReq) Reset() {
+	*x = OnlineReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *NodesReply) GetTcpPort() int32 {
+func (x *OnlineReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineReq) ProtoMessage() {}
+
+func (x *OnlineReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineReq.ProtoReflect.Descriptor instead.
+func (*OnlineReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OnlineReq) GetServer() string {
 	if x != nil {
-		return x.TcpPort
+		return x.Server
 	}
-	return 0
+	return ""
 }
 
-func (x *NodesReply) GetWsPort() int32 {
+func (x *OnlineReq) GetRoomCount() map[string]int32 {
 	if x != nil {
-		return x.WsPort
+		return x.RoomCount
 	}
-	return 0
+	return nil
 }
 
-func (x *NodesReply) GetWssPort() int32 {
+type OnlineReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AllRoomCount map[string]int32 `protobuf:"bytes,1,rep,name=allRoomCount,proto3" json:"allRoomCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *OnlineReply) Reset() {
+	*x = OnlineReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineReply) ProtoMessage() {}
+
+func (x *OnlineReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineReply.ProtoReflect.Descriptor instead.
+func (*OnlineReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OnlineReply) GetAllRoomCount() map[string]int32 {
 	if x != nil {
-		return x.WssPort
+		return x.AllRoomCount
 	}
-	return 0
+	return nil
 }
 
-func (x *NodesReply) GetHeartbeat() int32 {
+type ReceiveReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mid    int64           `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Proto  *protocol.Proto `protobuf:"bytes,2,opt,name=proto,proto3" json:"proto,omitempty"`
+	Key    string          `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Server string          `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	Room   string          `protobuf:"bytes,5,opt,name=room,proto3" json:"room,omitempty"`
+}
+
+func (x *ReceiveReq) Reset() {
+	*x = ReceiveReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReceiveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReq) ProtoMessage() {}
+
+func (x *ReceiveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReq.ProtoReflect.Descriptor instead.
+func (*ReceiveReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReceiveReq) GetMid() int64 {
 	if x != nil {
-		return x.Heartbeat
+		return x.Mid
 	}
 	return 0
 }
 
-func (x *NodesReply) GetNodes() []string {
+func (x *ReceiveReq) GetProto() *protocol.Proto {
 	if x != nil {
-		return x.Nodes
+		return x.Proto
 	}
 	return nil
 }
 
-func (x *NodesReply) GetBackoff() *Backoff {
+func (x *ReceiveReq) GetKey() string {
 	if x != nil {
-		return x.Backoff
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-func (x *NodesReply) GetHeartbeatMax() int32 {
+func (x *ReceiveReq) GetServer() string {
 	if x != nil {
-		return x.HeartbeatMax
+		return x.Server
 	}
-	return 0
+	return ""
 }
 
-type Backoff struct {
+func (x *ReceiveReq) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+type ReceiveReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	MaxDelay  int32   `protobuf:"varint,1,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"`
-	BaseDelay int32   `protobuf:"varint,2,opt,name=base_delay,json=baseDelay,proto3" json:"base_delay,omitempty"`
-	Factor    float32 `protobuf:"fixed32,3,opt,name=factor,proto3" json:"factor,omitempty"`
-	Jitter    float32 `protobuf:"fixed32,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+func (x *ReceiveReply) Reset() {
+	*x = ReceiveReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *Backoff) Reset() {
-	*x = Backoff{}
+func (x *ReceiveReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReply) ProtoMessage() {}
+
+func (x *ReceiveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReply.ProtoReflect.Descriptor instead.
+func (*ReceiveReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{15}
+}
+
+type AckReportReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server    string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Delivered int64  `protobuf:"varint,2,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Dropped   int64  `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Expired   int64  `protobuf:"varint,4,opt,name=expired,proto3" json:"expired,omitempty"`
+}
+
+func (x *AckReportReq) Reset() {
+	*x = AckReportReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logic_logic_proto_msgTypes[13]
+		mi := &file_logic_logic_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Backoff) String() string {
+func (x *AckReportReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Backoff) ProtoMessage() {}
+func (*AckReportReq) ProtoMessage() {}
 
-func (x *Backoff) ProtoReflect() protoreflect.Message {
-	mi := &file_logic_logic_proto_msgTypes[13]
+func (x *AckReportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckReportReq.ProtoReflect.Descriptor instead.
+func (*AckReportReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AckReportReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *AckReportReq) GetDelivered() int64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *AckReportReq) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *AckReportReq) GetExpired() int64 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+type AckReportReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AckReportReply) Reset() {
+	*x = AckReportReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AckReportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckReportReply) ProtoMessage() {}
+
+func (x *AckReportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Backoff.ProtoReflect.Descriptor instead.
-func (*Backoff) Descriptor() ([]byte, []int) {
-	return file_logic_logic_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *Backoff) GetMaxDelay() int32 {
-	if x != nil {
-		return x.MaxDelay
-	}
-	return 0
-}
-
-func (x *Backoff) GetBaseDelay() int32 {
-	if x != nil {
-		return x.BaseDelay
-	}
-	return 0
-}
-
-func (x *Backoff) GetFactor() float32 {
-	if x != nil {
-		return x.Factor
-	}
-	return 0
-}
-
-func (x *Backoff) GetJitter() float32 {
-	if x != nil {
-		return x.Jitter
-	}
-	return 0
-}
-
-var File_logic_logic_proto protoreflect.FileDescriptor
-
-var file_logic_logic_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
-	0x1a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x01, 0x0a, 0x07, 0x50, 0x75,
-	0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
-	0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x29, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x55, 0x53, 0x48, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
-	0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x52, 0x4f, 0x41, 0x44, 0x43,
-	0x41, 0x53, 0x54, 0x10, 0x02, 0x22, 0x52, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63,
-	0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6f,
-	0x6b, 0x69, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x82, 0x01, 0x0a, 0x0c, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73,
-	0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x22, 0x4b,
-	0x0a, 0x0d, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12,
-	0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69,
-	0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x23, 0x0a, 0x0f, 0x44,
-	0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x68, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x68, 0x61, 0x73,
-	0x22, 0x4a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71,
-	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d,
-	0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x10, 0x0a, 0x0e,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xa6,
-	0x01, 0x0a, 0x09, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
-	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x2e,
-	0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09,
-	0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x6f, 0x6f,
-	0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9e, 0x01, 0x0a, 0x0b, 0x4f, 0x6e, 0x6c, 0x69,
-	0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4e, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x52, 0x6f,
-	0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
-	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69,
-	0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x41, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x52, 0x6f,
-	0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x41, 0x6c, 0x6c, 0x52, 0x6f,
-	0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4b, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x0e, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x42, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1a, 0x0a,
-	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x22, 0xfc, 0x01, 0x0a, 0x0a, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x12, 0x19, 0x0a, 0x08, 0x74, 0x63, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x07, 0x74, 0x63, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x77,
-	0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x73,
-	0x50, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f,
-	0x64, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
-	0x69, 0x63, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b,
-	0x6f, 0x66, 0x66, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
-	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x72,
-	0x74, 0x62, 0x65, 0x61, 0x74, 0x4d, 0x61, 0x78, 0x22, 0x75, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b,
-	0x6f, 0x66, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79,
-	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12,
-	0x16, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x06, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65,
-	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x32,
-	0x8c, 0x03, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x12, 0x3d, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
-	0x69, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e,
-	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
-	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
-	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x43, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x19, 0x2e,
-	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x48, 0x65, 0x61, 0x72,
-	0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
-	0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4f, 0x6e,
-	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
-	0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x63,
-	0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
-	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
-	0x65, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
-	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x69,
-	0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x15,
-	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f,
-	0x67, 0x69, 0x63, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x29,
-	0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x63, 0x61,
-	0x71, 0x72, 0x6c, 0x2f, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x6f,
-	0x67, 0x69, 0x63, 0x3b, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
-}
-
-var (
-	file_logic_logic_proto_rawDescOnce sync.Once
-	file_logic_logic_proto_rawDescData = file_logic_logic_proto_rawDesc
-)
-
-func file_logic_logic_proto_rawDescGZIP() []byte {
-	file_logic_logic_proto_rawDescOnce.Do(func() {
-		file_logic_logic_proto_rawDescData = protoimpl.X.CompressGZIP(file_logic_logic_proto_rawDescData)
-	})
-	return file_logic_logic_proto_rawDescData
-}
-
-var file_logic_logic_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_logic_logic_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
-var file_logic_logic_proto_goTypes = []interface{}{
-	(PushMsg_Type)(0),       // 0: chime.logic.PushMsg.Type
-	(*PushMsg)(nil),         // 1: chime.logic.PushMsg
-	(*ConnectReq)(nil),      // 2: chime.logic.ConnectReq
-	(*ConnectReply)(nil),    // 3: chime.logic.ConnectReply
-	(*DisconnectReq)(nil),   // 4: chime.logic.DisconnectReq
-	(*DisconnectReply)(nil), // 5: chime.logic.DisconnectReply
-	(*HeartbeatReq)(nil),    // 6: chime.logic.HeartbeatReq
-	(*HeartbeatReply)(nil),  // 7: chime.logic.HeartbeatReply
-	(*OnlineReq)(nil),       // 8: chime.logic.OnlineReq
-	(*OnlineReply)(nil),     // 9: chime.logic.OnlineReply
-	(*ReceiveReq)(nil),      // 10: chime.logic.ReceiveReq
-	(*ReceiveReply)(nil),    // 11: chime.logic.ReceiveReply
-	(*NodesReq)(nil),        // 12: chime.logic.NodesReq
-	(*NodesReply)(nil),      // 13: chime.logic.NodesReply
-	(*Backoff)(nil),         // 14: chime.logic.Backoff
-	nil,                     // 15: chime.logic.OnlineReq.RoomCountEntry
-	nil,                     // 16: chime.logic.OnlineReply.AllRoomCountEntry
-	(*protocol.Proto)(nil),  // 17: chime.protocol.Proto
-}
-var file_logic_logic_proto_depIdxs = []int32{
-	0,  // 0: chime.logic.PushMsg.type:type_name -> chime.logic.PushMsg.Type
-	15, // 1: chime.logic.OnlineReq.roomCount:type_name -> chime.logic.OnlineReq.RoomCountEntry
-	16, // 2: chime.logic.OnlineReply.allRoomCount:type_name -> chime.logic.OnlineReply.AllRoomCountEntry
-	17, // 3: chime.logic.ReceiveReq.proto:type_name -> chime.protocol.Proto
-	14, // 4: chime.logic.NodesReply.backoff:type_name -> chime.logic.Backoff
-	2,  // 5: chime.logic.Logic.Connect:input_type -> chime.logic.ConnectReq
-	4,  // 6: chime.logic.Logic.Disconnect:input_type -> chime.logic.DisconnectReq
-	6,  // 7: chime.logic.Logic.Heartbeat:input_type -> chime.logic.HeartbeatReq
-	8,  // 8: chime.logic.Logic.RenewOnline:input_type -> chime.logic.OnlineReq
-	10, // 9: chime.logic.Logic.Receive:input_type -> chime.logic.ReceiveReq
-	12, // 10: chime.logic.Logic.Nodes:input_type -> chime.logic.NodesReq
-	3,  // 11: chime.logic.Logic.Connect:output_type -> chime.logic.ConnectReply
-	5,  // 12: chime.logic.Logic.Disconnect:output_type -> chime.logic.DisconnectReply
-	7,  // 13: chime.logic.Logic.Heartbeat:output_type -> chime.logic.HeartbeatReply
-	9,  // 14: chime.logic.Logic.RenewOnline:output_type -> chime.logic.OnlineReply
-	11, // 15: chime.logic.Logic.Receive:output_type -> chime.logic.ReceiveReply
-	13, // 16: chime.logic.Logic.Nodes:output_type -> chime.logic.NodesReply
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
-}
-
-func init() { file_logic_logic_proto_init() }
-func file_logic_logic_proto_init() {
-	if File_logic_logic_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_logic_logic_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushMsg); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckReportReply.ProtoReflect.Descriptor instead.
+func (*AckReportReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{17}
+}
+
+type SlowReportReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server       string           `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Keys         map[string]int64 `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Dropped      int64            `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Spilled      int64            `protobuf:"varint,4,opt,name=spilled,proto3" json:"spilled,omitempty"`
+	Disconnected int64            `protobuf:"varint,5,opt,name=disconnected,proto3" json:"disconnected,omitempty"`
+}
+
+func (x *SlowReportReq) Reset() {
+	*x = SlowReportReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlowReportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowReportReq) ProtoMessage() {}
+
+func (x *SlowReportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowReportReq.ProtoReflect.Descriptor instead.
+func (*SlowReportReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SlowReportReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *SlowReportReq) GetKeys() map[string]int64 {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *SlowReportReq) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *SlowReportReq) GetSpilled() int64 {
+	if x != nil {
+		return x.Spilled
+	}
+	return 0
+}
+
+func (x *SlowReportReq) GetDisconnected() int64 {
+	if x != nil {
+		return x.Disconnected
+	}
+	return 0
+}
+
+type SlowReportReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SlowReportReply) Reset() {
+	*x = SlowReportReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlowReportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlowReportReply) ProtoMessage() {}
+
+func (x *SlowReportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlowReportReply.ProtoReflect.Descriptor instead.
+func (*SlowReportReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{19}
+}
+
+type DeliveryCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delivered int64 `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Dropped   int64 `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *DeliveryCount) Reset() {
+	*x = DeliveryCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryCount) ProtoMessage() {}
+
+func (x *DeliveryCount) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryCount.ProtoReflect.Descriptor instead.
+func (*DeliveryCount) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeliveryCount) GetDelivered() int64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *DeliveryCount) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type DeliveryReportReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// push id -> counts since last report
+	Counts map[string]*DeliveryCount `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DeliveryReportReq) Reset() {
+	*x = DeliveryReportReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryReportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryReportReq) ProtoMessage() {}
+
+func (x *DeliveryReportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryReportReq.ProtoReflect.Descriptor instead.
+func (*DeliveryReportReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeliveryReportReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *DeliveryReportReq) GetCounts() map[string]*DeliveryCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type DeliveryReportReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeliveryReportReply) Reset() {
+	*x = DeliveryReportReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryReportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryReportReply) ProtoMessage() {}
+
+func (x *DeliveryReportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryReportReply.ProtoReflect.Descriptor instead.
+func (*DeliveryReportReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{22}
+}
+
+type SyncResumeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// the protos written since the last sync by key
+	Slots map[string]*ResumeSlot `protobuf:"bytes,2,rep,name=slots,proto3" json:"slots,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SyncResumeReq) Reset() {
+	*x = SyncResumeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncResumeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResumeReq) ProtoMessage() {}
+
+func (x *SyncResumeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResumeReq.ProtoReflect.Descriptor instead.
+func (*SyncResumeReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SyncResumeReq) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *SyncResumeReq) GetSlots() map[string]*ResumeSlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type SyncResumeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SyncResumeReply) Reset() {
+	*x = SyncResumeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncResumeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResumeReply) ProtoMessage() {}
+
+func (x *SyncResumeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResumeReply.ProtoReflect.Descriptor instead.
+func (*SyncResumeReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{24}
+}
+
+type KeyPresence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Mid       int64  `protobuf:"varint,2,opt,name=mid,proto3" json:"mid,omitempty"`
+	Online    bool   `protobuf:"varint,3,opt,name=online,proto3" json:"online,omitempty"`
+	Server    string `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	Room      string `protobuf:"bytes,5,opt,name=room,proto3" json:"room,omitempty"`
+	Platform  string `protobuf:"bytes,6,opt,name=platform,proto3" json:"platform,omitempty"`
+	Connected int64  `protobuf:"varint,7,opt,name=connected,proto3" json:"connected,omitempty"`
+}
+
+func (x *KeyPresence) Reset() {
+	*x = KeyPresence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyPresence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPresence) ProtoMessage() {}
+
+func (x *KeyPresence) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPresence.ProtoReflect.Descriptor instead.
+func (*KeyPresence) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *KeyPresence) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyPresence) GetMid() int64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *KeyPresence) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *KeyPresence) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *KeyPresence) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *KeyPresence) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *KeyPresence) GetConnected() int64 {
+	if x != nil {
+		return x.Connected
+	}
+	return 0
+}
+
+type MidPresence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mid    int64          `protobuf:"varint,1,opt,name=mid,proto3" json:"mid,omitempty"`
+	Online bool           `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	Keys   []*KeyPresence `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *MidPresence) Reset() {
+	*x = MidPresence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MidPresence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MidPresence) ProtoMessage() {}
+
+func (x *MidPresence) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MidPresence.ProtoReflect.Descriptor instead.
+func (*MidPresence) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MidPresence) GetMid() int64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *MidPresence) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *MidPresence) GetKeys() []*KeyPresence {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type OnlineMidsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mids []int64 `protobuf:"varint,1,rep,packed,name=mids,proto3" json:"mids,omitempty"`
+	Info bool    `protobuf:"varint,2,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *OnlineMidsReq) Reset() {
+	*x = OnlineMidsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineMidsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineMidsReq) ProtoMessage() {}
+
+func (x *OnlineMidsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineMidsReq.ProtoReflect.Descriptor instead.
+func (*OnlineMidsReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OnlineMidsReq) GetMids() []int64 {
+	if x != nil {
+		return x.Mids
+	}
+	return nil
+}
+
+func (x *OnlineMidsReq) GetInfo() bool {
+	if x != nil {
+		return x.Info
+	}
+	return false
+}
+
+type OnlineMidsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mids []*MidPresence `protobuf:"bytes,1,rep,name=mids,proto3" json:"mids,omitempty"`
+}
+
+func (x *OnlineMidsReply) Reset() {
+	*x = OnlineMidsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineMidsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineMidsReply) ProtoMessage() {}
+
+func (x *OnlineMidsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineMidsReply.ProtoReflect.Descriptor instead.
+func (*OnlineMidsReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *OnlineMidsReply) GetMids() []*MidPresence {
+	if x != nil {
+		return x.Mids
+	}
+	return nil
+}
+
+type OnlineKeysReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Info bool     `protobuf:"varint,2,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *OnlineKeysReq) Reset() {
+	*x = OnlineKeysReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineKeysReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineKeysReq) ProtoMessage() {}
+
+func (x *OnlineKeysReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineKeysReq.ProtoReflect.Descriptor instead.
+func (*OnlineKeysReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OnlineKeysReq) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *OnlineKeysReq) GetInfo() bool {
+	if x != nil {
+		return x.Info
+	}
+	return false
+}
+
+type OnlineKeysReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []*KeyPresence `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *OnlineKeysReply) Reset() {
+	*x = OnlineKeysReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineKeysReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineKeysReply) ProtoMessage() {}
+
+func (x *OnlineKeysReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineKeysReply.ProtoReflect.Descriptor instead.
+func (*OnlineKeysReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OnlineKeysReply) GetKeys() []*KeyPresence {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type PushKeysReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op             int32            `protobuf:"varint,1,opt,name=op,proto3" json:"op,omitempty"`
+	Keys           []string         `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Msg            []byte           `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Ack            bool             `protobuf:"varint,4,opt,name=ack,proto3" json:"ack,omitempty"`
+	IdempotencyKey string           `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	NotBefore      int64            `protobuf:"varint,6,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	ExpireAt       int64            `protobuf:"varint,7,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Priority       PushMsg_Priority `protobuf:"varint,8,opt,name=priority,proto3,enum=chime.logic.PushMsg_Priority" json:"priority,omitempty"`
+}
+
+func (x *PushKeysReq) Reset() {
+	*x = PushKeysReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushKeysReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushKeysReq) ProtoMessage() {}
+
+func (x *PushKeysReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushKeysReq.ProtoReflect.Descriptor instead.
+func (*PushKeysReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PushKeysReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *PushKeysReq) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *PushKeysReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *PushKeysReq) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *PushKeysReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PushKeysReq) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *PushKeysReq) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *PushKeysReq) GetPriority() PushMsg_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return PushMsg_DEFAULT
+}
+
+type PushKeysReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PushKeysReply) Reset() {
+	*x = PushKeysReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushKeysReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushKeysReply) ProtoMessage() {}
+
+func (x *PushKeysReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushKeysReply.ProtoReflect.Descriptor instead.
+func (*PushKeysReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PushKeysReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PushMidsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op             int32            `protobuf:"varint,1,opt,name=op,proto3" json:"op,omitempty"`
+	Mids           []int64          `protobuf:"varint,2,rep,packed,name=mids,proto3" json:"mids,omitempty"`
+	Msg            []byte           `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Ack            bool             `protobuf:"varint,4,opt,name=ack,proto3" json:"ack,omitempty"`
+	Offline        bool             `protobuf:"varint,5,opt,name=offline,proto3" json:"offline,omitempty"`
+	IdempotencyKey string           `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	NotBefore      int64            `protobuf:"varint,7,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	ExpireAt       int64            `protobuf:"varint,8,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Priority       PushMsg_Priority `protobuf:"varint,9,opt,name=priority,proto3,enum=chime.logic.PushMsg_Priority" json:"priority,omitempty"`
+}
+
+func (x *PushMidsReq) Reset() {
+	*x = PushMidsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMidsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMidsReq) ProtoMessage() {}
+
+func (x *PushMidsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMidsReq.ProtoReflect.Descriptor instead.
+func (*PushMidsReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PushMidsReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *PushMidsReq) GetMids() []int64 {
+	if x != nil {
+		return x.Mids
+	}
+	return nil
+}
+
+func (x *PushMidsReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *PushMidsReq) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *PushMidsReq) GetOffline() bool {
+	if x != nil {
+		return x.Offline
+	}
+	return false
+}
+
+func (x *PushMidsReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PushMidsReq) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *PushMidsReq) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *PushMidsReq) GetPriority() PushMsg_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return PushMsg_DEFAULT
+}
+
+type PushMidsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PushMidsReply) Reset() {
+	*x = PushMidsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMidsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMidsReply) ProtoMessage() {}
+
+func (x *PushMidsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMidsReply.ProtoReflect.Descriptor instead.
+func (*PushMidsReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PushMidsReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PushRoomReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op             int32            `protobuf:"varint,1,opt,name=op,proto3" json:"op,omitempty"`
+	Type           string           `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Room           string           `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"`
+	Msg            []byte           `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+	IdempotencyKey string           `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	NotBefore      int64            `protobuf:"varint,6,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	ExpireAt       int64            `protobuf:"varint,7,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Priority       PushMsg_Priority `protobuf:"varint,8,opt,name=priority,proto3,enum=chime.logic.PushMsg_Priority" json:"priority,omitempty"`
+}
+
+func (x *PushRoomReq) Reset() {
+	*x = PushRoomReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRoomReq) ProtoMessage() {}
+
+func (x *PushRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushRoomReq.ProtoReflect.Descriptor instead.
+func (*PushRoomReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PushRoomReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *PushRoomReq) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PushRoomReq) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *PushRoomReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *PushRoomReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PushRoomReq) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *PushRoomReq) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *PushRoomReq) GetPriority() PushMsg_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return PushMsg_DEFAULT
+}
+
+type PushRoomReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PushRoomReply) Reset() {
+	*x = PushRoomReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushRoomReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRoomReply) ProtoMessage() {}
+
+func (x *PushRoomReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushRoomReply.ProtoReflect.Descriptor instead.
+func (*PushRoomReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PushRoomReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PushAllReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op             int32            `protobuf:"varint,1,opt,name=op,proto3" json:"op,omitempty"`
+	Speed          int32            `protobuf:"varint,2,opt,name=speed,proto3" json:"speed,omitempty"`
+	Msg            []byte           `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	IdempotencyKey string           `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	NotBefore      int64            `protobuf:"varint,5,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	ExpireAt       int64            `protobuf:"varint,6,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Priority       PushMsg_Priority `protobuf:"varint,7,opt,name=priority,proto3,enum=chime.logic.PushMsg_Priority" json:"priority,omitempty"`
+}
+
+func (x *PushAllReq) Reset() {
+	*x = PushAllReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushAllReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushAllReq) ProtoMessage() {}
+
+func (x *PushAllReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushAllReq.ProtoReflect.Descriptor instead.
+func (*PushAllReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PushAllReq) GetOp() int32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *PushAllReq) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *PushAllReq) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *PushAllReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PushAllReq) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *PushAllReq) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *PushAllReq) GetPriority() PushMsg_Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return PushMsg_DEFAULT
+}
+
+type PushAllReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PushAllReply) Reset() {
+	*x = PushAllReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushAllReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushAllReply) ProtoMessage() {}
+
+func (x *PushAllReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushAllReply.ProtoReflect.Descriptor instead.
+func (*PushAllReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PushAllReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type OnlineTopReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Limit int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *OnlineTopReq) Reset() {
+	*x = OnlineTopReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineTopReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineTopReq) ProtoMessage() {}
+
+func (x *OnlineTopReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineTopReq.ProtoReflect.Descriptor instead.
+func (*OnlineTopReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *OnlineTopReq) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OnlineTopReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RoomTop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Count  int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *RoomTop) Reset() {
+	*x = RoomTop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomTop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomTop) ProtoMessage() {}
+
+func (x *RoomTop) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomTop.ProtoReflect.Descriptor instead.
+func (*RoomTop) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RoomTop) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *RoomTop) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type OnlineTopReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tops []*RoomTop `protobuf:"bytes,1,rep,name=tops,proto3" json:"tops,omitempty"`
+}
+
+func (x *OnlineTopReply) Reset() {
+	*x = OnlineTopReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineTopReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineTopReply) ProtoMessage() {}
+
+func (x *OnlineTopReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineTopReply.ProtoReflect.Descriptor instead.
+func (*OnlineTopReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *OnlineTopReply) GetTops() []*RoomTop {
+	if x != nil {
+		return x.Tops
+	}
+	return nil
+}
+
+type OnlineRoomReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Rooms []string `protobuf:"bytes,2,rep,name=rooms,proto3" json:"rooms,omitempty"`
+}
+
+func (x *OnlineRoomReq) Reset() {
+	*x = OnlineRoomReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineRoomReq) ProtoMessage() {}
+
+func (x *OnlineRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineRoomReq.ProtoReflect.Descriptor instead.
+func (*OnlineRoomReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *OnlineRoomReq) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OnlineRoomReq) GetRooms() []string {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type OnlineRoomReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rooms map[string]int32 `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *OnlineRoomReply) Reset() {
+	*x = OnlineRoomReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineRoomReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineRoomReply) ProtoMessage() {}
+
+func (x *OnlineRoomReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineRoomReply.ProtoReflect.Descriptor instead.
+func (*OnlineRoomReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *OnlineRoomReply) GetRooms() map[string]int32 {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type OnlineTotalReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OnlineTotalReq) Reset() {
+	*x = OnlineTotalReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineTotalReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineTotalReq) ProtoMessage() {}
+
+func (x *OnlineTotalReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineTotalReq.ProtoReflect.Descriptor instead.
+func (*OnlineTotalReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{44}
+}
+
+type OnlineTotalReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IpCount   int64 `protobuf:"varint,1,opt,name=ipCount,proto3" json:"ipCount,omitempty"`
+	ConnCount int64 `protobuf:"varint,2,opt,name=connCount,proto3" json:"connCount,omitempty"`
+}
+
+func (x *OnlineTotalReply) Reset() {
+	*x = OnlineTotalReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnlineTotalReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineTotalReply) ProtoMessage() {}
+
+func (x *OnlineTotalReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineTotalReply.ProtoReflect.Descriptor instead.
+func (*OnlineTotalReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *OnlineTotalReply) GetIpCount() int64 {
+	if x != nil {
+		return x.IpCount
+	}
+	return 0
+}
+
+func (x *OnlineTotalReply) GetConnCount() int64 {
+	if x != nil {
+		return x.ConnCount
+	}
+	return 0
+}
+
+type NodesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platform string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	ClientIP string `protobuf:"bytes,2,opt,name=clientIP,proto3" json:"clientIP,omitempty"`
+}
+
+func (x *NodesReq) Reset() {
+	*x = NodesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodesReq) ProtoMessage() {}
+
+func (x *NodesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodesReq.ProtoReflect.Descriptor instead.
+func (*NodesReq) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *NodesReq) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *NodesReq) GetClientIP() string {
+	if x != nil {
+		return x.ClientIP
+	}
+	return ""
+}
+
+type NodesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain       string   `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	TcpPort      int32    `protobuf:"varint,2,opt,name=tcp_port,json=tcpPort,proto3" json:"tcp_port,omitempty"`
+	WsPort       int32    `protobuf:"varint,3,opt,name=ws_port,json=wsPort,proto3" json:"ws_port,omitempty"`
+	WssPort      int32    `protobuf:"varint,4,opt,name=wss_port,json=wssPort,proto3" json:"wss_port,omitempty"`
+	Heartbeat    int32    `protobuf:"varint,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Nodes        []string `protobuf:"bytes,6,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Backoff      *Backoff `protobuf:"bytes,7,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	HeartbeatMax int32    `protobuf:"varint,8,opt,name=heartbeat_max,json=heartbeatMax,proto3" json:"heartbeat_max,omitempty"`
+}
+
+func (x *NodesReply) Reset() {
+	*x = NodesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodesReply) ProtoMessage() {}
+
+func (x *NodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodesReply.ProtoReflect.Descriptor instead.
+func (*NodesReply) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *NodesReply) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *NodesReply) GetTcpPort() int32 {
+	if x != nil {
+		return x.TcpPort
+	}
+	return 0
+}
+
+func (x *NodesReply) GetWsPort() int32 {
+	if x != nil {
+		return x.WsPort
+	}
+	return 0
+}
+
+func (x *NodesReply) GetWssPort() int32 {
+	if x != nil {
+		return x.WssPort
+	}
+	return 0
+}
+
+func (x *NodesReply) GetHeartbeat() int32 {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return 0
+}
+
+func (x *NodesReply) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *NodesReply) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+func (x *NodesReply) GetHeartbeatMax() int32 {
+	if x != nil {
+		return x.HeartbeatMax
+	}
+	return 0
+}
+
+type Backoff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxDelay  int32   `protobuf:"varint,1,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"`
+	BaseDelay int32   `protobuf:"varint,2,opt,name=base_delay,json=baseDelay,proto3" json:"base_delay,omitempty"`
+	Factor    float32 `protobuf:"fixed32,3,opt,name=factor,proto3" json:"factor,omitempty"`
+	Jitter    float32 `protobuf:"fixed32,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+}
+
+func (x *Backoff) Reset() {
+	*x = Backoff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logic_logic_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Backoff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backoff) ProtoMessage() {}
+
+func (x *Backoff) ProtoReflect() protoreflect.Message {
+	mi := &file_logic_logic_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backoff.ProtoReflect.Descriptor instead.
+func (*Backoff) Descriptor() ([]byte, []int) {
+	return file_logic_logic_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Backoff) GetMaxDelay() int32 {
+	if x != nil {
+		return x.MaxDelay
+	}
+	return 0
+}
+
+func (x *Backoff) GetBaseDelay() int32 {
+	if x != nil {
+		return x.BaseDelay
+	}
+	return 0
+}
+
+func (x *Backoff) GetFactor() float32 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+func (x *Backoff) GetJitter() float32 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+var File_logic_logic_proto protoreflect.FileDescriptor
+
+var file_logic_logic_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x1a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x85, 0x05, 0x0a, 0x07, 0x50, 0x75,
+	0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63,
+	0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f,
+	0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x5f, 0x61, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x69, 0x64, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x6d,
+	0x69, 0x64, 0x73, 0x22, 0x4b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x50,
+	0x55, 0x53, 0x48, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x01, 0x12,
+	0x0d, 0x0a, 0x09, 0x42, 0x52, 0x4f, 0x41, 0x44, 0x43, 0x41, 0x53, 0x54, 0x10, 0x02, 0x12, 0x08,
+	0x0a, 0x04, 0x4b, 0x49, 0x43, 0x4b, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x49, 0x47, 0x52,
+	0x41, 0x54, 0x45, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x05,
+	0x22, 0x2f, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0b, 0x0a, 0x07,
+	0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x41,
+	0x4c, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x55, 0x4c, 0x4b, 0x10,
+	0x02, 0x22, 0xa1, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x4d, 0x73, 0x67, 0x12, 0x26, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50,
+	0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x9d, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f,
+	0x6f, 0x6b, 0x69, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x65, 0x71, 0x12, 0x2f, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x22, 0xd6, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f,
+	0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x65, 0x71,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x65, 0x71, 0x22, 0x90,
+	0x01, 0x0a, 0x0d, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d,
+	0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x04,
+	0x73, 0x6c, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68, 0x69,
+	0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53,
+	0x6c, 0x6f, 0x74, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79,
+	0x53, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x65,
+	0x71, 0x22, 0x33, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd2, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x53, 0x6c, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x6f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x32, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63,
+	0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74,
+	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x6d, 0x61, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x65, 0x71, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x65, 0x71, 0x22, 0x8e, 0x01, 0x0a, 0x09,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x2f, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x68,
+	0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x53, 0x6c, 0x6f, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x22, 0xeb, 0x01, 0x0a,
+	0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x6b, 0x65, 0x79, 0x53, 0x65, 0x71, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6b, 0x65,
+	0x79, 0x53, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x22, 0x23, 0x0a, 0x0f, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x68, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x68, 0x61, 0x73, 0x22,
+	0x62, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6b,
+	0x65, 0x79, 0x53, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6b, 0x65, 0x79,
+	0x53, 0x65, 0x71, 0x22, 0x10, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xa6, 0x01, 0x0a, 0x09, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x09, 0x72,
+	0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9e,
+	0x01, 0x0a, 0x0b, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4e,
+	0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x41,
+	0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x3f,
+	0x0a, 0x11, 0x41, 0x6c, 0x6c, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x89, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64,
+	0x12, 0x2b, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x22, 0x0e, 0x0a, 0x0c, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x78, 0x0a, 0x0c, 0x41,
+	0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xf2, 0x01, 0x0a, 0x0d, 0x53, 0x6c, 0x6f, 0x77,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x38, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x53, 0x6c,
+	0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12,
+	0x22, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x11, 0x0a, 0x0f,
+	0x53, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x47, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0xc6, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x1a, 0x55, 0x0a, 0x0b, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x68, 0x69,
+	0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x15, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xb7, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x6e,
+	0x63, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x12, 0x3b, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
+	0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6c,
+	0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x1a,
+	0x51, 0x0a, 0x0a, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xaf, 0x01, 0x0a, 0x0b, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65,
+	0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f,
+	0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x65, 0x0a, 0x0b, 0x4d, 0x69, 0x64, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x2c, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4b, 0x65, 0x79,
+	0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x37,
+	0x0a, 0x0d, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x6d,
+	0x69, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x3f, 0x0a, 0x0f, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x4d, 0x69, 0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a, 0x04, 0x6d, 0x69,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
+	0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4d, 0x69, 0x64, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x04, 0x6d, 0x69, 0x64, 0x73, 0x22, 0x37, 0x0a, 0x0d, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x6e, 0x66,
+	0x6f, 0x22, 0x3f, 0x0a, 0x0f, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x2e, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x04, 0x6b, 0x65,
+	0x79, 0x73, 0x22, 0xf5, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x6f, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x12,
+	0x39, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
+	0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0d, 0x50, 0x75,
+	0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x8f, 0x02, 0x0a, 0x0b,
+	0x50, 0x75, 0x73, 0x68, 0x4d, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x6d, 0x69, 0x64, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73,
+	0x67, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x61, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f,
+	0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x41, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x1f, 0x0a,
+	0x0d, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x69, 0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xf7,
+	0x01, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d,
+	0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65,
+	0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x12, 0x39, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1d, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75,
+	0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0d, 0x50, 0x75, 0x73, 0x68,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xe4, 0x01, 0x0a, 0x0a, 0x50, 0x75,
+	0x73, 0x68, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x74,
+	0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e,
+	0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x2e, 0x50, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x22, 0x1e, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x38, 0x0a, 0x0c, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x71,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x37, 0x0a, 0x07, 0x52, 0x6f,
+	0x6f, 0x6d, 0x54, 0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x0e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x70,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x54, 0x6f, 0x70, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x73, 0x22,
+	0x39, 0x0a, 0x0d, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0f, 0x4f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d,
+	0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x1a, 0x38, 0x0a,
+	0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x10, 0x0a, 0x0e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x22, 0x4a, 0x0a, 0x10, 0x4f, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x69, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x69, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x42, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x22, 0xfc, 0x01, 0x0a, 0x0a, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x12, 0x19, 0x0a, 0x08, 0x74, 0x63, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x74, 0x63, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x77,
+	0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x73,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x4d, 0x61, 0x78, 0x22, 0x75, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x06, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x32,
+	0xde, 0x0a, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x12, 0x3d, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x43, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x19, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65,
+	0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4f, 0x6e,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67,
+	0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x63,
+	0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x69,
+	0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x15,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x43,
+	0x0a, 0x09, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x68,
+	0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x53, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
+	0x53, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x53, 0x6c, 0x6f, 0x77,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x52, 0x0a, 0x0e, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1e, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x20, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x46, 0x0a, 0x0a, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x69, 0x64, 0x73, 0x12, 0x1a, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x4d, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x69, 0x6d,
+	0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x69,
+	0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
+	0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x40, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x68,
+	0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4b, 0x65,
+	0x79, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f,
+	0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x40, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x69, 0x64, 0x73, 0x12, 0x18, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68,
+	0x4d, 0x69, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x69, 0x64, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x52, 0x6f, 0x6f, 0x6d, 0x12,
+	0x18, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75,
+	0x73, 0x68, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d,
+	0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x6f, 0x6f, 0x6d,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x75, 0x73, 0x68, 0x41, 0x6c, 0x6c,
+	0x12, 0x17, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50,
+	0x75, 0x73, 0x68, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d,
+	0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x41, 0x6c, 0x6c, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x43, 0x0a, 0x09, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f,
+	0x70, 0x12, 0x19, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e,
+	0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x63,
+	0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x54, 0x6f, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x4f, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1a, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x6f, 0x6f, 0x6d,
+	0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69,
+	0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x49, 0x0a, 0x0b, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x1b, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f,
+	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e,
+	0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0a,
+	0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x63, 0x68, 0x69,
+	0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x16,
+	0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2e, 0x6c,
+	0x6f, 0x67, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77,
+	0x63, 0x61, 0x71, 0x72, 0x6c, 0x2f, 0x63, 0x68, 0x69, 0x6d, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x6c, 0x6f, 0x67, 0x69, 0x63, 0x3b, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_logic_logic_proto_rawDescOnce sync.Once
+	file_logic_logic_proto_rawDescData = file_logic_logic_proto_rawDesc
+)
+
+func file_logic_logic_proto_rawDescGZIP() []byte {
+	file_logic_logic_proto_rawDescOnce.Do(func() {
+		file_logic_logic_proto_rawDescData = protoimpl.X.CompressGZIP(file_logic_logic_proto_rawDescData)
+	})
+	return file_logic_logic_proto_rawDescData
+}
+
+var file_logic_logic_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_logic_logic_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
+var file_logic_logic_proto_goTypes = []interface{}{
+	(PushMsg_Type)(0),           // 0: chime.logic.PushMsg.Type
+	(PushMsg_Priority)(0),       // 1: chime.logic.PushMsg.Priority
+	(*PushMsg)(nil),             // 2: chime.logic.PushMsg
+	(*DeadLetterMsg)(nil),       // 3: chime.logic.DeadLetterMsg
+	(*ConnectReq)(nil),          // 4: chime.logic.ConnectReq
+	(*ConnectReply)(nil),        // 5: chime.logic.ConnectReply
+	(*DisconnectReq)(nil),       // 6: chime.logic.DisconnectReq
+	(*ResumeEntry)(nil),         // 7: chime.logic.ResumeEntry
+	(*ResumeSlot)(nil),          // 8: chime.logic.ResumeSlot
+	(*ResumeReq)(nil),           // 9: chime.logic.ResumeReq
+	(*ResumeReply)(nil),         // 10: chime.logic.ResumeReply
+	(*DisconnectReply)(nil),     // 11: chime.logic.DisconnectReply
+	(*HeartbeatReq)(nil),        // 12: chime.logic.HeartbeatReq
+	(*HeartbeatReply)(nil),      // 13: chime.logic.HeartbeatReply
+	(*OnlineReq)(nil),           // 14: chime.logic.OnlineReq
+	(*OnlineReply)(nil),         // 15: chime.logic.OnlineReply
+	(*ReceiveReq)(nil),          // 16: chime.logic.ReceiveReq
+	(*ReceiveReply)(nil),        // 17: chime.logic.ReceiveReply
+	(*AckReportReq)(nil),        // 18: chime.logic.AckReportReq
+	(*AckReportReply)(nil),      // 19: chime.logic.AckReportReply
+	(*SlowReportReq)(nil),       // 20: chime.logic.SlowReportReq
+	(*SlowReportReply)(nil),     // 21: chime.logic.SlowReportReply
+	(*DeliveryCount)(nil),       // 22: chime.logic.DeliveryCount
+	(*DeliveryReportReq)(nil),   // 23: chime.logic.DeliveryReportReq
+	(*DeliveryReportReply)(nil), // 24: chime.logic.DeliveryReportReply
+	(*SyncResumeReq)(nil),       // 25: chime.logic.SyncResumeReq
+	(*SyncResumeReply)(nil),     // 26: chime.logic.SyncResumeReply
+	(*KeyPresence)(nil),         // 27: chime.logic.KeyPresence
+	(*MidPresence)(nil),         // 28: chime.logic.MidPresence
+	(*OnlineMidsReq)(nil),       // 29: chime.logic.OnlineMidsReq
+	(*OnlineMidsReply)(nil),     // 30: chime.logic.OnlineMidsReply
+	(*OnlineKeysReq)(nil),       // 31: chime.logic.OnlineKeysReq
+	(*OnlineKeysReply)(nil),     // 32: chime.logic.OnlineKeysReply
+	(*PushKeysReq)(nil),         // 33: chime.logic.PushKeysReq
+	(*PushKeysReply)(nil),       // 34: chime.logic.PushKeysReply
+	(*PushMidsReq)(nil),         // 35: chime.logic.PushMidsReq
+	(*PushMidsReply)(nil),       // 36: chime.logic.PushMidsReply
+	(*PushRoomReq)(nil),         // 37: chime.logic.PushRoomReq
+	(*PushRoomReply)(nil),       // 38: chime.logic.PushRoomReply
+	(*PushAllReq)(nil),          // 39: chime.logic.PushAllReq
+	(*PushAllReply)(nil),        // 40: chime.logic.PushAllReply
+	(*OnlineTopReq)(nil),        // 41: chime.logic.OnlineTopReq
+	(*RoomTop)(nil),             // 42: chime.logic.RoomTop
+	(*OnlineTopReply)(nil),      // 43: chime.logic.OnlineTopReply
+	(*OnlineRoomReq)(nil),       // 44: chime.logic.OnlineRoomReq
+	(*OnlineRoomReply)(nil),     // 45: chime.logic.OnlineRoomReply
+	(*OnlineTotalReq)(nil),      // 46: chime.logic.OnlineTotalReq
+	(*OnlineTotalReply)(nil),    // 47: chime.logic.OnlineTotalReply
+	(*NodesReq)(nil),            // 48: chime.logic.NodesReq
+	(*NodesReply)(nil),          // 49: chime.logic.NodesReply
+	(*Backoff)(nil),             // 50: chime.logic.Backoff
+	nil,                         // 51: chime.logic.OnlineReq.RoomCountEntry
+	nil,                         // 52: chime.logic.OnlineReply.AllRoomCountEntry
+	nil,                         // 53: chime.logic.SlowReportReq.KeysEntry
+	nil,                         // 54: chime.logic.DeliveryReportReq.CountsEntry
+	nil,                         // 55: chime.logic.SyncResumeReq.SlotsEntry
+	nil,                         // 56: chime.logic.OnlineRoomReply.RoomsEntry
+	(*protocol.Proto)(nil),      // 57: chime.protocol.Proto
+}
+var file_logic_logic_proto_depIdxs = []int32{
+	0,  // 0: chime.logic.PushMsg.type:type_name -> chime.logic.PushMsg.Type
+	1,  // 1: chime.logic.PushMsg.priority:type_name -> chime.logic.PushMsg.Priority
+	2,  // 2: chime.logic.DeadLetterMsg.msg:type_name -> chime.logic.PushMsg
+	8,  // 3: chime.logic.ConnectReq.resume:type_name -> chime.logic.ResumeSlot
+	8,  // 4: chime.logic.DisconnectReq.slot:type_name -> chime.logic.ResumeSlot
+	7,  // 5: chime.logic.ResumeSlot.entries:type_name -> chime.logic.ResumeEntry
+	8,  // 6: chime.logic.ResumeReq.resume:type_name -> chime.logic.ResumeSlot
+	51, // 7: chime.logic.OnlineReq.roomCount:type_name -> chime.logic.OnlineReq.RoomCountEntry
+	52, // 8: chime.logic.OnlineReply.allRoomCount:type_name -> chime.logic.OnlineReply.AllRoomCountEntry
+	57, // 9: chime.logic.ReceiveReq.proto:type_name -> chime.protocol.Proto
+	53, // 10: chime.logic.SlowReportReq.keys:type_name -> chime.logic.SlowReportReq.KeysEntry
+	54, // 11: chime.logic.DeliveryReportReq.counts:type_name -> chime.logic.DeliveryReportReq.CountsEntry
+	55, // 12: chime.logic.SyncResumeReq.slots:type_name -> chime.logic.SyncResumeReq.SlotsEntry
+	27, // 13: chime.logic.MidPresence.keys:type_name -> chime.logic.KeyPresence
+	28, // 14: chime.logic.OnlineMidsReply.mids:type_name -> chime.logic.MidPresence
+	27, // 15: chime.logic.OnlineKeysReply.keys:type_name -> chime.logic.KeyPresence
+	1,  // 16: chime.logic.PushKeysReq.priority:type_name -> chime.logic.PushMsg.Priority
+	1,  // 17: chime.logic.PushMidsReq.priority:type_name -> chime.logic.PushMsg.Priority
+	1,  // 18: chime.logic.PushRoomReq.priority:type_name -> chime.logic.PushMsg.Priority
+	1,  // 19: chime.logic.PushAllReq.priority:type_name -> chime.logic.PushMsg.Priority
+	42, // 20: chime.logic.OnlineTopReply.tops:type_name -> chime.logic.RoomTop
+	56, // 21: chime.logic.OnlineRoomReply.rooms:type_name -> chime.logic.OnlineRoomReply.RoomsEntry
+	50, // 22: chime.logic.NodesReply.backoff:type_name -> chime.logic.Backoff
+	22, // 23: chime.logic.DeliveryReportReq.CountsEntry.value:type_name -> chime.logic.DeliveryCount
+	8,  // 24: chime.logic.SyncResumeReq.SlotsEntry.value:type_name -> chime.logic.ResumeSlot
+	4,  // 25: chime.logic.Logic.Connect:input_type -> chime.logic.ConnectReq
+	6,  // 26: chime.logic.Logic.Disconnect:input_type -> chime.logic.DisconnectReq
+	12, // 27: chime.logic.Logic.Heartbeat:input_type -> chime.logic.HeartbeatReq
+	14, // 28: chime.logic.Logic.RenewOnline:input_type -> chime.logic.OnlineReq
+	16, // 29: chime.logic.Logic.Receive:input_type -> chime.logic.ReceiveReq
+	48, // 30: chime.logic.Logic.Nodes:input_type -> chime.logic.NodesReq
+	18, // 31: chime.logic.Logic.AckReport:input_type -> chime.logic.AckReportReq
+	20, // 32: chime.logic.Logic.SlowReport:input_type -> chime.logic.SlowReportReq
+	23, // 33: chime.logic.Logic.DeliveryReport:input_type -> chime.logic.DeliveryReportReq
+	29, // 34: chime.logic.Logic.OnlineMids:input_type -> chime.logic.OnlineMidsReq
+	31, // 35: chime.logic.Logic.OnlineKeys:input_type -> chime.logic.OnlineKeysReq
+	33, // 36: chime.logic.Logic.PushKeys:input_type -> chime.logic.PushKeysReq
+	35, // 37: chime.logic.Logic.PushMids:input_type -> chime.logic.PushMidsReq
+	37, // 38: chime.logic.Logic.PushRoom:input_type -> chime.logic.PushRoomReq
+	39, // 39: chime.logic.Logic.PushAll:input_type -> chime.logic.PushAllReq
+	41, // 40: chime.logic.Logic.OnlineTop:input_type -> chime.logic.OnlineTopReq
+	44, // 41: chime.logic.Logic.OnlineRoom:input_type -> chime.logic.OnlineRoomReq
+	46, // 42: chime.logic.Logic.OnlineTotal:input_type -> chime.logic.OnlineTotalReq
+	25, // 43: chime.logic.Logic.SyncResume:input_type -> chime.logic.SyncResumeReq
+	9,  // 44: chime.logic.Logic.Resume:input_type -> chime.logic.ResumeReq
+	5,  // 45: chime.logic.Logic.Connect:output_type -> chime.logic.ConnectReply
+	11, // 46: chime.logic.Logic.Disconnect:output_type -> chime.logic.DisconnectReply
+	13, // 47: chime.logic.Logic.Heartbeat:output_type -> chime.logic.HeartbeatReply
+	15, // 48: chime.logic.Logic.RenewOnline:output_type -> chime.logic.OnlineReply
+	17, // 49: chime.logic.Logic.Receive:output_type -> chime.logic.ReceiveReply
+	49, // 50: chime.logic.Logic.Nodes:output_type -> chime.logic.NodesReply
+	19, // 51: chime.logic.Logic.AckReport:output_type -> chime.logic.AckReportReply
+	21, // 52: chime.logic.Logic.SlowReport:output_type -> chime.logic.SlowReportReply
+	24, // 53: chime.logic.Logic.DeliveryReport:output_type -> chime.logic.DeliveryReportReply
+	30, // 54: chime.logic.Logic.OnlineMids:output_type -> chime.logic.OnlineMidsReply
+	32, // 55: chime.logic.Logic.OnlineKeys:output_type -> chime.logic.OnlineKeysReply
+	34, // 56: chime.logic.Logic.PushKeys:output_type -> chime.logic.PushKeysReply
+	36, // 57: chime.logic.Logic.PushMids:output_type -> chime.logic.PushMidsReply
+	38, // 58: chime.logic.Logic.PushRoom:output_type -> chime.logic.PushRoomReply
+	40, // 59: chime.logic.Logic.PushAll:output_type -> chime.logic.PushAllReply
+	43, // 60: chime.logic.Logic.OnlineTop:output_type -> chime.logic.OnlineTopReply
+	45, // 61: chime.logic.Logic.OnlineRoom:output_type -> chime.logic.OnlineRoomReply
+	47, // 62: chime.logic.Logic.OnlineTotal:output_type -> chime.logic.OnlineTotalReply
+	26, // 63: chime.logic.Logic.SyncResume:output_type -> chime.logic.SyncResumeReply
+	10, // 64: chime.logic.Logic.Resume:output_type -> chime.logic.ResumeReply
+	45, // [45:65] is the sub-list for method output_type
+	25, // [25:45] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_logic_logic_proto_init() }
+func file_logic_logic_proto_init() {
+	if File_logic_logic_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_logic_logic_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetterMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeSlot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisconnectReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReceiveReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReceiveReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AckReportReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AckReportReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlowReportReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlowReportReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryReportReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryReportReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncResumeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncResumeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyPresence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MidPresence); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1152,8 +4387,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConnectReq); i {
+		file_logic_logic_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineMidsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1164,8 +4399,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConnectReply); i {
+		file_logic_logic_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineMidsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1176,8 +4411,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisconnectReq); i {
+		file_logic_logic_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineKeysReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1188,8 +4423,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisconnectReply); i {
+		file_logic_logic_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineKeysReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1200,8 +4435,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeartbeatReq); i {
+		file_logic_logic_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushKeysReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1212,8 +4447,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeartbeatReply); i {
+		file_logic_logic_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushKeysReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1224,8 +4459,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OnlineReq); i {
+		file_logic_logic_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMidsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1236,8 +4471,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OnlineReply); i {
+		file_logic_logic_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMidsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1248,8 +4483,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReceiveReq); i {
+		file_logic_logic_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushRoomReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1260,8 +4495,8 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReceiveReply); i {
+		file_logic_logic_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushRoomReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1272,7 +4507,115 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_logic_logic_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushAllReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushAllReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineTopReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomTop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineTopReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineRoomReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineRoomReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineTotalReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnlineTotalReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logic_logic_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodesReq); i {
 			case 0:
 				return &v.state
@@ -1284,7 +4627,7 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_logic_logic_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodesReply); i {
 			case 0:
 				return &v.state
@@ -1296,7 +4639,7 @@ func file_logic_logic_proto_init() {
 				return nil
 			}
 		}
-		file_logic_logic_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_logic_logic_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Backoff); i {
 			case 0:
 				return &v.state
@@ -1314,8 +4657,8 @@ func file_logic_logic_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_logic_logic_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   16,
+			NumEnums:      2,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -34,6 +34,34 @@ type LogicClient interface {
 	Receive(ctx context.Context, in *ReceiveReq, opts ...grpc.CallOption) (*ReceiveReply, error)
 	//ServerList
 	Nodes(ctx context.Context, in *NodesReq, opts ...grpc.CallOption) (*NodesReply, error)
+	// AckReport
+	AckReport(ctx context.Context, in *AckReportReq, opts ...grpc.CallOption) (*AckReportReply, error)
+	// SlowReport report the slow consumer counters
+	SlowReport(ctx context.Context, in *SlowReportReq, opts ...grpc.CallOption) (*SlowReportReply, error)
+	// DeliveryReport report the deliveries of pushes
+	DeliveryReport(ctx context.Context, in *DeliveryReportReq, opts ...grpc.CallOption) (*DeliveryReportReply, error)
+	// OnlineMids presence of mids
+	OnlineMids(ctx context.Context, in *OnlineMidsReq, opts ...grpc.CallOption) (*OnlineMidsReply, error)
+	// OnlineKeys presence of keys
+	OnlineKeys(ctx context.Context, in *OnlineKeysReq, opts ...grpc.CallOption) (*OnlineKeysReply, error)
+	// PushKeys push a message by keys
+	PushKeys(ctx context.Context, in *PushKeysReq, opts ...grpc.CallOption) (*PushKeysReply, error)
+	// PushMids push a message by mids
+	PushMids(ctx context.Context, in *PushMidsReq, opts ...grpc.CallOption) (*PushMidsReply, error)
+	// PushRoom push a message to a room
+	PushRoom(ctx context.Context, in *PushRoomReq, opts ...grpc.CallOption) (*PushRoomReply, error)
+	// PushAll push a message to all
+	PushAll(ctx context.Context, in *PushAllReq, opts ...grpc.CallOption) (*PushAllReply, error)
+	// OnlineTop the top online rooms
+	OnlineTop(ctx context.Context, in *OnlineTopReq, opts ...grpc.CallOption) (*OnlineTopReply, error)
+	// OnlineRoom online of rooms
+	OnlineRoom(ctx context.Context, in *OnlineRoomReq, opts ...grpc.CallOption) (*OnlineRoomReply, error)
+	// OnlineTotal online ips and conns
+	OnlineTotal(ctx context.Context, in *OnlineTotalReq, opts ...grpc.CallOption) (*OnlineTotalReply, error)
+	// SyncResume append the protos written to the conns to their resume slots
+	SyncResume(ctx context.Context, in *SyncResumeReq, opts ...grpc.CallOption) (*SyncResumeReply, error)
+	// Resume reattach a detached session
+	Resume(ctx context.Context, in *ResumeReq, opts ...grpc.CallOption) (*ResumeReply, error)
 }
 
 type logicClient struct {
@@ -98,6 +126,132 @@ func (c *logicClient) Nodes(ctx context.Context, in *NodesReq, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *logicClient) AckReport(ctx context.Context, in *AckReportReq, opts ...grpc.CallOption) (*AckReportReply, error) {
+	out := new(AckReportReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/AckReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) SlowReport(ctx context.Context, in *SlowReportReq, opts ...grpc.CallOption) (*SlowReportReply, error) {
+	out := new(SlowReportReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/SlowReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) DeliveryReport(ctx context.Context, in *DeliveryReportReq, opts ...grpc.CallOption) (*DeliveryReportReply, error) {
+	out := new(DeliveryReportReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/DeliveryReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) OnlineMids(ctx context.Context, in *OnlineMidsReq, opts ...grpc.CallOption) (*OnlineMidsReply, error) {
+	out := new(OnlineMidsReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/OnlineMids", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) OnlineKeys(ctx context.Context, in *OnlineKeysReq, opts ...grpc.CallOption) (*OnlineKeysReply, error) {
+	out := new(OnlineKeysReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/OnlineKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) PushKeys(ctx context.Context, in *PushKeysReq, opts ...grpc.CallOption) (*PushKeysReply, error) {
+	out := new(PushKeysReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/PushKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) PushMids(ctx context.Context, in *PushMidsReq, opts ...grpc.CallOption) (*PushMidsReply, error) {
+	out := new(PushMidsReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/PushMids", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) PushRoom(ctx context.Context, in *PushRoomReq, opts ...grpc.CallOption) (*PushRoomReply, error) {
+	out := new(PushRoomReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/PushRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) PushAll(ctx context.Context, in *PushAllReq, opts ...grpc.CallOption) (*PushAllReply, error) {
+	out := new(PushAllReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/PushAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) OnlineTop(ctx context.Context, in *OnlineTopReq, opts ...grpc.CallOption) (*OnlineTopReply, error) {
+	out := new(OnlineTopReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/OnlineTop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) OnlineRoom(ctx context.Context, in *OnlineRoomReq, opts ...grpc.CallOption) (*OnlineRoomReply, error) {
+	out := new(OnlineRoomReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/OnlineRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) OnlineTotal(ctx context.Context, in *OnlineTotalReq, opts ...grpc.CallOption) (*OnlineTotalReply, error) {
+	out := new(OnlineTotalReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/OnlineTotal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) SyncResume(ctx context.Context, in *SyncResumeReq, opts ...grpc.CallOption) (*SyncResumeReply, error) {
+	out := new(SyncResumeReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/SyncResume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logicClient) Resume(ctx context.Context, in *ResumeReq, opts ...grpc.CallOption) (*ResumeReply, error) {
+	out := new(ResumeReply)
+	err := c.cc.Invoke(ctx, "/chime.logic.Logic/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogicServer is the server API for Logic service.
 // All implementations should embed UnimplementedLogicServer
 // for forward compatibility
@@ -114,6 +268,34 @@ type LogicServer interface {
 	Receive(context.Context, *ReceiveReq) (*ReceiveReply, error)
 	//ServerList
 	Nodes(context.Context, *NodesReq) (*NodesReply, error)
+	// AckReport
+	AckReport(context.Context, *AckReportReq) (*AckReportReply, error)
+	// SlowReport report the slow consumer counters
+	SlowReport(context.Context, *SlowReportReq) (*SlowReportReply, error)
+	// DeliveryReport report the deliveries of pushes
+	DeliveryReport(context.Context, *DeliveryReportReq) (*DeliveryReportReply, error)
+	// OnlineMids presence of mids
+	OnlineMids(context.Context, *OnlineMidsReq) (*OnlineMidsReply, error)
+	// OnlineKeys presence of keys
+	OnlineKeys(context.Context, *OnlineKeysReq) (*OnlineKeysReply, error)
+	// PushKeys push a message by keys
+	PushKeys(context.Context, *PushKeysReq) (*PushKeysReply, error)
+	// PushMids push a message by mids
+	PushMids(context.Context, *PushMidsReq) (*PushMidsReply, error)
+	// PushRoom push a message to a room
+	PushRoom(context.Context, *PushRoomReq) (*PushRoomReply, error)
+	// PushAll push a message to all
+	PushAll(context.Context, *PushAllReq) (*PushAllReply, error)
+	// OnlineTop the top online rooms
+	OnlineTop(context.Context, *OnlineTopReq) (*OnlineTopReply, error)
+	// OnlineRoom online of rooms
+	OnlineRoom(context.Context, *OnlineRoomReq) (*OnlineRoomReply, error)
+	// OnlineTotal online ips and conns
+	OnlineTotal(context.Context, *OnlineTotalReq) (*OnlineTotalReply, error)
+	// SyncResume append the protos written to the conns to their resume slots
+	SyncResume(context.Context, *SyncResumeReq) (*SyncResumeReply, error)
+	// Resume reattach a detached session
+	Resume(context.Context, *ResumeReq) (*ResumeReply, error)
 }
 
 // UnimplementedLogicServer should be embedded to have forward compatible implementations.
@@ -138,6 +320,48 @@ func (UnimplementedLogicServer) Receive(context.Context, *ReceiveReq) (*ReceiveR
 func (UnimplementedLogicServer) Nodes(context.Context, *NodesReq) (*NodesReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Nodes not implemented")
 }
+func (UnimplementedLogicServer) AckReport(context.Context, *AckReportReq) (*AckReportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckReport not implemented")
+}
+func (UnimplementedLogicServer) SlowReport(context.Context, *SlowReportReq) (*SlowReportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SlowReport not implemented")
+}
+func (UnimplementedLogicServer) DeliveryReport(context.Context, *DeliveryReportReq) (*DeliveryReportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliveryReport not implemented")
+}
+func (UnimplementedLogicServer) OnlineMids(context.Context, *OnlineMidsReq) (*OnlineMidsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineMids not implemented")
+}
+func (UnimplementedLogicServer) OnlineKeys(context.Context, *OnlineKeysReq) (*OnlineKeysReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineKeys not implemented")
+}
+func (UnimplementedLogicServer) PushKeys(context.Context, *PushKeysReq) (*PushKeysReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushKeys not implemented")
+}
+func (UnimplementedLogicServer) PushMids(context.Context, *PushMidsReq) (*PushMidsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushMids not implemented")
+}
+func (UnimplementedLogicServer) PushRoom(context.Context, *PushRoomReq) (*PushRoomReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushRoom not implemented")
+}
+func (UnimplementedLogicServer) PushAll(context.Context, *PushAllReq) (*PushAllReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushAll not implemented")
+}
+func (UnimplementedLogicServer) OnlineTop(context.Context, *OnlineTopReq) (*OnlineTopReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineTop not implemented")
+}
+func (UnimplementedLogicServer) OnlineRoom(context.Context, *OnlineRoomReq) (*OnlineRoomReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineRoom not implemented")
+}
+func (UnimplementedLogicServer) OnlineTotal(context.Context, *OnlineTotalReq) (*OnlineTotalReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineTotal not implemented")
+}
+func (UnimplementedLogicServer) SyncResume(context.Context, *SyncResumeReq) (*SyncResumeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncResume not implemented")
+}
+func (UnimplementedLogicServer) Resume(context.Context, *ResumeReq) (*ResumeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
 
 // UnsafeLogicServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to LogicServer will
@@ -258,6 +482,258 @@ func _Logic_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Logic_AckReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckReportReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).AckReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/AckReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).AckReport(ctx, req.(*AckReportReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_SlowReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlowReportReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).SlowReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/SlowReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).SlowReport(ctx, req.(*SlowReportReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_DeliveryReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryReportReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).DeliveryReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/DeliveryReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).DeliveryReport(ctx, req.(*DeliveryReportReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_OnlineMids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineMidsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).OnlineMids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/OnlineMids",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).OnlineMids(ctx, req.(*OnlineMidsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_OnlineKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineKeysReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).OnlineKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/OnlineKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).OnlineKeys(ctx, req.(*OnlineKeysReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_PushKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushKeysReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).PushKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/PushKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).PushKeys(ctx, req.(*PushKeysReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_PushMids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushMidsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).PushMids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/PushMids",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).PushMids(ctx, req.(*PushMidsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_PushRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).PushRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/PushRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).PushRoom(ctx, req.(*PushRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_PushAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).PushAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/PushAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).PushAll(ctx, req.(*PushAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_OnlineTop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineTopReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).OnlineTop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/OnlineTop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).OnlineTop(ctx, req.(*OnlineTopReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_OnlineRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).OnlineRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/OnlineRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).OnlineRoom(ctx, req.(*OnlineRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_OnlineTotal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineTotalReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).OnlineTotal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/OnlineTotal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).OnlineTotal(ctx, req.(*OnlineTotalReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_SyncResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncResumeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).SyncResume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/SyncResume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).SyncResume(ctx, req.(*SyncResumeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logic_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogicServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chime.logic.Logic/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogicServer).Resume(ctx, req.(*ResumeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Logic_ServiceDesc is the grpc.ServiceDesc for Logic service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +765,62 @@ var Logic_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Nodes",
 			Handler:    _Logic_Nodes_Handler,
 		},
+		{
+			MethodName: "AckReport",
+			Handler:    _Logic_AckReport_Handler,
+		},
+		{
+			MethodName: "SlowReport",
+			Handler:    _Logic_SlowReport_Handler,
+		},
+		{
+			MethodName: "DeliveryReport",
+			Handler:    _Logic_DeliveryReport_Handler,
+		},
+		{
+			MethodName: "OnlineMids",
+			Handler:    _Logic_OnlineMids_Handler,
+		},
+		{
+			MethodName: "OnlineKeys",
+			Handler:    _Logic_OnlineKeys_Handler,
+		},
+		{
+			MethodName: "PushKeys",
+			Handler:    _Logic_PushKeys_Handler,
+		},
+		{
+			MethodName: "PushMids",
+			Handler:    _Logic_PushMids_Handler,
+		},
+		{
+			MethodName: "PushRoom",
+			Handler:    _Logic_PushRoom_Handler,
+		},
+		{
+			MethodName: "PushAll",
+			Handler:    _Logic_PushAll_Handler,
+		},
+		{
+			MethodName: "OnlineTop",
+			Handler:    _Logic_OnlineTop_Handler,
+		},
+		{
+			MethodName: "OnlineRoom",
+			Handler:    _Logic_OnlineRoom_Handler,
+		},
+		{
+			MethodName: "OnlineTotal",
+			Handler:    _Logic_OnlineTotal_Handler,
+		},
+		{
+			MethodName: "SyncResume",
+			Handler:    _Logic_SyncResume_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Logic_Resume_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "logic/logic.proto",
@@ -0,0 +1,29 @@
+package protocol
+
+import (
+	"github.com/wcaqrl/chime/pkg/binary"
+)
+
+const (
+	// ReasonUnknown disconnect without a specified reason
+	ReasonUnknown = int32(0)
+	// ReasonAuthFailed disconnect because the auth token was rejected
+	ReasonAuthFailed = int32(1)
+	// ReasonKicked disconnect by the backend
+	ReasonKicked = int32(2)
+	// ReasonDraining the node is shutting down
+	ReasonDraining = int32(3)
+	// ReasonMigrate the node is rebalanced
+	ReasonMigrate = int32(4)
+	// ReasonResumeFailed the session to resume is gone, auth again
+	ReasonResumeFailed = int32(5)
+)
+
+// DisconnectBody encode the body of OpDisconnectReply:
+// 4 bytes big endian reason code followed by an utf8 message.
+func DisconnectBody(reason int32, msg string) []byte {
+	b := make([]byte, 4+len(msg))
+	binary.BigEndian.PutInt32(b, reason)
+	copy(b[4:], msg)
+	return b
+}
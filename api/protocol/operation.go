@@ -19,7 +19,8 @@ const (
 	// OpDisconnectReply disconnect reply
 	OpDisconnectReply = int32(6)
 
-	// OpAuth auth connnect
+	// OpAuth auth connnect, the seq carries the last offline seq received, the
+	// offline msgs replayed after the auth reply carry theirs
 	OpAuth = int32(7)
 	// OpAuthReply auth connect reply
 	OpAuthReply = int32(8)
@@ -46,4 +47,18 @@ const (
 	OpUnsub = int32(16)
 	// OpUnsubReply unsubscribe operation reply
 	OpUnsubReply = int32(17)
+
+	// OpPushAck client ack a server push by seq
+	OpPushAck = int32(18)
+	// OpPushAckReply client ack reply
+	OpPushAckReply = int32(19)
+
+	// OpReconnect server asks the client to reconnect, the conn is closed after it
+	OpReconnect = int32(20)
+
+	// OpResume resume a detached session in place of auth, the seq carries the
+	// number of server pushed protos received, OpReconnect excluded
+	OpResume = int32(21)
+	// OpResumeReply resume reply
+	OpResumeReply = int32(22)
 )
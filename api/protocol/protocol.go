@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"errors"
+	"time"
 
 	"github.com/wcaqrl/chime/pkg/binary"
 	"github.com/wcaqrl/chime/pkg/bufio"
@@ -21,7 +22,7 @@ const (
 	_verSize       = 2
 	_opSize        = 4
 	_seqSize       = 4
-	_heartSize     = 4
+	_heartSize     = 12
 	_rawHeaderSize = _packSize + _headerSize + _verSize + _opSize + _seqSize
 	_maxPackSize   = MaxBodySize + int32(_rawHeaderSize)
 	// offset
@@ -31,6 +32,9 @@ const (
 	_opOffset     = _verOffset + _verSize
 	_seqOffset    = _opOffset + _opSize
 	_heartOffset  = _seqOffset + _seqSize
+	// heartbeat body: online, key seq, room seq
+	_heartSeqOffset     = _heartOffset + 4
+	_heartRoomSeqOffset = _heartSeqOffset + 4
 )
 
 var (
@@ -63,6 +67,40 @@ func (p *Proto) WriteTo(b *bytes.Writer) {
 	}
 }
 
+// WithSeq return a copy of the proto carrying the seq, the protos packed in
+// a raw proto are numbered from seq on, Count seqs in all.
+func (p *Proto) WithSeq(seq int32) *Proto {
+	np := &Proto{Ver: p.Ver, Op: p.Op, Seq: seq, Body: p.Body, ExpireAt: p.ExpireAt, Bulk: p.Bulk}
+	if p.Op == OpRaw {
+		np.Seq = p.Seq
+		np.Body = make([]byte, len(p.Body))
+		copy(np.Body, p.Body)
+		for body := np.Body; len(body) >= _rawHeaderSize; seq++ {
+			packLen := int(binary.BigEndian.Int32(body[_packOffset:]))
+			if packLen < _rawHeaderSize || packLen > len(body) {
+				break
+			}
+			binary.BigEndian.PutInt32(body[_seqOffset:], seq)
+			body = body[packLen:]
+		}
+	}
+	return np
+}
+
+// Count get the number of protos sent to the client, the ones packed in a
+// raw proto.
+func (p *Proto) Count() int32 {
+	if p.Op == OpRaw {
+		return int32(CountProtos(p.Body))
+	}
+	return 1
+}
+
+// Expired check the push expired, it never does without expire_at.
+func (p *Proto) Expired() bool {
+	return p.ExpireAt > 0 && time.Now().Unix() >= p.ExpireAt
+}
+
 // ReadTCP read a proto from TCP reader.
 func (p *Proto) ReadTCP(rr *bufio.Reader) (err error) {
 	var (
@@ -119,8 +157,9 @@ func (p *Proto) WriteTCP(wr *bufio.Writer) (err error) {
 	return
 }
 
-// WriteTCPHeart write TCP heartbeat with room online.
-func (p *Proto) WriteTCPHeart(wr *bufio.Writer, online int32) (err error) {
+// WriteTCPHeart write TCP heartbeat with room online and the latest seqs
+// pushed to the key and to the room.
+func (p *Proto) WriteTCPHeart(wr *bufio.Writer, online, seq, roomSeq int32) (err error) {
 	var (
 		buf     []byte
 		packLen int
@@ -137,6 +176,8 @@ func (p *Proto) WriteTCPHeart(wr *bufio.Writer, online int32) (err error) {
 	binary.BigEndian.PutInt32(buf[_seqOffset:], p.Seq)
 	// body
 	binary.BigEndian.PutInt32(buf[_heartOffset:], online)
+	binary.BigEndian.PutInt32(buf[_heartSeqOffset:], seq)
+	binary.BigEndian.PutInt32(buf[_heartRoomSeqOffset:], roomSeq)
 	return
 }
 
@@ -197,8 +238,9 @@ func (p *Proto) WriteWebsocket(ws *websocket.Conn) (err error) {
 	return
 }
 
-// WriteWebsocketHeart write websocket heartbeat with room online.
-func (p *Proto) WriteWebsocketHeart(wr *websocket.Conn, online int32) (err error) {
+// WriteWebsocketHeart write websocket heartbeat with room online and the
+// latest seqs pushed to the key and to the room.
+func (p *Proto) WriteWebsocketHeart(wr *websocket.Conn, online, seq, roomSeq int32) (err error) {
 	var (
 		buf     []byte
 		packLen int
@@ -219,5 +261,7 @@ func (p *Proto) WriteWebsocketHeart(wr *websocket.Conn, online int32) (err error
 	binary.BigEndian.PutInt32(buf[_seqOffset:], p.Seq)
 	// proto body
 	binary.BigEndian.PutInt32(buf[_heartOffset:], online)
+	binary.BigEndian.PutInt32(buf[_heartSeqOffset:], seq)
+	binary.BigEndian.PutInt32(buf[_heartRoomSeqOffset:], roomSeq)
 	return
 }
@@ -0,0 +1,16 @@
+package protocol
+
+import "encoding/json"
+
+// Reconnect is the body of OpReconnect.
+type Reconnect struct {
+	Reason int32  `json:"reason"`
+	Node   string `json:"node,omitempty"`  // a suggested node to reconnect to
+	Token  string `json:"token,omitempty"` // the resume token of the session, sent with OpResume
+}
+
+// ReconnectBody encode the body of OpReconnect.
+func ReconnectBody(r *Reconnect) []byte {
+	b, _ := json.Marshal(r)
+	return b
+}
@@ -0,0 +1,42 @@
+package protocol
+
+import (
+	"encoding/json"
+
+	"github.com/wcaqrl/chime/pkg/binary"
+)
+
+// Resume is the body of OpResume.
+type Resume struct {
+	Key   string `json:"key"`
+	Token string `json:"token"`
+}
+
+// AuthReply is the body of OpAuthReply and OpResumeReply when sessions can
+// be resumed, the token resumes the session after a disconnect. On resume the
+// seq is the number of protos pushed before the replayed ones, past the seq
+// the client sent if the slot lost the protos between.
+type AuthReply struct {
+	Key   string `json:"key"`
+	Token string `json:"token"`
+	Seq   int64  `json:"seq,omitempty"`
+}
+
+// AuthReplyBody encode the body of OpAuthReply.
+func AuthReplyBody(r *AuthReply) []byte {
+	b, _ := json.Marshal(r)
+	return b
+}
+
+// CountProtos count the protos packed in the body of a raw proto.
+func CountProtos(body []byte) (n int) {
+	for len(body) >= _rawHeaderSize {
+		packLen := int(binary.BigEndian.Int32(body[_packOffset:]))
+		if packLen < _rawHeaderSize || packLen > len(body) {
+			break
+		}
+		body = body[packLen:]
+		n++
+	}
+	return
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/wcaqrl/chime/internal/comet"
 	"github.com/wcaqrl/chime/internal/comet/conf"
 	"github.com/wcaqrl/chime/internal/comet/grpc"
+	"github.com/wcaqrl/chime/internal/comet/http"
 	md "github.com/wcaqrl/chime/internal/logic/model"
 	"github.com/wcaqrl/chime/pkg/ip"
 	"github.com/wcaqrl/chime/pkg/logger"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 	"math/rand"
 	"net"
 	"os"
@@ -19,6 +22,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -26,6 +30,9 @@ import (
 const (
 	ver   = "1.0.0"
 	appid = "chime.comet"
+
+	// the interval renewing the discovery metadata
+	renewInterval = 10 * time.Second
 )
 
 func main() {
@@ -34,6 +41,7 @@ func main() {
 	}
 	conf.Conf.Logger.AppID = appid
 	logger.InitLogger(conf.Conf.Logger)
+	tracing.Init(appid, conf.Conf.Tracing)
 	rand.Seed(time.Now().UTC().UnixNano())
 	var numCpu = runtime.GOMAXPROCS(runtime.NumCPU())
 	action := "running"
@@ -47,9 +55,6 @@ func main() {
 	resolver.Register(dis)
 	// new comet server
 	srv := comet.NewServer(conf.Conf)
-	if err := comet.InitWhitelist(conf.Conf.Whitelist); err != nil {
-		panic(err)
-	}
 	if err := comet.InitTCP(srv, conf.Conf.TCP.Bind, runtime.NumCPU()); err != nil {
 		panic(err)
 	}
@@ -63,7 +68,13 @@ func main() {
 	}
 	// new grpc server
 	rpcSrv := grpc.New(conf.Conf.RPCServer, srv)
-	cancel := register(dis, srv)
+	metricSrv := metric.Serve(conf.Conf.Metrics.Addr)
+	// admin http
+	var httpSrv *http.Server
+	if conf.Conf.HTTPServer.Addr != "" {
+		httpSrv = http.New(conf.Conf.HTTPServer, srv)
+	}
+	cancel, offline := register(dis, srv)
 	// signal
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGINT)
@@ -72,11 +83,24 @@ func main() {
 		log.Infof("chime-comet get a signal %s", s.String())
 		switch s {
 		case syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGINT:
+			// drain the conns while the rpc server keeps pushing to them, once
+			// logic has seen the instance offline and stopped balancing to it
+			offline()
+			log.Infof("chime-comet offline, drain in %s", time.Duration(conf.Conf.Drain.Wait))
+			time.Sleep(time.Duration(conf.Conf.Drain.Wait))
+			srv.Drain()
 			if cancel != nil {
 				cancel()
 			}
 			rpcSrv.GracefulStop()
 			srv.Close()
+			if httpSrv != nil {
+				httpSrv.Close()
+			}
+			if metricSrv != nil {
+				metricSrv.Close()
+			}
+			tracing.Close()
 			log.Infof("chime-comet [version: %s] exit", ver)
 			// log.Flush()
 			return
@@ -87,7 +111,9 @@ func main() {
 	}
 }
 
-func register(dis *naming.Discovery, srv *comet.Server) context.CancelFunc {
+// register the comet to discovery, offline marks the instance offline so logic
+// stops balancing new conns to it.
+func register(dis *naming.Discovery, srv *comet.Server) (cancel context.CancelFunc, offline func()) {
 	env := conf.Conf.Env
 	addr := ip.InternalIP()
 	_, port, _ := net.SplitHostPort(conf.Conf.RPCServer.Addr)
@@ -110,8 +136,9 @@ func register(dis *naming.Discovery, srv *comet.Server) context.CancelFunc {
 	if err != nil {
 		panic(err)
 	}
+	offlineCh := make(chan struct{})
 	// renew discovery metadata
-	go func() {
+	go func(offlineCh <-chan struct{}) {
 		for {
 			var (
 				wrong error
@@ -131,8 +158,16 @@ func register(dis *naming.Discovery, srv *comet.Server) context.CancelFunc {
 				time.Sleep(time.Second)
 				continue
 			}
-			time.Sleep(time.Second * 10)
+			select {
+			case <-time.After(renewInterval):
+			case <-offlineCh:
+				ins.Metadata[md.MetaOffline] = strconv.FormatBool(true)
+				offlineCh = nil
+			}
 		}
-	}()
-	return cancel
+	}(offlineCh)
+	var once sync.Once
+	return cancel, func() {
+		once.Do(func() { close(offlineCh) })
+	}
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/bilibili/discovery/naming"
 	"github.com/wcaqrl/chime/internal/job"
 	"github.com/wcaqrl/chime/internal/job/conf"
+	"github.com/wcaqrl/chime/internal/job/http"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 
 	resolver "github.com/bilibili/discovery/naming/grpc"
 	log "github.com/sirupsen/logrus"
@@ -26,6 +29,7 @@ func main() {
 	}
 	conf.Conf.Logger.AppID = appid
 	logger.InitLogger(conf.Conf.Logger)
+	tracing.Init(appid, conf.Conf.Tracing)
 	var numCpu = runtime.GOMAXPROCS(runtime.NumCPU())
 	action := "running"
 	if conf.Conf.Debug {
@@ -39,6 +43,16 @@ func main() {
 	// job
 	j := job.New(conf.Conf)
 	go j.Consume()
+	// admin http
+	var httpSrv *http.Server
+	if conf.Conf.HTTPServer.Addr != "" {
+		if conf.Conf.HTTPServer.Token == "" {
+			log.Warn("admin http disabled: http_server.token is empty")
+		} else {
+			httpSrv = http.New(conf.Conf.HTTPServer, j)
+		}
+	}
+	metricSrv := metric.Serve(conf.Conf.Metrics.Addr)
 	// signal
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGINT)
@@ -47,7 +61,14 @@ func main() {
 		log.Infof("chime-job get a signal %s", s.String())
 		switch s {
 		case syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGINT:
+			if httpSrv != nil {
+				httpSrv.Close()
+			}
+			if metricSrv != nil {
+				metricSrv.Close()
+			}
 			j.Close()
+			tracing.Close()
 			log.Infof("chime-job [version: %s] exit", ver)
 			// log.Flush()
 			return
@@ -12,6 +12,8 @@ import (
 	"github.com/wcaqrl/chime/internal/logic/model"
 	"github.com/wcaqrl/chime/pkg/ip"
 	"github.com/wcaqrl/chime/pkg/logger"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 	"net"
 	"os"
 	"os/signal"
@@ -31,6 +33,7 @@ func main() {
 	}
 	conf.Conf.Logger.AppID = appid
 	logger.InitLogger(conf.Conf.Logger)
+	tracing.Init(appid, conf.Conf.Tracing)
 	var numCpu = runtime.GOMAXPROCS(runtime.NumCPU())
 	action := "running"
 	if conf.Conf.Debug {
@@ -45,6 +48,7 @@ func main() {
 	srv := logic.New(conf.Conf)
 	httpSrv := http.New(conf.Conf.HTTPServer, srv)
 	rpcSrv := grpc.New(conf.Conf.RPCServer, srv)
+	metricSrv := metric.Serve(conf.Conf.Metrics.Addr)
 	cancel := register(dis, srv)
 	// signal
 	c := make(chan os.Signal, 1)
@@ -60,6 +64,10 @@ func main() {
 			srv.Close()
 			httpSrv.Close()
 			rpcSrv.GracefulStop()
+			if metricSrv != nil {
+				metricSrv.Close()
+			}
+			tracing.Close()
 			log.Infof("chime-logic [version: %s] exit", ver)
 			// log.Flush()
 			return
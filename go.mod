@@ -5,11 +5,13 @@ go 1.16
 // replace google.golang.org/grpc => google.golang.org/grpc v1.26.0
 
 require (
+	github.com/Shopify/sarama v1.23.1
 	github.com/bilibili/discovery v1.2.0
 	github.com/bsm/sarama-cluster v2.1.15+incompatible
 	github.com/fastly/go-utils v0.0.0-20180712184237-d95a45783239 // indirect
 	github.com/gin-gonic/gin v1.8.1
 	github.com/go-ini/ini v1.67.0 // indirect
+	github.com/golang-jwt/jwt/v4 v4.5.2
 	github.com/golang/protobuf v1.5.2
 	github.com/gomodule/redigo v2.0.0+incompatible
 	github.com/google/uuid v1.3.0
@@ -17,6 +19,8 @@ require (
 	github.com/lestrrat/go-envload v0.0.0-20180220120943-6ed08b54a570 // indirect
 	github.com/lestrrat/go-file-rotatelogs v0.0.0-20180223000712-d3151e2a480f
 	github.com/lestrrat/go-strftime v0.0.0-20180220042222-ba3bf9c1d042 // indirect
+	github.com/nats-io/nats.go v1.20.0
+	github.com/prometheus/client_golang v1.12.2
 	github.com/rifflock/lfshook v0.0.0-20180920164130-b9218ef580f5
 	github.com/sirupsen/logrus v1.9.0
 	github.com/tebeka/strftime v0.1.5 // indirect
@@ -25,6 +29,5 @@ require (
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	github.com/zhenjl/cityhash v0.0.0-20131128155616-cdd6a94144ab
 	google.golang.org/grpc v1.45.0
-	google.golang.org/protobuf v1.28.1 // indirect
-	gopkg.in/Shopify/sarama.v1 v1.20.1
+	google.golang.org/protobuf v1.28.1
 )
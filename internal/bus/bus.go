@@ -0,0 +1,127 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// TypeKafka kafka, the default bus.
+	TypeKafka = "kafka"
+	// TypeNats nats core, at most once.
+	TypeNats = "nats"
+	// TypeRedis redis streams with consumer groups.
+	TypeRedis = "redis"
+	// TypeMemory in process channels, for a single binary or tests.
+	TypeMemory = "memory"
+)
+
+// NoPartition the partition of the messages of the buses without partitions.
+const NoPartition int32 = -1
+
+// Message is a message on the bus.
+type Message struct {
+	Topic     string
+	Key       string
+	Value     []byte
+	Partition int32 // NoPartition on nats, redis streams and memory
+	Offset    int64
+	Timestamp time.Time // produced at, zero if the bus does not keep it
+	// carried by nats, redis streams and kafka 0.11+
+	Headers map[string]string
+
+	done func()
+}
+
+// NewMessage new a message not consumed from a bus, done is called once it
+// is processed.
+func NewMessage(topic, key string, value []byte, done func()) *Message {
+	return &Message{Topic: topic, Key: key, Value: value, Partition: NoPartition, done: done}
+}
+
+// Shard get the shard keeping the order of the message, the partition on
+// kafka, a hash of the key on the buses without partitions.
+func (m *Message) Shard() uint32 {
+	if m.Partition != NoPartition {
+		return uint32(m.Partition)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(m.Key))
+	return h.Sum32()
+}
+
+// Done mark the message processed, kafka marks the offset and redis acks the entry.
+func (m *Message) Done() {
+	if m.done != nil {
+		m.done()
+	}
+}
+
+// Publisher publish messages to topics.
+type Publisher interface {
+	Publish(c context.Context, msg *Message) error
+	Close() error
+}
+
+// Subscriber deliver the messages of the subscribed topics.
+type Subscriber interface {
+	Messages() <-chan *Message
+	Close() error
+}
+
+// Config is message bus config.
+type Config struct {
+	Type string
+	// kafka
+	Brokers      []string
+	KafkaVersion string // the headers need 0.11+, the sarama default if empty
+	// nats
+	NatsURL string
+	// redis streams
+	Redis  *redis.Pool
+	MaxLen int64
+	Block  time.Duration
+	Claim  time.Duration // min idle of the pending entries claimed from a dead consumer
+	// nats subscription and memory topic buffer
+	Buffer int
+}
+
+// NewPublisher new a publisher by the bus type.
+func NewPublisher(c *Config) (pub Publisher, err error) {
+	switch c.Type {
+	case TypeKafka, "":
+		pub, err = newKafkaPublisher(c)
+	case TypeNats:
+		pub, err = newNatsPublisher(c)
+	case TypeRedis:
+		pub, err = newRedisPublisher(c)
+	case TypeMemory:
+		pub = newMemoryPublisher(c)
+	default:
+		return nil, fmt.Errorf("unknown bus type: %s", c.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &metricPublisher{Publisher: &tracePublisher{Publisher: pub}}, nil
+}
+
+// NewSubscriber new a subscriber of the topics in a consumer group by the bus type,
+// name identifies the consumer in the group.
+func NewSubscriber(c *Config, group, name string, topics []string) (Subscriber, error) {
+	switch c.Type {
+	case TypeKafka, "":
+		return newKafkaSubscriber(c, group, topics)
+	case TypeNats:
+		return newNatsSubscriber(c, group, topics)
+	case TypeRedis:
+		return newRedisSubscriber(c, group, name, topics)
+	case TypeMemory:
+		return newMemorySubscriber(c, topics), nil
+	}
+	return nil, fmt.Errorf("unknown bus type: %s", c.Type)
+}
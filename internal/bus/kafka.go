@@ -0,0 +1,125 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	cluster "github.com/bsm/sarama-cluster"
+	log "github.com/sirupsen/logrus"
+)
+
+type kafkaPublisher struct {
+	pub     sarama.SyncProducer
+	headers bool
+}
+
+func newKafkaPublisher(c *Config) (*kafkaPublisher, error) {
+	kc := sarama.NewConfig()
+	kc.Producer.RequiredAcks = sarama.WaitForAll // Wait for all in-sync replicas to ack the message
+	kc.Producer.Retry.Max = 10                   // Retry up to 10 times to produce the message
+	kc.Producer.Return.Successes = true
+	if c.KafkaVersion != "" {
+		version, err := sarama.ParseKafkaVersion(c.KafkaVersion)
+		if err != nil {
+			return nil, err
+		}
+		kc.Version = version
+	}
+	pub, err := sarama.NewSyncProducer(c.Brokers, kc)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaPublisher{pub: pub, headers: kc.Version.IsAtLeast(sarama.V0_11_0_0)}, nil
+}
+
+func (p *kafkaPublisher) Publish(c context.Context, msg *Message) (err error) {
+	m := &sarama.ProducerMessage{
+		Topic: msg.Topic,
+		Value: sarama.ByteEncoder(msg.Value),
+	}
+	if msg.Key != "" {
+		m.Key = sarama.StringEncoder(msg.Key)
+	}
+	if p.headers {
+		for k, v := range msg.Headers {
+			m.Headers = append(m.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	msg.Partition, msg.Offset, err = p.pub.SendMessage(m)
+	return
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.pub.Close()
+}
+
+type kafkaSubscriber struct {
+	consumer *cluster.Consumer
+	msgs     chan *Message
+}
+
+func newKafkaSubscriber(c *Config, group string, topics []string) (*kafkaSubscriber, error) {
+	config := cluster.NewConfig()
+	config.Consumer.Return.Errors = true
+	config.Group.Return.Notifications = true
+	if c.KafkaVersion != "" {
+		version, err := sarama.ParseKafkaVersion(c.KafkaVersion)
+		if err != nil {
+			return nil, err
+		}
+		config.Version = version
+	}
+	consumer, err := cluster.NewConsumer(c.Brokers, group, topics, config)
+	if err != nil {
+		return nil, err
+	}
+	s := &kafkaSubscriber{
+		consumer: consumer,
+		msgs:     make(chan *Message),
+	}
+	go s.consumeproc()
+	return s, nil
+}
+
+func (s *kafkaSubscriber) consumeproc() {
+	defer close(s.msgs)
+	for {
+		select {
+		case err := <-s.consumer.Errors():
+			log.Errorf("consumer error(%v)", err)
+		case n := <-s.consumer.Notifications():
+			log.Infof("consumer rebalanced(%v)", n)
+		case msg, ok := <-s.consumer.Messages():
+			if !ok {
+				return
+			}
+			var headers map[string]string
+			if len(msg.Headers) > 0 {
+				headers = make(map[string]string, len(msg.Headers))
+				for _, h := range msg.Headers {
+					headers[string(h.Key)] = string(h.Value)
+				}
+			}
+			s.msgs <- &Message{
+				Topic:     msg.Topic,
+				Key:       string(msg.Key),
+				Value:     msg.Value,
+				Partition: msg.Partition,
+				Offset:    msg.Offset,
+				Timestamp: msg.Timestamp,
+				Headers:   headers,
+				done: func() {
+					s.consumer.MarkOffset(msg, "")
+				},
+			}
+		}
+	}
+}
+
+func (s *kafkaSubscriber) Messages() <-chan *Message {
+	return s.msgs
+}
+
+func (s *kafkaSubscriber) Close() error {
+	return s.consumer.Close()
+}
@@ -0,0 +1,109 @@
+package bus
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// _hub the in process topics shared by the memory publishers and subscribers.
+var _hub = &hub{topics: make(map[string]chan *Message)}
+
+type hub struct {
+	mutex  sync.Mutex
+	topics map[string]chan *Message
+}
+
+func (h *hub) topic(name string, buffer int) chan *Message {
+	h.mutex.Lock()
+	ch, ok := h.topics[name]
+	if !ok {
+		ch = make(chan *Message, buffer)
+		h.topics[name] = ch
+	}
+	h.mutex.Unlock()
+	return ch
+}
+
+type memoryPublisher struct {
+	buffer int
+}
+
+func newMemoryPublisher(c *Config) *memoryPublisher {
+	return &memoryPublisher{buffer: c.Buffer}
+}
+
+// Publish block until the message is buffered or the context is done, the
+// subscriber gets a copy without partition.
+func (p *memoryPublisher) Publish(c context.Context, msg *Message) error {
+	msg.Timestamp = time.Now()
+	m := &Message{
+		Topic:     msg.Topic,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Partition: NoPartition,
+		Timestamp: msg.Timestamp,
+		Headers:   msg.Headers,
+	}
+	select {
+	case _hub.topic(msg.Topic, p.buffer) <- m:
+		return nil
+	case <-c.Done():
+		return c.Err()
+	}
+}
+
+func (p *memoryPublisher) Close() error {
+	return nil
+}
+
+// memorySubscriber the subscribers of a topic compete for its messages like a consumer group.
+type memorySubscriber struct {
+	msgs   chan *Message
+	closed chan struct{}
+	once   sync.Once
+	wg     sync.WaitGroup
+}
+
+func newMemorySubscriber(c *Config, topics []string) *memorySubscriber {
+	s := &memorySubscriber{
+		msgs:   make(chan *Message),
+		closed: make(chan struct{}),
+	}
+	for _, topic := range topics {
+		s.wg.Add(1)
+		go s.consumeproc(_hub.topic(topic, c.Buffer))
+	}
+	go func() {
+		s.wg.Wait()
+		close(s.msgs)
+	}()
+	return s
+}
+
+func (s *memorySubscriber) consumeproc(ch chan *Message) {
+	defer s.wg.Done()
+	for {
+		select {
+		case msg := <-ch:
+			select {
+			case s.msgs <- msg:
+			case <-s.closed:
+				return
+			}
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *memorySubscriber) Messages() <-chan *Message {
+	return s.msgs
+}
+
+func (s *memorySubscriber) Close() error {
+	s.once.Do(func() {
+		close(s.closed)
+	})
+	return nil
+}
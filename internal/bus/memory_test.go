@@ -0,0 +1,79 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryBus(t *testing.T) {
+	type msg struct {
+		topic string
+		key   string
+		value string
+	}
+	tests := []struct {
+		name   string
+		topics []string
+		msgs   []msg
+	}{
+		{
+			name:   "one topic",
+			topics: []string{"push"},
+			msgs:   []msg{{"push", "a", "1"}, {"push", "b", "2"}, {"push", "a", "3"}},
+		},
+		{
+			name:   "several topics",
+			topics: []string{"push", "bulk"},
+			msgs:   []msg{{"push", "a", "1"}, {"bulk", "b", "2"}},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{Type: TypeMemory, Buffer: 16}
+			// the hub is shared by the process, each case has its own topics
+			topic := func(name string) string { return fmt.Sprintf("test-%d-%s", i, name) }
+			var topics []string
+			for _, name := range tt.topics {
+				topics = append(topics, topic(name))
+			}
+			sub, err := NewSubscriber(c, "group", "name", topics)
+			if err != nil {
+				t.Fatalf("NewSubscriber() error(%v)", err)
+			}
+			defer sub.Close()
+			pub, err := NewPublisher(c)
+			if err != nil {
+				t.Fatalf("NewPublisher() error(%v)", err)
+			}
+			defer pub.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			want := make(map[string]msg, len(tt.msgs))
+			for _, m := range tt.msgs {
+				want[m.value] = m
+				pm := &Message{Topic: topic(m.topic), Key: m.key, Value: []byte(m.value), Headers: map[string]string{"h": m.value}}
+				if err = pub.Publish(ctx, pm); err != nil {
+					t.Fatalf("Publish(%s) error(%v)", m.value, err)
+				}
+			}
+			for range tt.msgs {
+				select {
+				case got := <-sub.Messages():
+					m, ok := want[string(got.Value)]
+					if !ok {
+						t.Fatalf("unexpected msg %s", got.Value)
+					}
+					delete(want, string(got.Value))
+					if got.Topic != topic(m.topic) || got.Key != m.key || got.Partition != NoPartition || got.Headers["h"] != m.value {
+						t.Fatalf("msg %+v, want %+v", got, m)
+					}
+					got.Done()
+				case <-ctx.Done():
+					t.Fatalf("msgs %v not received", want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package bus
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wcaqrl/chime/pkg/metric"
+)
+
+var (
+	publishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metric.Namespace,
+		Subsystem: "bus",
+		Name:      "publish_duration_seconds",
+		Help:      "Latencies of the publishes by topic.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+	publishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: "bus",
+		Name:      "publish_errors_total",
+		Help:      "Failed publishes by topic.",
+	}, []string{"topic"})
+	consumeLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metric.Namespace,
+		Subsystem: "bus",
+		Name:      "consume_lag_seconds",
+		Help:      "Time the messages waited on the bus by topic.",
+		Buckets:   []float64{.005, .01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"topic"})
+)
+
+// metricPublisher observe the publishes of a publisher.
+type metricPublisher struct {
+	Publisher
+}
+
+func (p *metricPublisher) Publish(c context.Context, msg *Message) (err error) {
+	start := time.Now()
+	if err = p.Publisher.Publish(c, msg); err != nil {
+		publishErrors.WithLabelValues(msg.Topic).Inc()
+	}
+	publishDuration.WithLabelValues(msg.Topic).Observe(time.Since(start).Seconds())
+	return
+}
+
+// ObserveLag observe the time a consumed message waited on the bus, ignored
+// if the bus does not keep the produce time.
+func ObserveLag(msg *Message) {
+	if !msg.Timestamp.IsZero() {
+		consumeLag.WithLabelValues(msg.Topic).Observe(time.Since(msg.Timestamp).Seconds())
+	}
+}
@@ -0,0 +1,95 @@
+package bus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	_natsHeaderKey = "Chime-Key"
+)
+
+type natsPublisher struct {
+	conn *nats.Conn
+}
+
+func newNatsPublisher(c *Config) (*natsPublisher, error) {
+	conn, err := nats.Connect(c.NatsURL)
+	if err != nil {
+		return nil, err
+	}
+	return &natsPublisher{conn: conn}, nil
+}
+
+func (p *natsPublisher) Publish(c context.Context, msg *Message) error {
+	m := nats.NewMsg(msg.Topic)
+	m.Data = msg.Value
+	if msg.Key != "" {
+		m.Header.Set(_natsHeaderKey, msg.Key)
+	}
+	for k, v := range msg.Headers {
+		m.Header.Set(k, v)
+	}
+	return p.conn.PublishMsg(m)
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
+
+// natsSubscriber queue subscribe the topics, the group members share the messages.
+type natsSubscriber struct {
+	conn *nats.Conn
+	msgs chan *Message
+	once sync.Once
+}
+
+func newNatsSubscriber(c *Config, group string, topics []string) (*natsSubscriber, error) {
+	s := &natsSubscriber{msgs: make(chan *Message, c.Buffer)}
+	conn, err := nats.Connect(c.NatsURL, nats.ClosedHandler(func(*nats.Conn) {
+		s.once.Do(func() {
+			close(s.msgs)
+		})
+	}))
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+	for _, topic := range topics {
+		if _, err = conn.QueueSubscribe(topic, group, s.handle); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *natsSubscriber) handle(m *nats.Msg) {
+	var headers map[string]string
+	for k := range m.Header {
+		if k != _natsHeaderKey {
+			if headers == nil {
+				headers = make(map[string]string)
+			}
+			headers[k] = m.Header.Get(k)
+		}
+	}
+	s.msgs <- &Message{
+		Topic:     m.Subject,
+		Key:       m.Header.Get(_natsHeaderKey),
+		Value:     m.Data,
+		Partition: NoPartition,
+		Headers:   headers,
+	}
+}
+
+func (s *natsSubscriber) Messages() <-chan *Message {
+	return s.msgs
+}
+
+// Close drain the pending messages then close the conn.
+func (s *natsSubscriber) Close() error {
+	return s.conn.Drain()
+}
@@ -0,0 +1,291 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	_redisFieldKey     = "key"
+	_redisFieldValue   = "value"
+	_redisHeaderPrefix = "h:"
+	_redisReadCount    = 128
+)
+
+var errRedisReply = errors.New("unexpected redis stream reply")
+
+type redisPublisher struct {
+	pool   *redis.Pool
+	maxLen int64
+}
+
+func newRedisPublisher(c *Config) (*redisPublisher, error) {
+	return &redisPublisher{pool: c.Redis, maxLen: c.MaxLen}, nil
+}
+
+func (p *redisPublisher) Publish(c context.Context, msg *Message) (err error) {
+	conn := p.pool.Get()
+	defer conn.Close()
+	args := redis.Args{msg.Topic}
+	if p.maxLen > 0 {
+		args = args.Add("MAXLEN", "~", p.maxLen)
+	}
+	args = args.Add("*", _redisFieldKey, msg.Key, _redisFieldValue, msg.Value)
+	for k, v := range msg.Headers {
+		args = args.Add(_redisHeaderPrefix+k, v)
+	}
+	if _, err = conn.Do("XADD", args...); err != nil {
+		log.Errorf("conn.Do(XADD %s) error(%v)", msg.Topic, err)
+	}
+	return
+}
+
+func (p *redisPublisher) Close() error {
+	return nil
+}
+
+type redisSubscriber struct {
+	pool   *redis.Pool
+	group  string
+	name   string
+	topics []string
+	block  time.Duration
+	// min idle of the pending entries claimed, disabled if zero
+	minIdle time.Duration
+	msgs    chan *Message
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newRedisSubscriber(c *Config, group, name string, topics []string) (s *redisSubscriber, err error) {
+	s = &redisSubscriber{
+		pool:    c.Redis,
+		group:   group,
+		name:    name,
+		topics:  topics,
+		block:   c.Block,
+		minIdle: c.Claim,
+		msgs:    make(chan *Message),
+		closed:  make(chan struct{}),
+	}
+	if s.block <= 0 {
+		s.block = time.Second
+	}
+	conn := s.pool.Get()
+	defer conn.Close()
+	for _, topic := range topics {
+		if _, err = conn.Do("XGROUP", "CREATE", topic, group, "$", "MKSTREAM"); err != nil {
+			if !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+				log.Errorf("conn.Do(XGROUP CREATE %s %s) error(%v)", topic, group, err)
+				return nil, err
+			}
+			err = nil
+		}
+	}
+	go s.consumeproc()
+	return
+}
+
+func (s *redisSubscriber) consumeproc() {
+	defer close(s.msgs)
+	args := redis.Args{"GROUP", s.group, s.name, "COUNT", _redisReadCount, "BLOCK", int64(s.block / time.Millisecond), "STREAMS"}
+	args = args.AddFlat(s.topics)
+	for range s.topics {
+		args = args.Add(">")
+	}
+	var lastClaim time.Time
+	for {
+		select {
+		case <-s.closed:
+			return
+		default:
+		}
+		var (
+			msgs []*Message
+			err  error
+		)
+		if s.minIdle > 0 && time.Since(lastClaim) >= s.minIdle/2 {
+			lastClaim = time.Now()
+			for _, topic := range s.topics {
+				claimed, err := s.claim(topic)
+				if err != nil {
+					log.Errorf("redis stream claim(%s) error(%v)", topic, err)
+				}
+				msgs = append(msgs, claimed...)
+			}
+		}
+		if len(msgs) == 0 {
+			if msgs, err = s.read(args); err != nil {
+				log.Errorf("redis stream read(%v) error(%v)", s.topics, err)
+				time.Sleep(s.block)
+				continue
+			}
+		}
+		for _, msg := range msgs {
+			select {
+			case s.msgs <- msg:
+			case <-s.closed:
+				return
+			}
+		}
+	}
+}
+
+// read parse the XREADGROUP reply: [[stream, [[id, [field, value, ...]], ...]], ...]
+func (s *redisSubscriber) read(args redis.Args) (msgs []*Message, err error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+	streams, err := redis.Values(conn.Do("XREADGROUP", args...))
+	if err != nil {
+		if err == redis.ErrNil {
+			err = nil
+		}
+		return
+	}
+	for _, stream := range streams {
+		var (
+			topic   string
+			entries []interface{}
+			parsed  []*Message
+		)
+		values, ok := stream.([]interface{})
+		if !ok {
+			return nil, errRedisReply
+		}
+		if _, err = redis.Scan(values, &topic, &entries); err != nil {
+			return
+		}
+		if parsed, err = s.parse(topic, entries); err != nil {
+			return
+		}
+		msgs = append(msgs, parsed...)
+	}
+	return
+}
+
+// claim take over the pending entries idle for the min idle, they were
+// delivered to a consumer which crashed before acking them. The entries are
+// parsed like the XREADGROUP ones.
+func (s *redisSubscriber) claim(topic string) (msgs []*Message, err error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+	// [[id, consumer, idle ms, deliveries], ...]
+	pending, err := redis.Values(conn.Do("XPENDING", topic, s.group, "-", "+", _redisReadCount))
+	if err != nil {
+		return
+	}
+	minIdle := int64(s.minIdle / time.Millisecond)
+	args := redis.Args{topic, s.group, s.name, minIdle}
+	n := len(args)
+	for _, p := range pending {
+		var (
+			id   string
+			idle int64
+		)
+		values, ok := p.([]interface{})
+		if !ok || len(values) < 3 {
+			return nil, errRedisReply
+		}
+		if id, err = redis.String(values[0], nil); err != nil {
+			return
+		}
+		if idle, err = redis.Int64(values[2], nil); err != nil {
+			return
+		}
+		if idle >= minIdle {
+			args = args.Add(id)
+		}
+	}
+	if len(args) == n {
+		return
+	}
+	entries, err := redis.Values(conn.Do("XCLAIM", args...))
+	if err != nil {
+		return
+	}
+	if msgs, err = s.parse(topic, entries); err == nil && len(msgs) > 0 {
+		log.Infof("redis stream %s claimed %d pending entries", topic, len(msgs))
+	}
+	return
+}
+
+// parse the entries of a stream: [[id, [field, value, ...]], ...], an entry
+// trimmed meanwhile is nil.
+func (s *redisSubscriber) parse(topic string, entries []interface{}) (msgs []*Message, err error) {
+	for _, entry := range entries {
+		var (
+			id      string
+			fields  map[string]string
+			headers map[string]string
+		)
+		if entry == nil {
+			continue
+		}
+		kv, ok := entry.([]interface{})
+		if !ok || len(kv) != 2 {
+			return nil, errRedisReply
+		}
+		if id, err = redis.String(kv[0], nil); err != nil {
+			return
+		}
+		if fields, err = redis.StringMap(kv[1], nil); err != nil {
+			return
+		}
+		for field, v := range fields {
+			if strings.HasPrefix(field, _redisHeaderPrefix) {
+				if headers == nil {
+					headers = make(map[string]string)
+				}
+				headers[strings.TrimPrefix(field, _redisHeaderPrefix)] = v
+			}
+		}
+		msgs = append(msgs, &Message{
+			Topic:     topic,
+			Key:       fields[_redisFieldKey],
+			Value:     []byte(fields[_redisFieldValue]),
+			Partition: NoPartition,
+			Timestamp: redisIDTime(id),
+			Headers:   headers,
+			done:      s.acker(topic, id),
+		})
+	}
+	return
+}
+
+// redisIDTime get the time an entry was added from its id, ms-seq.
+func redisIDTime(id string) time.Time {
+	if idx := strings.IndexByte(id, '-'); idx > 0 {
+		if ms, err := strconv.ParseInt(id[:idx], 10, 64); err == nil {
+			return time.Unix(0, ms*int64(time.Millisecond))
+		}
+	}
+	return time.Time{}
+}
+
+func (s *redisSubscriber) acker(topic, id string) func() {
+	return func() {
+		conn := s.pool.Get()
+		defer conn.Close()
+		if _, err := conn.Do("XACK", topic, s.group, id); err != nil {
+			log.Errorf("conn.Do(XACK %s %s) error(%v)", topic, id, err)
+		}
+	}
+}
+
+func (s *redisSubscriber) Messages() <-chan *Message {
+	return s.msgs
+}
+
+func (s *redisSubscriber) Close() error {
+	s.once.Do(func() {
+		close(s.closed)
+	})
+	return nil
+}
@@ -0,0 +1,34 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/wcaqrl/chime/pkg/tracing"
+)
+
+// tracePublisher record a producer span per publish and carry it in the
+// traceparent header.
+type tracePublisher struct {
+	Publisher
+}
+
+func (p *tracePublisher) Publish(c context.Context, msg *Message) (err error) {
+	c, span := tracing.Start(c, "bus.Publish", tracing.KindProducer)
+	if span == nil {
+		return p.Publisher.Publish(c, msg)
+	}
+	span.SetAttr("topic", msg.Topic)
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]string)
+	}
+	msg.Headers[tracing.HeaderTraceparent] = tracing.Traceparent(c)
+	err = p.Publisher.Publish(c, msg)
+	span.SetError(err)
+	span.End()
+	return
+}
+
+// ContextWithTrace set the remote parent carried by the message headers.
+func ContextWithTrace(c context.Context, msg *Message) context.Context {
+	return tracing.ContextWithRemote(c, msg.Headers[tracing.HeaderTraceparent])
+}
@@ -0,0 +1,182 @@
+package comet
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+	"github.com/wcaqrl/chime/internal/comet/errors"
+)
+
+type ackItem struct {
+	p        *protocol.Proto
+	id       string // push id
+	deadline time.Time
+	attempts int
+}
+
+// Acker track the server pushes waiting for a client ack and redeliver them on timeout.
+// An acked push counts as delivered, a push dropped or expired as dropped.
+type Acker struct {
+	c          *conf.Ack
+	deliveries *Deliveries
+	mutex      sync.Mutex
+	chs        map[*Channel]struct{} // channels having pending pushes
+	// counters since last report
+	delivered int64
+	dropped   int64
+	expired   int64
+}
+
+// NewAcker new an acker.
+func NewAcker(c *conf.Ack, deliveries *Deliveries) *Acker {
+	return &Acker{
+		c:          c,
+		deliveries: deliveries,
+		chs:        make(map[*Channel]struct{}),
+	}
+}
+
+// Push push a message of the push id to the channel and wait for its ack.
+func (a *Acker) Push(ch *Channel, p *protocol.Proto, id string) (err error) {
+	ch.ackMutex.Lock()
+	if ch.acks == nil {
+		ch.acks = make(map[int32]*ackItem)
+	}
+	if len(ch.acks) >= a.c.Pending {
+		ch.ackMutex.Unlock()
+		atomic.AddInt64(&a.dropped, 1)
+		a.deliveries.Add(id, 0, 1)
+		return errors.ErrAckPendingFull
+	}
+	// a raw proto packing several protos is acked by its last seq
+	n := p.Count()
+	seq := ch.NextSeq(n) + n - 1
+	item := &ackItem{
+		p:        p.WithSeq(seq - n + 1),
+		id:       id,
+		deadline: time.Now().Add(time.Duration(a.c.Timeout)),
+		attempts: 1,
+	}
+	ch.acks[seq] = item
+	ch.ackMutex.Unlock()
+	a.mutex.Lock()
+	a.chs[ch] = struct{}{}
+	a.mutex.Unlock()
+	// a full signal is not fatal, redelivered after timeout
+	if err = ch.Push(item.p); err != nil {
+		log.Warningf("key: %s ack push seq:%d error(%v)", ch.Key, seq, err)
+	}
+	return nil
+}
+
+// Ack the client received the push of seq.
+func (a *Acker) Ack(ch *Channel, seq int32) (ok bool) {
+	ch.ackMutex.Lock()
+	item, ok := ch.acks[seq]
+	if ok {
+		delete(ch.acks, seq)
+	}
+	ch.ackMutex.Unlock()
+	if ok {
+		atomic.AddInt64(&a.delivered, 1)
+		a.deliveries.Add(item.id, 1, 0)
+	}
+	return
+}
+
+// Release the channel disconnected, all pending pushes are dropped.
+func (a *Acker) Release(ch *Channel) {
+	ch.ackMutex.Lock()
+	acks := ch.acks
+	ch.acks = nil
+	ch.ackMutex.Unlock()
+	if len(acks) > 0 {
+		atomic.AddInt64(&a.dropped, int64(len(acks)))
+		for _, item := range acks {
+			a.deliveries.Add(item.id, 0, 1)
+		}
+	}
+	a.mutex.Lock()
+	delete(a.chs, ch)
+	a.mutex.Unlock()
+}
+
+// Stats return the counters since last call.
+func (a *Acker) Stats() (delivered, dropped, expired int64) {
+	return atomic.SwapInt64(&a.delivered, 0), atomic.SwapInt64(&a.dropped, 0), atomic.SwapInt64(&a.expired, 0)
+}
+
+func (a *Acker) redeliver(now time.Time) {
+	a.mutex.Lock()
+	chs := make([]*Channel, 0, len(a.chs))
+	for ch := range a.chs {
+		chs = append(chs, ch)
+	}
+	a.mutex.Unlock()
+	for _, ch := range chs {
+		var resend []*protocol.Proto
+		ch.ackMutex.Lock()
+		for seq, item := range ch.acks {
+			if now.Before(item.deadline) {
+				continue
+			}
+			if item.attempts > a.c.Retry {
+				delete(ch.acks, seq)
+				atomic.AddInt64(&a.expired, 1)
+				a.deliveries.Add(item.id, 0, 1)
+				continue
+			}
+			item.attempts++
+			item.deadline = now.Add(time.Duration(a.c.Timeout))
+			resend = append(resend, item.p)
+		}
+		idle := len(ch.acks) == 0
+		ch.ackMutex.Unlock()
+		for _, p := range resend {
+			_ = ch.Push(p)
+		}
+		if idle {
+			a.mutex.Lock()
+			delete(a.chs, ch)
+			a.mutex.Unlock()
+		}
+	}
+}
+
+// ackproc redeliver the timeout pushes and report the counters to logic.
+func (s *Server) ackproc() {
+	var (
+		tick       = time.Duration(s.c.Ack.Timeout) / 2
+		lastReport = time.Now()
+	)
+	if tick <= 0 {
+		tick = time.Second
+	}
+	for {
+		time.Sleep(tick)
+		now := time.Now()
+		s.acker.redeliver(now)
+		if now.Sub(lastReport) < time.Duration(s.c.Ack.Report) {
+			continue
+		}
+		lastReport = now
+		delivered, dropped, expired := s.acker.Stats()
+		if delivered == 0 && dropped == 0 && expired == 0 {
+			continue
+		}
+		if _, err := s.rpcClient.AckReport(context.Background(), &logic.AckReportReq{
+			Server:    s.serverID,
+			Delivered: delivered,
+			Dropped:   dropped,
+			Expired:   expired,
+		}); err != nil {
+			log.Errorf("s.rpcClient.AckReport(delivered:%d dropped:%d expired:%d) error(%v)", delivered, dropped, expired, err)
+		}
+	}
+}
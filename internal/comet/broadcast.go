@@ -0,0 +1,378 @@
+package comet
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	pb "github.com/wcaqrl/chime/api/comet"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+	"github.com/wcaqrl/chime/internal/comet/errors"
+)
+
+// the states of a broadcast.
+const (
+	BroadcastQueued   = "queued"
+	BroadcastRunning  = "running"
+	BroadcastDone     = "done"
+	BroadcastCanceled = "canceled"
+	BroadcastExpired  = "expired"
+)
+
+// broadcast a queued or running broadcast, the progress is guarded by the
+// mutex of the broadcaster.
+type broadcast struct {
+	arg      *pb.BroadcastReq
+	progress *pb.BroadcastProgress
+	cancel   chan struct{}
+}
+
+// Broadcaster run a bounded number of broadcasts at once by a fixed pool of
+// workers, so a paced one does not hold the others back, the others wait in a
+// bounded queue in order. The msgs of each are paced by its speed and the
+// msgs of all by a token bucket shared across the broadcasts.
+type Broadcaster struct {
+	c          *conf.Broadcast
+	buckets    []*Bucket
+	deliveries *Deliveries
+	tb         *tokenBucket    // the rate of all the broadcasts
+	seq        uint64          // ids of the broadcasts without push id
+	queue      chan *broadcast // queued in order, taken by the workers
+
+	mutex   sync.Mutex
+	active  map[string]*broadcast // queued and running by id
+	history []*broadcast          // finished, the latest last
+}
+
+// NewBroadcaster new a broadcaster of the buckets and start its workers.
+func NewBroadcaster(c *conf.Broadcast, buckets []*Bucket, deliveries *Deliveries) *Broadcaster {
+	b := &Broadcaster{
+		c:          c,
+		buckets:    buckets,
+		deliveries: deliveries,
+		tb:         newTokenBucket(c.Rate, c.Burst),
+		queue:      make(chan *broadcast, c.Queue),
+		active:     make(map[string]*broadcast),
+	}
+	workers := c.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go b.work()
+	}
+	return b
+}
+
+// work run the queued broadcasts one after another.
+func (b *Broadcaster) work() {
+	for bc := range b.queue {
+		b.run(bc)
+	}
+}
+
+// Add queue a broadcast and return its id, it runs once a worker is free. A
+// retry of a push id queued or kept in the history is not broadcasted again.
+func (b *Broadcaster) Add(arg *pb.BroadcastReq) (id string, err error) {
+	if id = arg.Id; id == "" {
+		id = "b" + strconv.FormatUint(atomic.AddUint64(&b.seq, 1), 10)
+	}
+	bc := &broadcast{
+		arg: arg,
+		progress: &pb.BroadcastProgress{
+			Id:      id,
+			State:   BroadcastQueued,
+			Rate:    int32(b.rate(arg.Speed)),
+			Created: time.Now().Unix(),
+		},
+		cancel: make(chan struct{}),
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.find(id) != nil {
+		return
+	}
+	if len(b.active) >= b.c.Queue {
+		return "", errors.ErrBroadcastFull
+	}
+	// the queue holds all the active ones, it never blocks here
+	select {
+	case b.queue <- bc:
+	default:
+		return "", errors.ErrBroadcastFull
+	}
+	b.active[id] = bc
+	broadcastQueue.Inc()
+	return
+}
+
+// Cancel cancel a queued or running broadcast, false if it is unknown or
+// finished.
+func (b *Broadcaster) Cancel(id string) bool {
+	b.mutex.Lock()
+	bc, ok := b.active[id]
+	if !ok || bc.progress.State == BroadcastCanceled {
+		b.mutex.Unlock()
+		return false
+	}
+	// a queued one is finished at once and skipped by the workers, a running
+	// one stops before its next msgs
+	queued := bc.progress.State == BroadcastQueued
+	bc.progress.State = BroadcastCanceled
+	close(bc.cancel)
+	if queued {
+		b.retire(bc, BroadcastCanceled)
+	}
+	b.mutex.Unlock()
+	if queued {
+		b.report(bc, BroadcastCanceled)
+	}
+	return true
+}
+
+// Broadcasts get the progress of the queued, running and finished
+// broadcasts, or of the broadcast of the id only.
+func (b *Broadcaster) Broadcasts(id string) (res []*pb.BroadcastProgress) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if id != "" {
+		if bc := b.find(id); bc != nil {
+			res = append(res, copyProgress(bc.progress))
+		}
+		return
+	}
+	for _, bc := range b.history {
+		res = append(res, copyProgress(bc.progress))
+	}
+	for _, bc := range b.active {
+		res = append(res, copyProgress(bc.progress))
+	}
+	return
+}
+
+func copyProgress(p *pb.BroadcastProgress) *pb.BroadcastProgress {
+	return &pb.BroadcastProgress{
+		Id:        p.Id,
+		State:     p.State,
+		Rate:      p.Rate,
+		Total:     p.Total,
+		Sent:      p.Sent,
+		Delivered: p.Delivered,
+		Dropped:   p.Dropped,
+		Created:   p.Created,
+		Started:   p.Started,
+		Finished:  p.Finished,
+	}
+}
+
+func (b *Broadcaster) find(id string) *broadcast {
+	if bc, ok := b.active[id]; ok {
+		return bc
+	}
+	for _, bc := range b.history {
+		if bc.progress.Id == id {
+			return bc
+		}
+	}
+	return nil
+}
+
+// rate get the msgs per second of a broadcast, the lower of its speed and
+// the configured rate, unpaced if zero.
+func (b *Broadcaster) rate(speed int32) int {
+	rate := b.c.Rate
+	if speed > 0 && (rate <= 0 || int(speed) < rate) {
+		rate = int(speed)
+	}
+	return rate
+}
+
+// run push a broadcast to the conns of every bucket, a batch at a time. The
+// batch is at most the burst and the rate, so a slow broadcast is smooth.
+func (b *Broadcaster) run(bc *broadcast) {
+	var total int64
+	for _, bucket := range b.buckets {
+		total += int64(bucket.ChannelCount())
+	}
+	b.mutex.Lock()
+	if bc.progress.State != BroadcastQueued {
+		// canceled while queued
+		b.mutex.Unlock()
+		return
+	}
+	bc.progress.State = BroadcastRunning
+	bc.progress.Total = total
+	bc.progress.Started = time.Now().Unix()
+	b.mutex.Unlock()
+	p, batch := bc.arg.Proto, b.c.Burst
+	if rate := int(bc.progress.Rate); rate > 0 && rate < batch {
+		batch = rate
+	}
+	if batch <= 0 {
+		batch = 1
+	}
+	tb := newTokenBucket(int(bc.progress.Rate), batch)
+	for _, bucket := range b.buckets {
+		chs := bucket.Channels()
+		for i := 0; i < len(chs); i += batch {
+			end := i + batch
+			if end > len(chs) {
+				end = len(chs)
+			}
+			if !tb.take(end-i, bc.cancel) || !b.tb.take(end-i, bc.cancel) {
+				b.finish(bc, BroadcastCanceled)
+				return
+			}
+			if p.Expired() {
+				b.finish(bc, BroadcastExpired)
+				return
+			}
+			delivered, dropped := broadcastChannels(chs[i:end], p, bc.arg.ProtoOp)
+			// the local ids of the broadcasts without push id are not reported
+			b.deliveries.Add(bc.arg.Id, delivered, dropped)
+			broadcastMsgs.WithLabelValues("delivered").Add(float64(delivered))
+			broadcastMsgs.WithLabelValues("dropped").Add(float64(dropped))
+			b.mutex.Lock()
+			bc.progress.Sent += int64(end - i)
+			bc.progress.Delivered += delivered
+			bc.progress.Dropped += dropped
+			b.setProgress()
+			b.mutex.Unlock()
+		}
+	}
+	b.finish(bc, BroadcastDone)
+}
+
+// setProgress set the progress of the running broadcasts, the mutex is held.
+func (b *Broadcaster) setProgress() {
+	var sent, total int64
+	for _, bc := range b.active {
+		if bc.progress.State == BroadcastRunning {
+			sent += bc.progress.Sent
+			total += bc.progress.Total
+		}
+	}
+	if total > 0 {
+		broadcastProgress.Set(float64(sent) / float64(total))
+	} else {
+		broadcastProgress.Set(0)
+	}
+}
+
+// finish move a broadcast to the history, a broadcast canceled meanwhile
+// stays canceled.
+func (b *Broadcaster) finish(bc *broadcast, state string) {
+	b.mutex.Lock()
+	if bc.progress.State == BroadcastCanceled {
+		state = BroadcastCanceled
+	}
+	b.retire(bc, state)
+	b.mutex.Unlock()
+	b.report(bc, state)
+}
+
+// retire move a broadcast in the state to the history, the mutex is held.
+func (b *Broadcaster) retire(bc *broadcast, state string) {
+	bc.progress.State = state
+	bc.progress.Finished = time.Now().Unix()
+	delete(b.active, bc.progress.Id)
+	if b.c.History > 0 {
+		if len(b.history) >= b.c.History {
+			b.history[0] = nil
+			b.history = b.history[1:]
+		}
+		b.history = append(b.history, bc)
+	}
+	b.setProgress()
+}
+
+// report count and log a finished broadcast.
+func (b *Broadcaster) report(bc *broadcast, state string) {
+	broadcastQueue.Dec()
+	broadcasts.WithLabelValues(state).Inc()
+	log.Infof("broadcast:%s %s total:%d sent:%d delivered:%d dropped:%d", bc.progress.Id, state,
+		bc.progress.Total, bc.progress.Sent, bc.progress.Delivered, bc.progress.Dropped)
+}
+
+// broadcastChannels push a msg to the channels watching its op, it returns
+// the channels the msg was delivered to or dropped for.
+func broadcastChannels(chs []*Channel, p *protocol.Proto, op int32) (delivered, dropped int64) {
+	for _, ch := range chs {
+		if !ch.NeedPush(op) {
+			continue
+		}
+		if ch.Push(p) != nil {
+			dropped++
+		} else {
+			delivered++
+		}
+	}
+	return
+}
+
+// tokenBucket pace the msgs, it refills rate tokens a second up to burst. It
+// is safe for concurrent use, a take reserves its tokens before waiting.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// take wait for n tokens, it returns false once canceled. The tokens go
+// negative while reserved, the later takes wait for them to refill.
+func (t *tokenBucket) take(n int, cancel <-chan struct{}) bool {
+	if t.rate <= 0 {
+		select {
+		case <-cancel:
+			return false
+		default:
+			return true
+		}
+	}
+	if wait := t.reserve(n, time.Now()); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-cancel:
+			timer.Stop()
+			return false
+		}
+		return true
+	}
+	select {
+	case <-cancel:
+		return false
+	default:
+		return true
+	}
+}
+
+// reserve take n tokens at now and return how long to wait for them.
+func (t *tokenBucket) reserve(n int, now time.Time) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if now.After(t.last) {
+		if t.tokens += now.Sub(t.last).Seconds() * t.rate; t.tokens > t.burst {
+			t.tokens = t.burst
+		}
+		t.last = now
+	}
+	if t.tokens -= float64(n); t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
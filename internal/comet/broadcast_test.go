@@ -0,0 +1,194 @@
+package comet
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/wcaqrl/chime/api/comet"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+	"github.com/wcaqrl/chime/internal/comet/errors"
+)
+
+func TestBroadcasterQueue(t *testing.T) {
+	type step struct {
+		op   string // add or cancel
+		id   string
+		err  error
+		want map[string]string // states of the broadcasts after the step
+	}
+	tests := []struct {
+		name    string
+		workers int
+		queue   int
+		steps   []step
+	}{
+		{
+			name: "one worker runs them in order", workers: 1, queue: 3,
+			steps: []step{
+				{op: "add", id: "a", want: map[string]string{"a": BroadcastRunning}},
+				{op: "add", id: "b", want: map[string]string{"a": BroadcastRunning, "b": BroadcastQueued}},
+				{op: "add", id: "c", want: map[string]string{"a": BroadcastRunning, "b": BroadcastQueued, "c": BroadcastQueued}},
+				{op: "cancel", id: "a", want: map[string]string{"a": BroadcastCanceled, "b": BroadcastRunning, "c": BroadcastQueued}},
+				{op: "cancel", id: "b", want: map[string]string{"b": BroadcastCanceled, "c": BroadcastRunning}},
+				{op: "cancel", id: "c", want: map[string]string{"c": BroadcastCanceled}},
+			},
+		},
+		{
+			name: "workers cap the running ones", workers: 2, queue: 3,
+			steps: []step{
+				{op: "add", id: "a", want: map[string]string{"a": BroadcastRunning}},
+				{op: "add", id: "b", want: map[string]string{"a": BroadcastRunning, "b": BroadcastRunning}},
+				{op: "add", id: "c", want: map[string]string{"a": BroadcastRunning, "b": BroadcastRunning, "c": BroadcastQueued}},
+				{op: "cancel", id: "b", want: map[string]string{"a": BroadcastRunning, "b": BroadcastCanceled, "c": BroadcastRunning}},
+				{op: "cancel", id: "a"},
+				{op: "cancel", id: "c"},
+			},
+		},
+		{
+			name: "full queue", workers: 1, queue: 2,
+			steps: []step{
+				{op: "add", id: "a", want: map[string]string{"a": BroadcastRunning}},
+				{op: "add", id: "b", want: map[string]string{"b": BroadcastQueued}},
+				{op: "add", id: "c", err: errors.ErrBroadcastFull},
+				{op: "cancel", id: "b", want: map[string]string{"a": BroadcastRunning, "b": BroadcastCanceled}},
+				{op: "add", id: "c", want: map[string]string{"c": BroadcastQueued}},
+				{op: "cancel", id: "a", want: map[string]string{"a": BroadcastCanceled, "c": BroadcastRunning}},
+				{op: "cancel", id: "c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := NewBucket(&conf.Bucket{Channel: 1, Room: 1}, nil)
+			ch := NewChannel(1, 1)
+			ch.Key = "key"
+			ch.Watch(1)
+			_ = bucket.Put("", ch)
+			c := &conf.Broadcast{Workers: tt.workers, Queue: tt.queue, Rate: 1, Burst: 1, History: 8}
+			b := NewBroadcaster(c, []*Bucket{bucket}, nil)
+			// the shared bucket is drained, a running broadcast waits until canceled
+			b.tb.reserve(1<<20, time.Now())
+			for i, s := range tt.steps {
+				switch s.op {
+				case "add":
+					if _, err := b.Add(&pb.BroadcastReq{Id: s.id, ProtoOp: 1, Proto: &protocol.Proto{Op: 1}}); err != s.err {
+						t.Fatalf("step %d Add(%s) error(%v), want %v", i, s.id, err, s.err)
+					}
+				case "cancel":
+					if !b.Cancel(s.id) {
+						t.Fatalf("step %d Cancel(%s) = false", i, s.id)
+					}
+				}
+				waitStates(t, b, i, s.want)
+			}
+		})
+	}
+}
+
+// waitStates wait for the broadcasts to get to the states.
+func waitStates(t *testing.T, b *Broadcaster, step int, want map[string]string) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		got := make(map[string]string)
+		for _, p := range b.Broadcasts("") {
+			got[p.Id] = p.State
+		}
+		ok := true
+		for id, state := range want {
+			if got[id] != state {
+				ok = false
+			}
+		}
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("step %d states %v, want %v", step, got, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	type take struct {
+		at   time.Duration // time of the take from the start
+		n    int
+		wait time.Duration
+	}
+	tests := []struct {
+		name  string
+		rate  int
+		burst int
+		takes []take
+	}{
+		{
+			name: "within burst",
+			rate: 100, burst: 50,
+			takes: []take{{0, 20, 0}, {0, 30, 0}},
+		},
+		{
+			name: "over burst waits for the refill",
+			rate: 100, burst: 50,
+			takes: []take{{0, 50, 0}, {0, 10, 100 * time.Millisecond}},
+		},
+		{
+			name: "reserved tokens delay the later takes",
+			rate: 100, burst: 50,
+			takes: []take{{0, 50, 0}, {0, 10, 100 * time.Millisecond}, {0, 10, 200 * time.Millisecond}},
+		},
+		{
+			name: "refill pays the reservations back",
+			rate: 100, burst: 50,
+			takes: []take{{0, 60, 100 * time.Millisecond}, {100 * time.Millisecond, 10, 100 * time.Millisecond}, {300 * time.Millisecond, 10, 0}},
+		},
+		{
+			name: "idle refill is capped at burst",
+			rate: 100, burst: 50,
+			takes: []take{{0, 50, 0}, {10 * time.Second, 50, 0}, {10 * time.Second, 1, 10 * time.Millisecond}},
+		},
+		{
+			name: "past time does not refill",
+			rate: 100, burst: 10,
+			takes: []take{{time.Second, 10, 0}, {0, 10, 100 * time.Millisecond}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTokenBucket(tt.rate, tt.burst)
+			start := tb.last
+			for i, tk := range tt.takes {
+				if wait := tb.reserve(tk.n, start.Add(tk.at)); wait != tk.wait {
+					t.Fatalf("take %d reserve(%d) wait %s, want %s", i, tk.n, wait, tk.wait)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenBucketTake(t *testing.T) {
+	canceled := make(chan struct{})
+	close(canceled)
+	tests := []struct {
+		name   string
+		rate   int
+		burst  int
+		n      int
+		cancel <-chan struct{}
+		want   bool
+	}{
+		{"unlimited", 0, 0, 1000, nil, true},
+		{"unlimited canceled", 0, 0, 1, canceled, false},
+		{"within burst", 10, 10, 10, nil, true},
+		{"within burst canceled", 10, 10, 1, canceled, false},
+		{"canceled while waiting", 1, 1, 100, canceled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTokenBucket(tt.rate, tt.burst)
+			if got := tb.take(tt.n, tt.cancel); got != tt.want {
+				t.Fatalf("take(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"sync/atomic"
 
 	pb "github.com/wcaqrl/chime/api/comet"
-	"github.com/wcaqrl/chime/api/protocol"
 	"github.com/wcaqrl/chime/internal/comet/conf"
 )
 
@@ -17,23 +16,29 @@ type Bucket struct {
 	// room
 	rooms       map[string]*Room // bucket room channels
 	routines    []chan *pb.BroadcastRoomReq
+	bulks       []chan *pb.BroadcastRoomReq // bulk broadcasts of the routines
 	routinesNum uint64
+	deliveries  *Deliveries
 
 	ipCnts map[string]int32
 }
 
 // NewBucket new a bucket struct. store the key with im channel.
-func NewBucket(c *conf.Bucket) (b *Bucket) {
+func NewBucket(c *conf.Bucket, deliveries *Deliveries) (b *Bucket) {
 	b = new(Bucket)
+	b.deliveries = deliveries
 	b.chs = make(map[string]*Channel, c.Channel)
 	b.ipCnts = make(map[string]int32)
 	b.c = c
 	b.rooms = make(map[string]*Room, c.Room)
 	b.routines = make([]chan *pb.BroadcastRoomReq, c.RoutineAmount)
+	b.bulks = make([]chan *pb.BroadcastRoomReq, c.RoutineAmount)
 	for i := uint64(0); i < c.RoutineAmount; i++ {
 		rc := make(chan *pb.BroadcastRoomReq, c.RoutineSize)
+		bc := make(chan *pb.BroadcastRoomReq, c.RoutineSize)
 		b.routines[i] = rc
-		go b.roomproc(rc)
+		b.bulks[i] = bc
+		go b.roomproc(rc, bc)
 	}
 	return
 }
@@ -154,17 +159,15 @@ func (b *Bucket) Channel(key string) (ch *Channel) {
 	return
 }
 
-// Broadcast push msgs to all channels in the bucket.
-func (b *Bucket) Broadcast(p *protocol.Proto, op int32) {
-	var ch *Channel
+// Channels get all channels in the bucket.
+func (b *Bucket) Channels() (chs []*Channel) {
 	b.cLock.RLock()
-	for _, ch = range b.chs {
-		if !ch.NeedPush(op) {
-			continue
-		}
-		_ = ch.Push(p)
+	chs = make([]*Channel, 0, len(b.chs))
+	for _, ch := range b.chs {
+		chs = append(chs, ch)
 	}
 	b.cLock.RUnlock()
+	return
 }
 
 // Room get a room by roomId.
@@ -183,9 +186,14 @@ func (b *Bucket) DelRoom(room *Room) {
 	room.Close()
 }
 
-// BroadcastRoom broadcast a message to specified room
+// BroadcastRoom broadcast a message to specified room, a bulk one is queued
+// behind the realtime ones of the routine.
 func (b *Bucket) BroadcastRoom(arg *pb.BroadcastRoomReq) {
 	num := atomic.AddUint64(&b.routinesNum, 1) % b.c.RoutineAmount
+	if arg.Proto.Bulk {
+		b.bulks[num] <- arg
+		return
+	}
 	b.routines[num] <- arg
 }
 
@@ -234,11 +242,22 @@ func (b *Bucket) UpRoomsCount(roomCountMap map[string]int32) {
 }
 
 // roomproc
-func (b *Bucket) roomproc(c chan *pb.BroadcastRoomReq) {
+func (b *Bucket) roomproc(c, bulk chan *pb.BroadcastRoomReq) {
+	var arg *pb.BroadcastRoomReq
 	for {
-		arg := <-c
+		select {
+		case arg = <-c:
+		default:
+			select {
+			case arg = <-c:
+			case arg = <-bulk:
+			}
+		}
 		if room := b.Room(arg.RoomID); room != nil {
-			room.Push(arg.Proto)
+			delivered, dropped := room.Push(arg.Proto)
+			for _, id := range arg.Ids {
+				b.deliveries.Add(id, delivered, dropped)
+			}
 		}
 	}
 }
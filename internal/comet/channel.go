@@ -1,7 +1,10 @@
 package comet
 
 import (
+	"io"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/wcaqrl/chime/api/protocol"
 	"github.com/wcaqrl/chime/internal/comet/errors"
@@ -13,6 +16,7 @@ type Channel struct {
 	Room     *Room
 	CliProto Ring
 	signal   chan *protocol.Proto
+	bulk     chan *protocol.Proto // bulk pushes, dispatched once signal is empty
 	Writer   bufio.Writer
 	Reader   bufio.Reader
 	Next     *Channel
@@ -23,6 +27,26 @@ type Channel struct {
 	IP       string
 	watchOps map[int32]struct{}
 	mutex    sync.RWMutex
+	created  time.Time
+
+	// seq of the last key push, ack
+	seq      int32
+	acks     map[int32]*ackItem
+	ackMutex sync.Mutex
+
+	// resume, nil if disabled
+	resume *resumeRing
+	// the proto the conn is closed after, out of band of the pushed ops
+	closing atomic.Value
+
+	// slow consumer
+	slow       *SlowConsumer
+	closer     io.Closer
+	overflow   []*protocol.Proto
+	spills     int32 // len(overflow)
+	drops      int32 // consecutive drops
+	slowClosed int32
+	slowMutex  sync.Mutex
 }
 
 // NewChannel new a channel.
@@ -30,7 +54,9 @@ func NewChannel(cli, svr int) *Channel {
 	c := new(Channel)
 	c.CliProto.Init(cli)
 	c.signal = make(chan *protocol.Proto, svr)
+	c.bulk = make(chan *protocol.Proto, svr)
 	c.watchOps = make(map[int32]struct{})
+	c.created = time.Now()
 	return c
 }
 
@@ -52,6 +78,16 @@ func (c *Channel) UnWatch(accepts ...int32) {
 	c.mutex.Unlock()
 }
 
+// Accepts get the watched operations.
+func (c *Channel) Accepts() (accepts []int32) {
+	c.mutex.RLock()
+	for op := range c.watchOps {
+		accepts = append(accepts, op)
+	}
+	c.mutex.RUnlock()
+	return
+}
+
 // NeedPush verify if in watch.
 func (c *Channel) NeedPush(op int32) bool {
 	c.mutex.RLock()
@@ -63,19 +99,133 @@ func (c *Channel) NeedPush(op int32) bool {
 	return false
 }
 
-// Push server push message.
+// NextSeq alloc the n seqs of a key push and return the first one, a push
+// dropped later leaves a gap the client detects.
+func (c *Channel) NextSeq(n int32) int32 {
+	return atomic.AddInt32(&c.seq, n) - n + 1
+}
+
+// SetSeq set the seq the key pushes go on after, the last one of the former
+// conns of the key.
+func (c *Channel) SetSeq(seq int32) {
+	atomic.StoreInt32(&c.seq, seq)
+}
+
+// Seq get the seq of the last key push.
+func (c *Channel) Seq() int32 {
+	return atomic.LoadInt32(&c.seq)
+}
+
+// Created get the time the conn was accepted.
+func (c *Channel) Created() time.Time {
+	return c.created
+}
+
+// Queued get the number of protos waiting for the dispatch.
+func (c *Channel) Queued() int {
+	return len(c.signal) + len(c.bulk) + int(atomic.LoadInt32(&c.spills))
+}
+
+// Kick push a disconnect reply, the conn is closed once it is written or at
+// once if the queue is full.
+func (c *Channel) Kick(reason int32, msg string) {
+	err := c.PushClose(&protocol.Proto{
+		Ver:  1,
+		Op:   protocol.OpDisconnectReply,
+		Body: protocol.DisconnectBody(reason, msg),
+	})
+	if err != nil && c.closer != nil {
+		_ = c.closer.Close()
+	}
+}
+
+// PushClose push a proto the conn is closed after, a pushed msg of the same
+// op does not close it.
+func (c *Channel) PushClose(p *protocol.Proto) error {
+	c.closing.Store(p)
+	return c.Push(p)
+}
+
+// sent keep a proto written to the conn, or left in its queues at close, in
+// the resume ring, true if the conn is closed after it.
+func (c *Channel) sent(p *protocol.Proto) bool {
+	if closing, _ := c.closing.Load().(*protocol.Proto); closing != p {
+		c.resume.record(p)
+		return false
+	}
+	if p.Op == protocol.OpDisconnectReply {
+		// kicked, the session is not resumable
+		c.resume.close()
+	}
+	return true
+}
+
+// Push server push message, a full signal chan applies the slow consumer
+// policy. While the overflow list is not empty the msgs are queued behind it.
+// A bulk push is queued apart and dropped once its queue is full, the slow
+// consumer policy is kept for the realtime ones.
 func (c *Channel) Push(p *protocol.Proto) (err error) {
+	if p.Bulk {
+		return c.pushBulk(p)
+	}
+	if atomic.LoadInt32(&c.spills) == 0 {
+		select {
+		case c.signal <- p:
+			if atomic.LoadInt32(&c.drops) != 0 {
+				atomic.StoreInt32(&c.drops, 0)
+			}
+			return
+		default:
+		}
+	}
+	if c.slow == nil {
+		return errors.ErrSignalFullMsgDropped
+	}
+	return c.slow.push(c, p)
+}
+
+func (c *Channel) pushBulk(p *protocol.Proto) error {
 	select {
-	case c.signal <- p:
+	case c.bulk <- p:
+		return nil
 	default:
-		err = errors.ErrSignalFullMsgDropped
+	}
+	bulkDrops.Inc()
+	return errors.ErrSignalFullMsgDropped
+}
+
+// Ready check the channel ready or close? the overflow list is taken once
+// the signal chan is empty, then the bulk pushes. The expired pushes are
+// dropped.
+func (c *Channel) Ready() (p *protocol.Proto) {
+	for p = c.ready(); p.Expired(); p = c.ready() {
+		pushExpired.Inc()
 	}
 	return
 }
 
-// Ready check the channel ready or close?
-func (c *Channel) Ready() *protocol.Proto {
-	return <-c.signal
+func (c *Channel) ready() *protocol.Proto {
+	if atomic.LoadInt32(&c.spills) > 0 {
+		select {
+		case p := <-c.signal:
+			return p
+		default:
+		}
+		if p := c.unspill(); p != nil {
+			return p
+		}
+	}
+	select {
+	case p := <-c.signal:
+		return p
+	default:
+	}
+	select {
+	case p := <-c.signal:
+		return p
+	case p := <-c.bulk:
+		return p
+	}
 }
 
 // Signal send signal to the channel, protocol ready.
@@ -8,6 +8,7 @@ import (
 	xcommon "github.com/wcaqrl/chime/pkg/common"
 	"github.com/wcaqrl/chime/pkg/pather"
 	xtime "github.com/wcaqrl/chime/pkg/time"
+	"github.com/wcaqrl/chime/pkg/tracing"
 	"os"
 	"strconv"
 	"strings"
@@ -68,6 +69,11 @@ func Init() (err error) {
 		panic(err)
 	}
 	initConfig()
+	if Conf.Slow.Policy == SlowDropOldest && Conf.Slow.Spill <= 0 {
+		// the oldest msg is dropped from the overflow list, without one it
+		// would drop the newest
+		err = fmt.Errorf("slow.spill %d: the %s policy needs an overflow list", Conf.Slow.Spill, SlowDropOldest)
+	}
 	return
 }
 
@@ -121,16 +127,55 @@ func Default() *Config {
 			RoutineAmount: 32,
 			RoutineSize:   1024,
 		},
+		Ack: &Ack{
+			Timeout: xtime.Duration(time.Second * 5),
+			Retry:   3,
+			Pending: 64,
+			Report:  xtime.Duration(time.Second * 10),
+		},
+		Slow: &Slow{
+			Policy:     SlowDropNewest,
+			Disconnect: 16,
+			Spill:      64,
+			Report:     xtime.Duration(time.Second * 10),
+		},
+		Delivery: &Delivery{
+			Report: xtime.Duration(time.Second * 5),
+			Max:    10000,
+		},
+		Broadcast: &Broadcast{
+			Workers: 4,
+			Queue:   64,
+			Burst:   500,
+			History: 32,
+		},
+		Drain: &Drain{
+			Rate:    500,
+			Wait:    xtime.Duration(10 * time.Second),
+			Timeout: xtime.Duration(15 * time.Second),
+			Suggest: true,
+		},
+		Resume: &Resume{
+			Slot: 32,
+			TTL:  xtime.Duration(30 * time.Second),
+			Sync: xtime.Duration(time.Second),
+		},
+		Trace: &Trace{
+			TTL:     xtime.Duration(10 * time.Minute),
+			MaxSize: 100 << 20,
+			Stream:  1024,
+		},
+		Metrics:    &Metrics{Addr: ":3108"},
+		Tracing:    &tracing.Config{Endpoint: "http://127.0.0.1:4318/v1/traces", Ratio: 1},
+		HTTPServer: &HTTPServer{Addr: ":3107"},
 	}
 }
 
 func initConfig() {
 	var (
-		err         error
-		tmpInt64    int64
-		tmpStr      string
-		tmpStrSlice []string
-		conf        *ini.IniFileConfigSource
+		err    error
+		tmpStr string
+		conf   *ini.IniFileConfigSource
 	)
 	conf = ini.NewIniFileConfigSource(confPath)
 	// logger
@@ -157,6 +202,15 @@ func initConfig() {
 		Conf.RPCServer.Timeout = xtime.Duration(1e9)
 	}
 	Conf.RPCServer.Addr = conf.GetDefault("rpc_server.addr", ":3109")
+	// metrics
+	Conf.Metrics.Addr = conf.GetDefault("metrics.addr", ":3108")
+	// tracing
+	Conf.Tracing.Exporter = conf.GetDefault("tracing.exporter", "")
+	Conf.Tracing.Endpoint = conf.GetDefault("tracing.endpoint", "http://127.0.0.1:4318/v1/traces")
+	Conf.Tracing.Ratio = conf.GetFloat64Default("tracing.ratio", 1)
+	// admin http server
+	Conf.HTTPServer.Addr = conf.GetDefault("http_server.addr", ":3107")
+	Conf.HTTPServer.Token = conf.GetDefault("http_server.token", "")
 	// tcp
 	tmpStr = conf.GetDefault("tcp.bind", ":3101")
 	if tmpStr != "" {
@@ -198,20 +252,67 @@ func initConfig() {
 	Conf.Bucket.Room = conf.GetIntDefault("bucket.room", 1024)
 	Conf.Bucket.RoutineAmount = uint64(conf.GetIntDefault("bucket.routine_amount", 32))
 	Conf.Bucket.RoutineSize = conf.GetIntDefault("bucket.routine_size", 1024)
-	// whitelist
-	tmpStr = conf.GetDefault("whitelist.white_list", "")
-	Conf.Whitelist = &Whitelist{
-		Whitelist: make([]int64, 0),
-		WhiteLog:  conf.GetDefault("whitelist.white_log", "/tmp/white_list.log"),
+	// ack
+	tmpStr = conf.GetDefault("ack.timeout", "5s")
+	if Conf.Ack.Timeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Ack.Timeout = xtime.Duration(5 * 1e9)
 	}
-	if tmpStr != "" {
-		tmpStrSlice = strings.Split(tmpStr, ",")
-		for _, v := range tmpStrSlice {
-			if tmpInt64, err = strconv.ParseInt(v, 10, 64); err == nil {
-				Conf.Whitelist.Whitelist = append(Conf.Whitelist.Whitelist, tmpInt64)
-			}
-		}
+	Conf.Ack.Retry = conf.GetIntDefault("ack.retry", 3)
+	Conf.Ack.Pending = conf.GetIntDefault("ack.pending", 64)
+	tmpStr = conf.GetDefault("ack.report", "10s")
+	if Conf.Ack.Report, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Ack.Report = xtime.Duration(10 * 1e9)
 	}
+	// slow consumer
+	Conf.Slow.Policy = conf.GetDefault("slow.policy", SlowDropNewest)
+	Conf.Slow.Disconnect = conf.GetIntDefault("slow.disconnect", 16)
+	Conf.Slow.Spill = conf.GetIntDefault("slow.spill", 64)
+	tmpStr = conf.GetDefault("slow.report", "10s")
+	if Conf.Slow.Report, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Slow.Report = xtime.Duration(10 * 1e9)
+	}
+	// delivery
+	tmpStr = conf.GetDefault("delivery.report", "5s")
+	if Conf.Delivery.Report, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Delivery.Report = xtime.Duration(5 * 1e9)
+	}
+	Conf.Delivery.Max = conf.GetIntDefault("delivery.max", 10000)
+	// broadcast
+	Conf.Broadcast.Workers = conf.GetIntDefault("broadcast.workers", 4)
+	Conf.Broadcast.Queue = conf.GetIntDefault("broadcast.queue", 64)
+	Conf.Broadcast.Rate = conf.GetIntDefault("broadcast.rate", 0)
+	Conf.Broadcast.Burst = conf.GetIntDefault("broadcast.burst", 500)
+	Conf.Broadcast.History = conf.GetIntDefault("broadcast.history", 32)
+	// drain
+	Conf.Drain.Rate = conf.GetIntDefault("drain.rate", 500)
+	tmpStr = conf.GetDefault("drain.wait", "10s")
+	if Conf.Drain.Wait, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Drain.Wait = xtime.Duration(10 * 1e9)
+	}
+	tmpStr = conf.GetDefault("drain.timeout", "15s")
+	if Conf.Drain.Timeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Drain.Timeout = xtime.Duration(15 * 1e9)
+	}
+	Conf.Drain.Suggest = conf.GetBoolDefault("drain.suggest", true)
+	// resume
+	Conf.Resume.Enable = conf.GetBoolDefault("resume.enable", false)
+	Conf.Resume.Slot = conf.GetIntDefault("resume.slot", 32)
+	tmpStr = conf.GetDefault("resume.ttl", "30s")
+	if Conf.Resume.TTL, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Resume.TTL = xtime.Duration(30 * 1e9)
+	}
+	tmpStr = conf.GetDefault("resume.sync", "1s")
+	if Conf.Resume.Sync, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Resume.Sync = xtime.Duration(time.Second)
+	}
+	// trace
+	Conf.Trace.Log = conf.GetDefault("trace.log", "")
+	Conf.Trace.MaxSize = int64(conf.GetIntDefault("trace.max_size", 100<<20))
+	tmpStr = conf.GetDefault("trace.ttl", "10m")
+	if Conf.Trace.TTL, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Trace.TTL = xtime.Duration(10 * time.Minute)
+	}
+	Conf.Trace.Stream = conf.GetIntDefault("trace.stream", 1024)
 }
 
 func usage() {
@@ -236,17 +337,26 @@ func usage() {
 
 // Config is comet config.
 type Config struct {
-	Debug     bool
-	Logger    *xcommon.Logger
-	Env       *Env
-	Discovery *naming.Config
-	TCP       *TCP
-	Websocket *Websocket
-	Protocol  *Protocol
-	Bucket    *Bucket
-	RPCClient *RPCClient
-	RPCServer *RPCServer
-	Whitelist *Whitelist
+	Debug      bool
+	Logger     *xcommon.Logger
+	Env        *Env
+	Discovery  *naming.Config
+	TCP        *TCP
+	Websocket  *Websocket
+	Protocol   *Protocol
+	Bucket     *Bucket
+	RPCClient  *RPCClient
+	RPCServer  *RPCServer
+	Trace      *Trace
+	Ack        *Ack
+	Slow       *Slow
+	Delivery   *Delivery
+	Broadcast  *Broadcast
+	Drain      *Drain
+	Resume     *Resume
+	Metrics    *Metrics
+	Tracing    *tracing.Config
+	HTTPServer *HTTPServer
 }
 
 // Env is env config.
@@ -260,6 +370,19 @@ type Env struct {
 	Addrs     []string
 }
 
+// Metrics is metrics listener config, disabled when the addr is empty.
+type Metrics struct {
+	Addr string
+}
+
+// HTTPServer is admin http server config, disabled when the addr is empty. An
+// addr without host binds the internal ip, an empty token leaves the read only
+// routes unguarded and the others unregistered.
+type HTTPServer struct {
+	Addr  string
+	Token string
+}
+
 // RPCClient is RPC client config.
 type RPCClient struct {
 	Dial    xtime.Duration
@@ -319,8 +442,74 @@ type Bucket struct {
 	RoutineSize   int
 }
 
-// Whitelist is white list config.
-type Whitelist struct {
-	Whitelist []int64
-	WhiteLog  string
+// Ack is push ack config.
+type Ack struct {
+	Timeout xtime.Duration
+	Retry   int
+	Pending int
+	Report  xtime.Duration
+}
+
+// the policies of a slow consumer, whose signal chan is full.
+const (
+	// SlowDropNewest drop the msg pushed.
+	SlowDropNewest = "dropnewest"
+	// SlowDropOldest queue the msg in the overflow list, a full list drops
+	// its oldest msg.
+	SlowDropOldest = "dropoldest"
+	// SlowDisconnect drop the msg and close the conn after consecutive drops.
+	SlowDisconnect = "disconnect"
+	// SlowSpill queue the msg in the overflow list, a full list drops the msg.
+	SlowSpill = "spill"
+)
+
+// Slow is slow consumer config.
+type Slow struct {
+	Policy     string         // dropnewest, dropoldest, disconnect or spill
+	Disconnect int            // consecutive drops closing the conn
+	Spill      int            // overflow list size of a channel, at least 1 for dropoldest
+	Report     xtime.Duration // report interval of the counters to logic
+}
+
+// Delivery is push delivery report config.
+type Delivery struct {
+	Report xtime.Duration // report interval of the counts to logic
+	Max    int            // max push ids counted per interval
+}
+
+// Broadcast is broadcast scheduler config, the broadcasts run a few at a time
+// in the order they are queued and are paced by a token bucket across all the
+// buckets.
+type Broadcast struct {
+	Workers int // broadcasts running at once, the others wait queued
+	Queue   int // max broadcasts queued or running
+	Rate    int // max msgs per second, unpaced if zero unless the speed of a broadcast
+	Burst   int // msgs pushed at once
+	History int // finished broadcasts kept for the progress
+}
+
+// Drain is shutdown drain config, wait and timeout together should fit in
+// the termination grace period of the deployment.
+type Drain struct {
+	Rate    int            // conns closed per second
+	Wait    xtime.Duration // wait after going offline, at least the discovery renew interval
+	Timeout xtime.Duration // max drain duration
+	Suggest bool           // suggest an alternate node from logic
+}
+
+// Resume is session resume config.
+type Resume struct {
+	Enable bool           // keep a resume slot after a disconnect
+	Slot   int            // server protos kept in the slot
+	TTL    xtime.Duration // how long the slot waits for the client
+	Sync   xtime.Duration // interval syncing the slots to logic, 0 disables
+}
+
+// Trace is conn trace config, the events are written to the log as json lines
+// unless it is empty.
+type Trace struct {
+	Log     string
+	TTL     xtime.Duration // default lifetime of a trace
+	MaxSize int64          // bytes of the log before it is rotated, 0 never
+	Stream  int            // events buffered by a live stream
 }
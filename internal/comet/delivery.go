@@ -0,0 +1,78 @@
+package comet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+)
+
+// Deliveries count the deliveries of the pushes by id, reported to logic
+// which keeps the status of the pushes. The pushes without id are not counted.
+type Deliveries struct {
+	c      *conf.Delivery
+	mutex  sync.Mutex
+	counts map[string]*logic.DeliveryCount // counts by push id since last report
+}
+
+// NewDeliveries new a delivery counter.
+func NewDeliveries(c *conf.Delivery) *Deliveries {
+	return &Deliveries{
+		c:      c,
+		counts: make(map[string]*logic.DeliveryCount),
+	}
+}
+
+// Add count the conns a push was delivered to or dropped for, the new ids
+// are ignored once the max ids of the interval is reached.
+func (d *Deliveries) Add(id string, delivered, dropped int64) {
+	if id == "" || (delivered == 0 && dropped == 0) {
+		return
+	}
+	d.mutex.Lock()
+	count, ok := d.counts[id]
+	if !ok {
+		if len(d.counts) >= d.c.Max {
+			d.mutex.Unlock()
+			return
+		}
+		count = new(logic.DeliveryCount)
+		d.counts[id] = count
+	}
+	count.Delivered += delivered
+	count.Dropped += dropped
+	d.mutex.Unlock()
+}
+
+// Stats return the counts since last call.
+func (d *Deliveries) Stats() (counts map[string]*logic.DeliveryCount) {
+	d.mutex.Lock()
+	counts = d.counts
+	d.counts = make(map[string]*logic.DeliveryCount)
+	d.mutex.Unlock()
+	return
+}
+
+// deliveryproc report the delivery counts to logic.
+func (s *Server) deliveryproc() {
+	tick := time.Duration(s.c.Delivery.Report)
+	if tick <= 0 {
+		tick = 5 * time.Second
+	}
+	for {
+		time.Sleep(tick)
+		counts := s.deliveries.Stats()
+		if len(counts) == 0 {
+			continue
+		}
+		if _, err := s.rpcClient.DeliveryReport(context.Background(), &logic.DeliveryReportReq{
+			Server: s.serverID,
+			Counts: counts,
+		}); err != nil {
+			log.Errorf("s.rpcClient.DeliveryReport(ids:%d) error(%v)", len(counts), err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package comet
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/errors"
+)
+
+const (
+	_drainInterval = 100 * time.Millisecond
+)
+
+// Drain stop accepting and ask the conns to reconnect to another node at the
+// configured rate, it returns once they are all closed or the drain timed out.
+func (s *Server) Drain() {
+	s.listenerMutex.Lock()
+	for _, l := range s.listeners {
+		if err := l.Close(); err != nil {
+			log.Errorf("listener.Close(%s) error(%v)", l.Addr(), err)
+		}
+	}
+	s.listeners = nil
+	s.listenerMutex.Unlock()
+	var (
+		deadline = time.Now().Add(time.Duration(s.c.Drain.Timeout))
+		nodes    = s.suggestNodes()
+		chs      []*Channel
+	)
+	for _, b := range s.buckets {
+		chs = append(chs, b.Channels()...)
+	}
+	log.Infof("comet drain conns:%d nodes:%v", len(chs), nodes)
+	s.reconnect(chs, protocol.ReasonDraining, nodes, nil, deadline)
+	for s.connCount() > 0 && time.Now().Before(deadline) {
+		time.Sleep(_drainInterval)
+	}
+	log.Infof("comet drain finish remain conns:%d", s.connCount())
+}
+
+// Migrate ask count conns to reconnect to the nodes with their resume token,
+// at the drain rate in the background. One migration runs at a time, another
+// one is rejected until it finishes.
+func (s *Server) Migrate(count int, nodes []string) error {
+	if !atomic.CompareAndSwapInt32(&s.migrating, 0, 1) {
+		return errors.ErrMigrating
+	}
+	go func() {
+		defer atomic.StoreInt32(&s.migrating, 0)
+		s.migrate(count, nodes)
+	}()
+	return nil
+}
+
+func (s *Server) migrate(count int, nodes []string) {
+	var chs []*Channel
+	for i, start := 0, rand.Intn(len(s.buckets)); i < len(s.buckets) && len(chs) < count; i++ {
+		b := s.buckets[(start+i)%len(s.buckets)]
+		chs = append(chs, b.Channels()...)
+	}
+	if len(chs) > count {
+		chs = chs[:count]
+	}
+	tokens := make(map[string]string, len(chs))
+	for _, ch := range chs {
+		if tk := ch.resume.Token(); tk != "" {
+			tokens[ch.Key] = tk
+		}
+	}
+	log.Infof("comet migrate conns:%d tokens:%d nodes:%v", len(chs), len(tokens), nodes)
+	s.reconnect(chs, protocol.ReasonMigrate, nodes, tokens, time.Now().Add(time.Duration(s.c.Drain.Timeout)))
+}
+
+// reconnect push a reconnect hint to the conns at the drain rate, a full
+// signal chan rejects the hint and it is asked again in the next round.
+func (s *Server) reconnect(chs []*Channel, reason int32, nodes []string, tokens map[string]string, deadline time.Time) {
+	var (
+		n     int
+		batch = s.c.Drain.Rate * int(_drainInterval) / int(time.Second)
+	)
+	if batch <= 0 {
+		batch = 1
+	}
+	for len(chs) > 0 && time.Now().Before(deadline) {
+		var failed []*Channel
+		for i, ch := range chs {
+			if i > 0 && i%batch == 0 {
+				time.Sleep(_drainInterval)
+			}
+			r := &protocol.Reconnect{Reason: reason, Token: tokens[ch.Key]}
+			if len(nodes) > 0 {
+				r.Node = nodes[n%len(nodes)]
+				n++
+			}
+			if err := ch.PushClose(&protocol.Proto{Ver: 1, Op: protocol.OpReconnect, Body: protocol.ReconnectBody(r)}); err != nil {
+				failed = append(failed, ch)
+			}
+		}
+		chs = failed
+		time.Sleep(_drainInterval)
+	}
+}
+
+func (s *Server) connCount() (n int) {
+	for _, b := range s.buckets {
+		n += b.ChannelCount()
+	}
+	return
+}
+
+// suggestNodes get the nodes logic balances new conns to, except this one.
+func (s *Server) suggestNodes() (nodes []string) {
+	if !s.c.Drain.Suggest {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.c.RPCClient.Timeout))
+	defer cancel()
+	reply, err := s.rpcClient.Nodes(ctx, &logic.NodesReq{})
+	if err != nil {
+		log.Errorf("s.rpcClient.Nodes() error(%v)", err)
+		return
+	}
+	self := make(map[string]struct{}, len(s.c.Env.Addrs))
+	for _, addr := range s.c.Env.Addrs {
+		self[addr] = struct{}{}
+	}
+	for _, node := range reply.Nodes {
+		if _, ok := self[node]; ok || strings.HasPrefix(node, s.serverID) || node == reply.Domain {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return
+}
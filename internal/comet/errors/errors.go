@@ -22,9 +22,16 @@ var (
 	ErrMPushMsgArg          = errors.New("rpc mpushmsg arg error")
 	ErrMPushMsgsArg         = errors.New("rpc mpushmsgs arg error")
 	ErrSignalFullMsgDropped = errors.New("signal channel full, msg dropped")
+	ErrAckPendingFull       = errors.New("ack pending full, msg dropped")
+	ErrKickArg              = errors.New("rpc kick arg error")
+	ErrMigrateArg           = errors.New("rpc migrate arg error")
+	ErrMigrating            = errors.New("migration already running")
+	ErrTraceArg             = errors.New("rpc trace arg error")
+	ErrResumeDisabled       = errors.New("session resume disabled")
 	// bucket
 	ErrBroadCastArg     = errors.New("rpc broadcast arg error")
 	ErrBroadCastRoomArg = errors.New("rpc broadcast  room arg error")
+	ErrBroadcastFull    = errors.New("broadcast queue full")
 
 	// room
 	ErrRoomDroped = errors.New("room droped")
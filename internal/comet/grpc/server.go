@@ -9,11 +9,15 @@ import (
 	"github.com/wcaqrl/chime/internal/comet"
 	"github.com/wcaqrl/chime/internal/comet/conf"
 	"github.com/wcaqrl/chime/internal/comet/errors"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
 
+var grpcDuration = metric.NewGRPCHistogram("comet", "grpc_duration_seconds", "Latencies of the grpc calls served.")
+
 // New comet grpc server.
 func New(c *conf.RPCServer, s *comet.Server) *grpc.Server {
 	keepParams := grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -23,7 +27,7 @@ func New(c *conf.RPCServer, s *comet.Server) *grpc.Server {
 		Timeout:               time.Duration(c.KeepAliveTimeout),
 		MaxConnectionAge:      time.Duration(c.MaxLifeTime),
 	})
-	srv := grpc.NewServer(keepParams)
+	srv := grpc.NewServer(keepParams, grpc.ChainUnaryInterceptor(metric.UnaryServerInterceptor(grpcDuration), tracing.UnaryServerInterceptor()))
 	pb.RegisterCometServer(srv, &server{s})
 	lis, err := net.Listen(c.Network, c.Addr)
 	if err != nil {
@@ -48,6 +52,15 @@ func (s *server) PushMsg(ctx context.Context, req *pb.PushMsgReq) (reply *pb.Pus
 	if len(req.Keys) == 0 || req.Proto == nil {
 		return nil, errors.ErrPushMsgArg
 	}
+	_, span := tracing.Start(ctx, "comet.Channel.Push", tracing.KindInternal)
+	span.SetAttr("keys", len(req.Keys))
+	// a key failing the push is counted as dropped, the other keys go on
+	var delivered, dropped, ackDropped int64
+	defer func() {
+		s.srv.Deliveries().Add(req.Id, delivered, dropped)
+		span.SetAttr("dropped", dropped+ackDropped)
+		span.End()
+	}()
 	for _, key := range req.Keys {
 		bucket := s.srv.Bucket(key)
 		if bucket == nil {
@@ -57,9 +70,19 @@ func (s *server) PushMsg(ctx context.Context, req *pb.PushMsgReq) (reply *pb.Pus
 			if !channel.NeedPush(req.ProtoOp) {
 				continue
 			}
-			if err = channel.Push(req.Proto); err != nil {
-				return
+			if req.Ack {
+				// the acker counts the delivery once acked and the drop of a full pending
+				if err = s.srv.Acker().Push(channel, req.Proto, req.Id); err != nil {
+					ackDropped++
+				}
+				continue
+			}
+			// the key pushes, the replayed offline ones too, are numbered by the channel
+			if err = channel.Push(req.Proto.WithSeq(channel.NextSeq(req.Proto.Count()))); err != nil {
+				dropped++
+				continue
 			}
+			delivered++
 		}
 	}
 	return &pb.PushMsgReply{}, nil
@@ -70,17 +93,21 @@ func (s *server) Broadcast(ctx context.Context, req *pb.BroadcastReq) (*pb.Broad
 	if req.Proto == nil {
 		return nil, errors.ErrBroadCastArg
 	}
-	// TODO use broadcast queue
-	go func() {
-		for _, bucket := range s.srv.Buckets() {
-			bucket.Broadcast(req.GetProto(), req.ProtoOp)
-			if req.Speed > 0 {
-				t := bucket.ChannelCount() / int(req.Speed)
-				time.Sleep(time.Duration(t) * time.Second)
-			}
-		}
-	}()
-	return &pb.BroadcastReply{}, nil
+	id, err := s.srv.Broadcaster().Add(req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BroadcastReply{Id: id}, nil
+}
+
+// CancelBroadcast cancel a queued or running broadcast.
+func (s *server) CancelBroadcast(ctx context.Context, req *pb.CancelBroadcastReq) (*pb.CancelBroadcastReply, error) {
+	return &pb.CancelBroadcastReply{Canceled: s.srv.Broadcaster().Cancel(req.Id)}, nil
+}
+
+// Broadcasts get the progress of the broadcasts.
+func (s *server) Broadcasts(ctx context.Context, req *pb.BroadcastsReq) (*pb.BroadcastsReply, error) {
+	return &pb.BroadcastsReply{Broadcasts: s.srv.Broadcaster().Broadcasts(req.Id)}, nil
 }
 
 // BroadcastRoom broadcast msg to specified room.
@@ -94,6 +121,30 @@ func (s *server) BroadcastRoom(ctx context.Context, req *pb.BroadcastRoomReq) (*
 	return &pb.BroadcastRoomReply{}, nil
 }
 
+// Kick disconnect the specified sub keys or every entry of a room.
+func (s *server) Kick(ctx context.Context, req *pb.KickReq) (*pb.KickReply, error) {
+	if len(req.Keys) == 0 && req.RoomID == "" {
+		return nil, errors.ErrKickArg
+	}
+	for _, key := range req.Keys {
+		bucket := s.srv.Bucket(key)
+		if bucket == nil {
+			continue
+		}
+		if channel := bucket.Channel(key); channel != nil {
+			channel.Kick(req.Reason, req.Msg)
+		}
+	}
+	if req.RoomID != "" {
+		for _, bucket := range s.srv.Buckets() {
+			if room := bucket.Room(req.RoomID); room != nil {
+				room.Kick(req.Reason, req.Msg)
+			}
+		}
+	}
+	return &pb.KickReply{}, nil
+}
+
 // Rooms gets all the room ids for the server.
 func (s *server) Rooms(ctx context.Context, req *pb.RoomsReq) (*pb.RoomsReply, error) {
 	var (
@@ -106,3 +157,27 @@ func (s *server) Rooms(ctx context.Context, req *pb.RoomsReq) (*pb.RoomsReply, e
 	}
 	return &pb.RoomsReply{Rooms: roomIds}, nil
 }
+
+// Migrate ask a number of entries to reconnect to other nodes.
+func (s *server) Migrate(ctx context.Context, req *pb.MigrateReq) (*pb.MigrateReply, error) {
+	if req.Count <= 0 {
+		return nil, errors.ErrMigrateArg
+	}
+	if err := s.srv.Migrate(int(req.Count), req.Nodes); err != nil {
+		return nil, err
+	}
+	return &pb.MigrateReply{}, nil
+}
+
+// Trace trace the conns of a mid or key.
+func (s *server) Trace(ctx context.Context, req *pb.TraceReq) (*pb.TraceReply, error) {
+	if req.Mid <= 0 && req.Key == "" {
+		return nil, errors.ErrTraceArg
+	}
+	if req.Ttl < 0 {
+		s.srv.Tracer().Untrace(req.Mid, req.Key)
+	} else {
+		s.srv.Tracer().Trace(req.Mid, req.Key, time.Duration(req.Ttl)*time.Second)
+	}
+	return &pb.TraceReply{}, nil
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/pkg/admin"
+)
+
+func (s *Server) broadcasts(c *gin.Context) {
+	var arg struct {
+		ID string `form:"id"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	admin.Result(c, s.comet.Broadcaster().Broadcasts(arg.ID), admin.OK)
+}
+
+func (s *Server) cancelBroadcast(c *gin.Context) {
+	var arg struct {
+		ID string `form:"id" binding:"required"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	if !s.comet.Broadcaster().Cancel(arg.ID) {
+		admin.Errors(c, admin.NothingFound, "broadcast not found or finished")
+		return
+	}
+	admin.Result(c, nil, admin.OK)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/wcaqrl/chime/internal/comet"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+	"github.com/wcaqrl/chime/pkg/admin"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// Server is admin http server.
+type Server struct {
+	engine *gin.Engine
+	srv    *http.Server
+	comet  *comet.Server
+}
+
+// New new a http server.
+func New(c *conf.HTTPServer, cs *comet.Server) *Server {
+	engine := gin.New()
+	engine.Use(admin.Logger, admin.Recover, admin.Auth(c.Token))
+	s := &Server{
+		engine: engine,
+		srv:    &http.Server{Addr: admin.BindAddr(c.Addr), Handler: engine},
+		comet:  cs,
+	}
+	s.initRouter(c.Token != "")
+	go func() {
+		log.Infof("start admin http listen: %s", s.srv.Addr)
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			panic(err)
+		}
+	}()
+	return s
+}
+
+// initRouter register the routes, the ones exposing msgs, profiles or
+// cancelling broadcasts only behind a token.
+func (s *Server) initRouter(secured bool) {
+	group := s.engine.Group("/chime")
+	group.GET("/buckets", s.buckets)
+	group.GET("/channel", s.channel)
+	group.GET("/room", s.room)
+	group.GET("/timers", s.timers)
+	group.GET("/broadcasts", s.broadcasts)
+	if !secured {
+		log.Warn("admin http trace, pprof and broadcast cancel disabled: http_server.token is empty")
+		return
+	}
+	group.GET("/traces", s.traces)
+	group.POST("/trace", s.trace)
+	group.POST("/untrace", s.untrace)
+	group.GET("/trace/stream", s.traceStream)
+	group.POST("/broadcast/cancel", s.cancelBroadcast)
+	s.engine.GET("/debug/pprof/*name", s.pprof)
+	s.engine.POST("/debug/pprof/*name", s.pprof)
+}
+
+// Close close the server.
+func (s *Server) Close() {
+	_ = s.srv.Close()
+}
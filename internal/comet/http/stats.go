@@ -0,0 +1,54 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/pkg/admin"
+)
+
+const (
+	_defaultRoomLimit = 1000
+)
+
+func (s *Server) buckets(c *gin.Context) {
+	admin.Result(c, s.comet.BucketStats(), admin.OK)
+}
+
+func (s *Server) channel(c *gin.Context) {
+	var arg struct {
+		Key string `form:"key" binding:"required"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	stats := s.comet.ChannelStats(arg.Key)
+	if stats == nil {
+		admin.Errors(c, admin.NothingFound, "channel not found")
+		return
+	}
+	admin.Result(c, stats, admin.OK)
+}
+
+func (s *Server) room(c *gin.Context) {
+	var arg struct {
+		Room  string `form:"room" binding:"required"`
+		Limit int    `form:"limit" binding:"min=0"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	if arg.Limit == 0 {
+		arg.Limit = _defaultRoomLimit
+	}
+	stats := s.comet.RoomStats(arg.Room, arg.Limit)
+	if stats == nil {
+		admin.Errors(c, admin.NothingFound, "room not found")
+		return
+	}
+	admin.Result(c, stats, admin.OK)
+}
+
+func (s *Server) timers(c *gin.Context) {
+	admin.Result(c, s.comet.TimerStats(), admin.OK)
+}
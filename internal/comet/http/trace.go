@@ -0,0 +1,77 @@
+package http
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/pkg/admin"
+)
+
+func (s *Server) traces(c *gin.Context) {
+	admin.Result(c, s.comet.Tracer().Traces(), admin.OK)
+}
+
+func (s *Server) trace(c *gin.Context) {
+	var arg struct {
+		Mid int64  `form:"mid"`
+		Key string `form:"key"`
+		TTL string `form:"ttl"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	if arg.Mid <= 0 && arg.Key == "" {
+		admin.Errors(c, admin.RequestErr, "mid or key required")
+		return
+	}
+	var ttl time.Duration
+	if arg.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(arg.TTL); err != nil || ttl <= 0 {
+			admin.Errors(c, admin.RequestErr, "invalid ttl")
+			return
+		}
+	}
+	s.comet.Tracer().Trace(arg.Mid, arg.Key, ttl)
+	admin.Result(c, nil, admin.OK)
+}
+
+func (s *Server) untrace(c *gin.Context) {
+	var arg struct {
+		Mid int64  `form:"mid"`
+		Key string `form:"key"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	s.comet.Tracer().Untrace(arg.Mid, arg.Key)
+	admin.Result(c, nil, admin.OK)
+}
+
+// traceStream stream the trace events as json lines until the client leaves.
+func (s *Server) traceStream(c *gin.Context) {
+	var arg struct {
+		Mid int64  `form:"mid"`
+		Key string `form:"key"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	tracer := s.comet.Tracer()
+	stream := tracer.Subscribe(arg.Mid, arg.Key)
+	defer tracer.Unsubscribe(stream)
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case b := <-stream.C:
+			_, err := w.Write(b)
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
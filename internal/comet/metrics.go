@@ -0,0 +1,102 @@
+package comet
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wcaqrl/chime/pkg/metric"
+)
+
+const (
+	_subsystem = "comet"
+
+	_transportTCP       = "tcp"
+	_transportWebsocket = "websocket"
+)
+
+var (
+	connections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "connections",
+		Help:      "Handshaked connections by transport.",
+	}, []string{"transport"})
+	handshakeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "handshake_failures_total",
+		Help:      "Failed handshakes by transport and step.",
+	}, []string{"transport", "step"})
+	bucketChannels = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "bucket_channels",
+		Help:      "Channels of a bucket.",
+	}, []string{"bucket"})
+	bucketRooms = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "bucket_rooms",
+		Help:      "Rooms of a bucket.",
+	}, []string{"bucket"})
+	ringFull = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "ring_full_total",
+		Help:      "Connections closed as their client proto ring was full.",
+	}, []string{"transport"})
+	signalDrops = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "signal_drops_total",
+		Help:      "Server protos dropped as the signal chan was full, by slow consumer policy.",
+	}, []string{"policy"})
+	signalSpills = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "signal_spills_total",
+		Help:      "Server protos queued in the overflow list of a channel.",
+	})
+	slowDisconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "slow_disconnects_total",
+		Help:      "Connections closed by the slow consumer policy.",
+	})
+	bulkDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "bulk_drops_total",
+		Help:      "Bulk server protos dropped as the bulk queue of a channel was full.",
+	})
+	pushExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "push_expired_total",
+		Help:      "Server protos dropped from the queue of a channel as expired.",
+	})
+	broadcastQueue = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "broadcast_queue",
+		Help:      "Broadcasts queued or running.",
+	})
+	broadcastProgress = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "broadcast_progress_ratio",
+		Help:      "Conns the running broadcast was sent to over its conns, zero if none.",
+	})
+	broadcastMsgs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "broadcast_msgs_total",
+		Help:      "Broadcast msgs by result, delivered or dropped.",
+	}, []string{"result"})
+	broadcasts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "broadcasts_total",
+		Help:      "Finished broadcasts by state, done, canceled or expired.",
+	}, []string{"state"})
+	logicDuration = metric.NewGRPCHistogram(_subsystem, "logic_call_duration_seconds", "Latencies of the calls to logic.")
+)
@@ -6,42 +6,65 @@ import (
 
 	"github.com/wcaqrl/chime/api/logic"
 	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/conf"
 	"github.com/wcaqrl/chime/pkg/stringer"
 	log "github.com/sirupsen/logrus"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
 )
 
-// Connect connected a connection.
-func (s *Server) Connect(c context.Context, p *protocol.Proto, cookie string) (mid int64, key, rid string, accepts []int32, heartbeat time.Duration, err error) {
+// Connect connected a connection, the token resumes the session if resume is
+// enabled and offline packs the offline msgs to push. The key pushes go on
+// after seq, the last one of the former conns of the key.
+func (s *Server) Connect(c context.Context, p *protocol.Proto, cookie string) (mid int64, key, rid string, accepts []int32, heartbeat time.Duration, token string, offline []byte, seq int32, err error) {
 	reply, err := s.rpcClient.Connect(c, &logic.ConnectReq{
-		Server: s.serverID,
-		Cookie: cookie,
-		Token:  p.Body,
+		Server:  s.serverID,
+		Cookie:  cookie,
+		Token:   p.Body,
+		LastSeq: int64(p.Seq),
+		Resume:  s.resumeArg(),
 	})
 	if err != nil {
 		return
 	}
-	return reply.Mid, reply.Key, reply.RoomID, reply.Accepts, time.Duration(reply.Heartbeat), nil
+	return reply.Mid, reply.Key, reply.RoomID, reply.Accepts, time.Duration(reply.Heartbeat), reply.ResumeToken, reply.Offline, reply.KeySeq, nil
+}
+
+// connectRejected turn the proto into a disconnect reply telling the client why logic refused it.
+func connectRejected(p *protocol.Proto, err error) {
+	reason := protocol.ReasonUnknown
+	st := status.Convert(err)
+	if st.Code() == codes.Unauthenticated {
+		reason = protocol.ReasonAuthFailed
+	}
+	p.Op = protocol.OpDisconnectReply
+	p.Body = protocol.DisconnectBody(reason, st.Message())
 }
 
-// Disconnect disconnected a connection.
-func (s *Server) Disconnect(c context.Context, mid int64, key string) (err error) {
+// Disconnect disconnected a connection, with a slot logic keeps the session
+// for the client to resume it. The seq of the last key push is kept for the
+// next conn of the key.
+func (s *Server) Disconnect(c context.Context, mid int64, key string, seq int32, slot *logic.ResumeSlot) (err error) {
 	_, err = s.rpcClient.Disconnect(context.Background(), &logic.DisconnectReq{
 		Server: s.serverID,
 		Mid:    mid,
 		Key:    key,
+		Slot:   slot,
+		KeySeq: seq,
 	})
 	return
 }
 
-// Heartbeat heartbeat a connection session.
-func (s *Server) Heartbeat(ctx context.Context, mid int64, key string) (err error) {
+// Heartbeat heartbeat a connection session with the seq of the last key push.
+func (s *Server) Heartbeat(ctx context.Context, mid int64, key string, seq int32) (err error) {
 	_, err = s.rpcClient.Heartbeat(ctx, &logic.HeartbeatReq{
 		Server: s.serverID,
 		Mid:    mid,
 		Key:    key,
+		KeySeq: seq,
 	})
 	return
 }
@@ -59,8 +82,14 @@ func (s *Server) RenewOnline(ctx context.Context, serverID string, roomCount map
 }
 
 // Receive receive a message.
-func (s *Server) Receive(ctx context.Context, mid int64, p *protocol.Proto) (err error) {
-	_, err = s.rpcClient.Receive(ctx, &logic.ReceiveReq{Mid: mid, Proto: p})
+func (s *Server) Receive(ctx context.Context, mid int64, key, room string, p *protocol.Proto) (err error) {
+	_, err = s.rpcClient.Receive(ctx, &logic.ReceiveReq{
+		Server: s.serverID,
+		Mid:    mid,
+		Key:    key,
+		Room:   room,
+		Proto:  p,
+	})
 	return
 }
 
@@ -82,9 +111,20 @@ func (s *Server) Operate(ctx context.Context, p *protocol.Proto, ch *Channel, b
 			ch.UnWatch(ops...)
 		}
 		p.Op = protocol.OpUnsubReply
+	case protocol.OpPushAck:
+		if !s.acker.Ack(ch, p.Seq) && conf.Conf.Debug {
+			log.Infof("key: %s ack unknown seq:%d", ch.Key, p.Seq)
+		}
+		p.Op = protocol.OpPushAckReply
+		p.Body = nil
 	default:
-		// TODO ack ok&failed
-		if err := s.Receive(ctx, ch.Mid, p); err != nil {
+		// an upstream op is handed to logic as is, the client acks the pushes
+		// with OpPushAck, counted by the acker and reported to logic
+		var room string
+		if ch.Room != nil {
+			room = ch.Room.ID
+		}
+		if err := s.Receive(ctx, ch.Mid, ch.Key, room, p); err != nil {
 			log.Errorf("s.Report(%d) op:%d error(%v)", ch.Mid, p.Op, err)
 		}
 		p.Body = nil
@@ -0,0 +1,290 @@
+package comet
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/errors"
+	"github.com/wcaqrl/chime/pkg/bytes"
+)
+
+const (
+	_resumeSyncBatch = 256
+	_resumeDrainWait = time.Second
+)
+
+// resumeRing keep the last server protos written to a conn, they are synced
+// to the resume slot logic opened for the session and fill it once the conn
+// is disconnected.
+type resumeRing struct {
+	mutex   sync.Mutex
+	token   string // signed by logic, empty if no slot
+	seq     int64
+	synced  int64 // seq synced to the slot
+	max     int
+	entries []*logic.ResumeEntry
+	// kicked, the session is not resumable
+	closed bool
+	// closed once the protos left in the queues at close are recorded
+	drained chan struct{}
+}
+
+func newResumeRing(max int) *resumeRing {
+	return &resumeRing{max: max, drained: make(chan struct{})}
+}
+
+// reset start a session with the token of its slot, seq is the number of
+// protos the client received before.
+func (r *resumeRing) reset(token string, seq int64) {
+	r.mutex.Lock()
+	r.token = token
+	r.seq, r.synced = seq, seq
+	r.entries = nil
+	r.mutex.Unlock()
+}
+
+// close mark the session not resumable.
+func (r *resumeRing) close() {
+	if r != nil {
+		r.mutex.Lock()
+		r.closed = true
+		r.mutex.Unlock()
+	}
+}
+
+// Token get the token resuming the session, empty if none.
+func (r *resumeRing) Token() string {
+	if r == nil {
+		return ""
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed {
+		return ""
+	}
+	return r.token
+}
+
+// drain mark the protos left in the queues recorded.
+func (r *resumeRing) drain() {
+	if r != nil {
+		close(r.drained)
+	}
+}
+
+// delta get the protos recorded since the last sync, nil if none.
+func (r *resumeRing) delta() *logic.ResumeSlot {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed || r.token == "" || r.seq == r.synced {
+		return nil
+	}
+	slot := &logic.ResumeSlot{Token: r.token, Seq: r.seq}
+	for _, e := range r.entries {
+		if e.Seq > r.synced {
+			slot.Entries = append(slot.Entries, e)
+		}
+	}
+	r.synced = r.seq
+	return slot
+}
+
+// record a proto written to the conn, or left in its queues at close, a raw
+// proto counts the protos it packs.
+func (r *resumeRing) record(p *protocol.Proto) {
+	if r == nil || p == protocol.ProtoReady || p == protocol.ProtoFinish {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var (
+		data  []byte
+		count = 1
+	)
+	if p.Op == protocol.OpRaw {
+		// the raw body is shared by the conns but never modified
+		if count = protocol.CountProtos(p.Body); count == 0 {
+			return
+		}
+		data = p.Body
+	} else {
+		buf := bytes.NewWriterSize(len(p.Body) + 16)
+		p.WriteTo(buf)
+		data = buf.Buffer()
+	}
+	r.seq += int64(count)
+	if len(r.entries) == r.max && r.max > 0 {
+		copy(r.entries, r.entries[1:])
+		r.entries = r.entries[:r.max-1]
+	}
+	if r.max > 0 {
+		r.entries = append(r.entries, &logic.ResumeEntry{Seq: r.seq, Data: data})
+	}
+}
+
+// resumeSlot get the resume slot of a disconnected conn, nil if not resumable.
+// It waits for the protos left in the queues to be recorded.
+func (s *Server) resumeSlot(ch *Channel) *logic.ResumeSlot {
+	r := ch.resume
+	if r == nil || ch.Key == "" {
+		return nil
+	}
+	select {
+	case <-r.drained:
+	case <-time.After(_resumeDrainWait):
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed || r.token == "" {
+		return nil
+	}
+	slot := &logic.ResumeSlot{
+		Token:   r.token,
+		Accepts: ch.Accepts(),
+		Seq:     r.seq,
+		KeySeq:  ch.Seq(),
+		Entries: append([]*logic.ResumeEntry(nil), r.entries...),
+		Ttl:     int64(time.Duration(s.c.Resume.TTL) / time.Second),
+		Max:     int32(r.max),
+	}
+	if ch.Room != nil {
+		slot.Room = ch.Room.ID
+	}
+	return slot
+}
+
+// resumeArg get the resume slot logic opens for a new session, nil if resume
+// is disabled.
+func (s *Server) resumeArg() *logic.ResumeSlot {
+	if !s.c.Resume.Enable {
+		return nil
+	}
+	return &logic.ResumeSlot{
+		Ttl: int64(time.Duration(s.c.Resume.TTL) / time.Second),
+		Max: int32(s.c.Resume.Slot),
+	}
+}
+
+// Resume reattach a detached session, the replay packs the protos pushed
+// after the seq of the client and seq is the number of protos before them,
+// past the seq of the client if the slot lost some. The token resumes the
+// session next time and the key pushes go on after keySeq.
+func (s *Server) Resume(c context.Context, p *protocol.Proto) (mid int64, key, rid string, accepts []int32, heartbeat time.Duration, seq int64, replay []byte, token string, keySeq int32, err error) {
+	var arg protocol.Resume
+	if err = json.Unmarshal(p.Body, &arg); err != nil {
+		return
+	}
+	reply, err := s.rpcClient.Resume(c, &logic.ResumeReq{
+		Server: s.serverID,
+		Key:    arg.Key,
+		Token:  arg.Token,
+		Seq:    int64(p.Seq),
+		Resume: s.resumeArg(),
+	})
+	if err != nil {
+		return
+	}
+	for _, data := range reply.Data {
+		replay = append(replay, data...)
+	}
+	return reply.Mid, reply.Key, reply.RoomID, reply.Accepts, time.Duration(reply.Heartbeat), reply.First, replay, reply.Token, reply.KeySeq, nil
+}
+
+// resumeproc sync the protos written to the conns to their resume slots, so
+// the sessions are resumable even if this comet is gone.
+func (s *Server) resumeproc() {
+	for {
+		time.Sleep(time.Duration(s.c.Resume.Sync))
+		slots := make(map[string]*logic.ResumeSlot)
+		for _, b := range s.buckets {
+			for _, ch := range b.Channels() {
+				if ch.resume == nil || ch.Key == "" {
+					continue
+				}
+				slot := ch.resume.delta()
+				if slot == nil {
+					continue
+				}
+				slot.Accepts = ch.Accepts()
+				slot.KeySeq = ch.Seq()
+				if ch.Room != nil {
+					slot.Room = ch.Room.ID
+				}
+				if slots[ch.Key] = slot; len(slots) >= _resumeSyncBatch {
+					s.syncResume(slots)
+					slots = make(map[string]*logic.ResumeSlot)
+				}
+			}
+		}
+		if len(slots) > 0 {
+			s.syncResume(slots)
+		}
+	}
+}
+
+func (s *Server) syncResume(slots map[string]*logic.ResumeSlot) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.c.RPCClient.Timeout))
+	defer cancel()
+	if _, err := s.rpcClient.SyncResume(ctx, &logic.SyncResumeReq{Server: s.serverID, Slots: slots}); err != nil {
+		log.Errorf("s.rpcClient.SyncResume(%d) error(%v)", len(slots), err)
+	}
+}
+
+// handshake auth or resume a conn by the first proto, which is turned into
+// the reply. With resume enabled the reply carries the token of the session.
+// The replay is pushed once the conn is registered, the protos of a resumed
+// session or the offline msgs, which keep their offline seq. The key pushes go
+// on after the key seq of the resumed session or the former conns of the key.
+func (s *Server) handshake(ctx context.Context, p *protocol.Proto, cookie string, ch *Channel) (mid int64, key, rid string, accepts []int32, hb time.Duration, replay *protocol.Proto, err error) {
+	var (
+		r     = ch.resume
+		seq   int64
+		token string
+	)
+	if p.Op == protocol.OpResume {
+		var (
+			raw    []byte
+			keySeq int32
+		)
+		if r == nil {
+			err = errors.ErrResumeDisabled
+		} else {
+			mid, key, rid, accepts, hb, seq, raw, token, keySeq, err = s.Resume(ctx, p)
+		}
+		if err != nil {
+			p.Op = protocol.OpDisconnectReply
+			p.Body = protocol.DisconnectBody(protocol.ReasonResumeFailed, err.Error())
+			return
+		}
+		ch.SetSeq(keySeq)
+		if len(raw) > 0 {
+			replay = &protocol.Proto{Ver: 1, Op: protocol.OpRaw, Body: raw}
+		}
+		p.Op = protocol.OpResumeReply
+	} else {
+		var (
+			offline []byte
+			keySeq  int32
+		)
+		if mid, key, rid, accepts, hb, token, offline, keySeq, err = s.Connect(ctx, p, cookie); err != nil {
+			connectRejected(p, err)
+			return
+		}
+		ch.SetSeq(keySeq)
+		if len(offline) > 0 {
+			replay = &protocol.Proto{Ver: 1, Op: protocol.OpRaw, Body: offline}
+		}
+		p.Op = protocol.OpAuthReply
+	}
+	p.Body = nil
+	if r != nil {
+		r.reset(token, seq)
+		p.Body = protocol.AuthReplyBody(&protocol.AuthReply{Key: key, Token: token, Seq: seq})
+	}
+	return
+}
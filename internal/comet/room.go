@@ -2,6 +2,7 @@ package comet
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/wcaqrl/chime/api/protocol"
 	"github.com/wcaqrl/chime/internal/comet/errors"
@@ -15,6 +16,7 @@ type Room struct {
 	drop      bool
 	Online    int32 // dirty read is ok
 	AllOnline int32
+	seq       int32
 }
 
 // NewRoom new a room struct, store channel room info.
@@ -66,11 +68,42 @@ func (r *Room) Del(ch *Channel) bool {
 	return r.drop
 }
 
-// Push push msg to the room, if chan full discard it.
-func (r *Room) Push(p *protocol.Proto) {
+// Push push msg to the room, if chan full discard it. It returns the channels
+// the msg was delivered to or dropped for. The bulk room msgs are unsequenced,
+// the seq of the room only moves forward with the realtime ones.
+func (r *Room) Push(p *protocol.Proto) (delivered, dropped int64) {
+	for seq := atomic.LoadInt32(&r.seq); p.Seq > seq; seq = atomic.LoadInt32(&r.seq) {
+		if atomic.CompareAndSwapInt32(&r.seq, seq, p.Seq) {
+			break
+		}
+	}
+	r.rLock.RLock()
+	for ch := r.next; ch != nil; ch = ch.Next {
+		if ch.Push(p) != nil {
+			dropped++
+		} else {
+			delivered++
+		}
+	}
+	r.rLock.RUnlock()
+	return
+}
+
+// Channels get at most limit channels of the room.
+func (r *Room) Channels(limit int) (chs []*Channel) {
+	r.rLock.RLock()
+	for ch := r.next; ch != nil && len(chs) < limit; ch = ch.Next {
+		chs = append(chs, ch)
+	}
+	r.rLock.RUnlock()
+	return
+}
+
+// Kick kick every channel of the room.
+func (r *Room) Kick(reason int32, msg string) {
 	r.rLock.RLock()
 	for ch := r.next; ch != nil; ch = ch.Next {
-		_ = ch.Push(p)
+		ch.Kick(reason, msg)
 	}
 	r.rLock.RUnlock()
 }
@@ -91,3 +124,8 @@ func (r *Room) OnlineNum() int32 {
 	}
 	return r.Online
 }
+
+// Seq get the seq of the last room msg.
+func (r *Room) Seq() int32 {
+	return atomic.LoadInt32(&r.seq)
+}
@@ -3,11 +3,16 @@ package comet
 import (
 	"context"
 	"math/rand"
+	"net"
+	"strconv"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/wcaqrl/chime/api/logic"
 	"github.com/wcaqrl/chime/internal/comet/conf"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 	"github.com/zhenjl/cityhash"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
@@ -44,6 +49,7 @@ func newLogicClient(c *conf.RPCClient) logic.LogicClient {
 				PermitWithoutStream: true,
 			}),
 			grpc.WithBalancerName(roundrobin.Name),
+			grpc.WithChainUnaryInterceptor(metric.UnaryClientInterceptor(logicDuration), tracing.UnaryClientInterceptor()),
 		}...)
 	if err != nil {
 		panic(err)
@@ -58,25 +64,46 @@ type Server struct {
 	buckets   []*Bucket // subkey bucket
 	bucketIdx uint32
 
-	serverID  string
-	rpcClient logic.LogicClient
+	serverID    string
+	rpcClient   logic.LogicClient
+	acker       *Acker
+	slow        *SlowConsumer
+	tracer      *Tracer
+	deliveries  *Deliveries
+	broadcaster *Broadcaster
+	migrating   int32 // a migration is running
+
+	listeners     []net.Listener
+	listenerMutex sync.Mutex
 }
 
 // NewServer returns a new Server.
 func NewServer(c *conf.Config) *Server {
+	deliveries := NewDeliveries(c.Delivery)
 	s := &Server{
-		c:         c,
-		round:     NewRound(c),
-		rpcClient: newLogicClient(c.RPCClient),
+		c:          c,
+		round:      NewRound(c),
+		rpcClient:  newLogicClient(c.RPCClient),
+		acker:      NewAcker(c.Ack, deliveries),
+		slow:       NewSlowConsumer(c.Slow),
+		deliveries: deliveries,
 	}
+	s.tracer = NewTracer(c.Trace)
 	// init bucket
 	s.buckets = make([]*Bucket, c.Bucket.Size)
 	s.bucketIdx = uint32(c.Bucket.Size)
 	for i := 0; i < c.Bucket.Size; i++ {
-		s.buckets[i] = NewBucket(c.Bucket)
+		s.buckets[i] = NewBucket(c.Bucket, s.deliveries)
 	}
+	s.broadcaster = NewBroadcaster(c.Broadcast, s.buckets, s.deliveries)
 	s.serverID = c.Env.Host
 	go s.onlineproc()
+	go s.ackproc()
+	go s.slowproc()
+	go s.deliveryproc()
+	if c.Resume.Enable && c.Resume.Sync > 0 {
+		go s.resumeproc()
+	}
 	return s
 }
 
@@ -85,6 +112,26 @@ func (s *Server) Buckets() []*Bucket {
 	return s.buckets
 }
 
+// Acker return the push acker.
+func (s *Server) Acker() *Acker {
+	return s.acker
+}
+
+// Deliveries return the push delivery counter.
+func (s *Server) Deliveries() *Deliveries {
+	return s.deliveries
+}
+
+// Broadcaster return the broadcast scheduler.
+func (s *Server) Broadcaster() *Broadcaster {
+	return s.broadcaster
+}
+
+// Tracer return the conn tracer.
+func (s *Server) Tracer() *Tracer {
+	return s.tracer
+}
+
 // Bucket get the bucket by subkey.
 func (s *Server) Bucket(subKey string) *Bucket {
 	idx := cityhash.CityHash32([]byte(subKey), uint32(len(subKey))) % s.bucketIdx
@@ -101,7 +148,13 @@ func (s *Server) RandServerHearbeat() time.Duration {
 
 // Close close the server.
 func (s *Server) Close() (err error) {
-	return
+	return s.tracer.Close()
+}
+
+func (s *Server) addListener(l net.Listener) {
+	s.listenerMutex.Lock()
+	s.listeners = append(s.listeners, l)
+	s.listenerMutex.Unlock()
 }
 
 func (s *Server) onlineproc() {
@@ -111,7 +164,10 @@ func (s *Server) onlineproc() {
 			err           error
 		)
 		roomCount := make(map[string]int32)
-		for _, bucket := range s.buckets {
+		for i, bucket := range s.buckets {
+			idx := strconv.Itoa(i)
+			bucketChannels.WithLabelValues(idx).Set(float64(bucket.ChannelCount()))
+			bucketRooms.WithLabelValues(idx).Set(float64(bucket.RoomCount()))
 			for roomID, count := range bucket.RoomsCount() {
 				roomCount[roomID] += count
 			}
@@ -36,6 +36,7 @@ func InitTCP(server *Server, addrs []string, accept int) (err error) {
 			return
 		}
 		log.Infof("start tcp listen: %s", bind)
+		server.addListener(listener)
 		// split N core accept
 		for i := 0; i < accept; i++ {
 			go acceptTCP(server, listener)
@@ -101,8 +102,8 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 		rid     string
 		accepts []int32
 		hb      time.Duration
-		white   bool
 		p       *protocol.Proto
+		replay  *protocol.Proto
 		b       *Bucket
 		trd     *xtime.TimerData
 		lastHb  = time.Now()
@@ -114,6 +115,10 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 	)
 	ch.Reader.ResetBuffer(conn, rb.Bytes())
 	ch.Writer.ResetBuffer(conn, wb.Bytes())
+	ch.slow, ch.closer = s.slow, conn
+	if s.c.Resume.Enable {
+		ch.resume = newResumeRing(s.c.Resume.Slot)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	// handshake
@@ -126,10 +131,12 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 	// must not setadv, only used in auth
 	step = 1
 	if p, err = ch.CliProto.Set(); err == nil {
-		if ch.Mid, ch.Key, rid, accepts, hb, err = s.authTCP(ctx, rr, wr, p); err == nil {
+		if ch.Mid, ch.Key, rid, accepts, hb, replay, err = s.authTCP(ctx, rr, wr, p, ch); err == nil {
 			ch.Watch(accepts...)
 			b = s.Bucket(ch.Key)
-			err = b.Put(rid, ch)
+			if err = b.Put(rid, ch); err == nil && replay != nil {
+				_ = ch.Push(replay)
+			}
 			if conf.Conf.Debug {
 				log.Infof("tcp connnected key:%s mid:%d proto:%+v", ch.Key, ch.Mid, p)
 			}
@@ -141,39 +148,34 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 		rp.Put(rb)
 		wp.Put(wb)
 		tr.Del(trd)
+		handshakeFailures.WithLabelValues(_transportTCP, "auth").Inc()
 		log.Errorf("key: %s handshake failed error(%v)", ch.Key, err)
 		return
 	}
+	connections.WithLabelValues(_transportTCP).Inc()
 	trd.Key = ch.Key
 	tr.Set(trd, hb)
-	white = whitelist.Contains(ch.Mid)
-	if white {
-		whitelist.Printf("key: %s[%s] auth\n", ch.Key, rid)
-	}
+	s.tracer.Event(ch, "auth", nil, nil)
 	step = 3
 	// hanshake ok start dispatch goroutine
 	go s.dispatchTCP(conn, wr, wp, wb, ch)
 	serverHeartbeat := s.RandServerHearbeat()
 	for {
 		if p, err = ch.CliProto.Set(); err != nil {
+			ringFull.WithLabelValues(_transportTCP).Inc()
 			break
 		}
-		if white {
-			whitelist.Printf("key: %s start read proto\n", ch.Key)
-		}
 		if err = p.ReadTCP(rr); err != nil {
 			break
 		}
-		if white {
-			whitelist.Printf("key: %s read proto:%v\n", ch.Key, p)
-		}
+		s.tracer.Event(ch, "read", p, nil)
 		if p.Op == protocol.OpHeartbeat {
 			tr.Set(trd, hb)
 			p.Op = protocol.OpHeartbeatReply
 			p.Body = nil
 			// NOTE: send server heartbeat for a long time
 			if now := time.Now(); now.Sub(lastHb) > serverHeartbeat {
-				if err1 := s.Heartbeat(ctx, ch.Mid, ch.Key); err1 == nil {
+				if err1 := s.Heartbeat(ctx, ch.Mid, ch.Key, ch.Seq()); err1 == nil {
 					lastHb = now
 				}
 			}
@@ -186,18 +188,11 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 				break
 			}
 		}
-		if white {
-			whitelist.Printf("key: %s process proto:%v\n", ch.Key, p)
-		}
+		s.tracer.Event(ch, "process", p, nil)
 		ch.CliProto.SetAdv()
 		ch.Signal()
-		if white {
-			whitelist.Printf("key: %s signal\n", ch.Key)
-		}
-	}
-	if white {
-		whitelist.Printf("key: %s server tcp error(%v)\n", ch.Key, err)
 	}
+	s.tracer.Event(ch, "serve", nil, err)
 	if err != nil && err != io.EOF && !strings.Contains(err.Error(), "closed") {
 		log.Errorf("key: %s server tcp failed error(%v)", ch.Key, err)
 	}
@@ -205,13 +200,13 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 	tr.Del(trd)
 	rp.Put(rb)
 	conn.Close()
+	connections.WithLabelValues(_transportTCP).Dec()
 	ch.Close()
-	if err = s.Disconnect(ctx, ch.Mid, ch.Key); err != nil {
+	s.acker.Release(ch)
+	if err = s.Disconnect(ctx, ch.Mid, ch.Key, ch.Seq(), s.resumeSlot(ch)); err != nil {
 		log.Errorf("key: %s mid: %d operator do disconnect error(%v)", ch.Key, ch.Mid, err)
 	}
-	if white {
-		whitelist.Printf("key: %s mid: %d disconnect error(%v)\n", ch.Key, ch.Mid, err)
-	}
+	s.tracer.Event(ch, "disconnect", nil, err)
 	if conf.Conf.Debug {
 		log.Infof("tcp disconnected key: %s mid: %d", ch.Key, ch.Mid)
 	}
@@ -222,31 +217,23 @@ func (s *Server) ServeTCP(conn *net.TCPConn, rp, wp *bytes.Pool, tr *xtime.Timer
 // invokes it in a go statement.
 func (s *Server) dispatchTCP(conn *net.TCPConn, wr *bufio.Writer, wp *bytes.Pool, wb *bytes.Buffer, ch *Channel) {
 	var (
-		err    error
-		finish bool
-		online int32
-		white  = whitelist.Contains(ch.Mid)
+		err     error
+		finish  bool
+		online  int32
+		roomSeq int32
 	)
 	if conf.Conf.Debug {
 		log.Infof("key: %s start dispatch tcp goroutine", ch.Key)
 	}
 	for {
-		if white {
-			whitelist.Printf("key: %s wait proto ready\n", ch.Key)
-		}
 		var p = ch.Ready()
-		if white {
-			whitelist.Printf("key: %s proto ready\n", ch.Key)
-		}
 		if conf.Conf.Debug {
-			log.Infof("key:%s dispatch msg:%v", ch.Key, *p)
+			log.Infof("key:%s dispatch msg:%v", ch.Key, p)
 		}
 
 		switch p {
 		case protocol.ProtoFinish:
-			if white {
-				whitelist.Printf("key: %s receive proto finish\n", ch.Key)
-			}
+			s.tracer.Event(ch, "finish", nil, nil)
 			if conf.Conf.Debug {
 				log.Infof("key: %s wakeup exit dispatch goroutine", ch.Key)
 			}
@@ -258,14 +245,12 @@ func (s *Server) dispatchTCP(conn *net.TCPConn, wr *bufio.Writer, wp *bytes.Pool
 				if p, err = ch.CliProto.Get(); err != nil {
 					break
 				}
-				if white {
-					whitelist.Printf("key: %s start write client proto%v\n", ch.Key, p)
-				}
 				if p.Op == protocol.OpHeartbeatReply {
 					if ch.Room != nil {
 						online = ch.Room.OnlineNum()
+						roomSeq = ch.Room.Seq()
 					}
-					if err = p.WriteTCPHeart(wr, online); err != nil {
+					if err = p.WriteTCPHeart(wr, online, ch.Seq(), roomSeq); err != nil {
 						goto failed
 					}
 				} else {
@@ -273,75 +258,71 @@ func (s *Server) dispatchTCP(conn *net.TCPConn, wr *bufio.Writer, wp *bytes.Pool
 						goto failed
 					}
 				}
-				if white {
-					whitelist.Printf("key: %s write client proto%v\n", ch.Key, p)
-				}
+				s.tracer.Event(ch, "reply", p, nil)
 				p.Body = nil // avoid memory leak
 				ch.CliProto.GetAdv()
 			}
 		default:
-			if white {
-				whitelist.Printf("key: %s start write server proto%v\n", ch.Key, p)
-			}
 			// server send
 			if err = p.WriteTCP(wr); err != nil {
 				goto failed
 			}
-			if white {
-				whitelist.Printf("key: %s write server proto%v\n", ch.Key, p)
-			}
+			closing := ch.sent(p)
+			s.tracer.Event(ch, "push", p, nil)
 			if conf.Conf.Debug {
 				log.Infof("tcp sent a message key:%s mid:%d proto:%+v", ch.Key, ch.Mid, p)
 			}
+			if closing {
+				// kicked or drained, close the conn and the reader tears the channel down
+				err = wr.Flush()
+				goto failed
+			}
 		}
 
-		if white {
-			whitelist.Printf("key: %s start flush \n", ch.Key)
-		}
 		// only hungry flush response
 		if err = wr.Flush(); err != nil {
 			break
 		}
-		if white {
-			whitelist.Printf("key: %s flush\n", ch.Key)
-		}
 	}
 failed:
-	if white {
-		whitelist.Printf("key: %s dispatch tcp error(%v)\n", ch.Key, err)
-	}
+	s.tracer.Event(ch, "dispatch", nil, err)
 	if err != nil {
 		log.Errorf("key: %s dispatch tcp error(%v)", ch.Key, err)
 	}
 	conn.Close()
 	wp.Put(wb)
-	// must ensure all channel message discard, for reader won't blocking Signal
+	// must ensure all channel message discard, for reader won't blocking Signal,
+	// the discarded msgs are kept in the resume slot
 	for !finish {
-		finish = (ch.Ready() == protocol.ProtoFinish)
+		p := ch.Ready()
+		finish = (p == protocol.ProtoFinish)
+		ch.sent(p)
 	}
+	ch.resume.drain()
 	if conf.Conf.Debug {
 		log.Infof("key: %s dispatch goroutine exit", ch.Key)
 	}
 }
 
-// auth for chime handshake with client, use rsa & aes.
-func (s *Server) authTCP(ctx context.Context, rr *bufio.Reader, wr *bufio.Writer, p *protocol.Proto) (mid int64, key, rid string, accepts []int32, hb time.Duration, err error) {
+// auth for chime handshake with client, use rsa & aes, or resume a session.
+func (s *Server) authTCP(ctx context.Context, rr *bufio.Reader, wr *bufio.Writer, p *protocol.Proto, ch *Channel) (mid int64, key, rid string, accepts []int32, hb time.Duration, replay *protocol.Proto, err error) {
 	for {
 		if err = p.ReadTCP(rr); err != nil {
 			return
 		}
-		if p.Op == protocol.OpAuth {
+		if p.Op == protocol.OpAuth || p.Op == protocol.OpResume {
 			break
 		} else {
 			log.Errorf("tcp request operation(%d) not auth", p.Op)
 		}
 	}
-	if mid, key, rid, accepts, hb, err = s.Connect(ctx, p, ""); err != nil {
+	if mid, key, rid, accepts, hb, replay, err = s.handshake(ctx, p, "", ch); err != nil {
 		log.Errorf("authTCP.Connect(key:%v).err(%v)", key, err)
+		if p.WriteTCP(wr) == nil {
+			_ = wr.Flush()
+		}
 		return
 	}
-	p.Op = protocol.OpAuthReply
-	p.Body = nil
 	if err = p.WriteTCP(wr); err != nil {
 		log.Errorf("authTCP.WriteTCP(key:%v).err(%v)", key, err)
 		return
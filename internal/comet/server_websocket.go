@@ -33,6 +33,7 @@ func InitWebsocket(server *Server, addrs []string, accept int) (err error) {
 			return
 		}
 		log.Infof("start ws listen: %s", bind)
+		server.addListener(listener)
 		// split N core accept
 		for i := 0; i < accept; i++ {
 			go acceptWebsocket(server, listener)
@@ -67,6 +68,7 @@ func InitWebsocketWithTLS(server *Server, addrs []string, certFile, privateFile
 			return
 		}
 		log.Infof("start wss listen: %s", bind)
+		server.addListener(listener)
 		// split N core accept
 		for i := 0; i < accept; i++ {
 			go acceptWebsocketWithTLS(server, listener)
@@ -154,8 +156,8 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 		rid     string
 		accepts []int32
 		hb      time.Duration
-		white   bool
 		p       *protocol.Proto
+		replay  *protocol.Proto
 		b       *Bucket
 		trd     *xtime.TimerData
 		lastHB  = time.Now()
@@ -168,6 +170,10 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 	)
 	// reader
 	ch.Reader.ResetBuffer(conn, rb.Bytes())
+	ch.slow, ch.closer = s.slow, conn
+	if s.c.Resume.Enable {
+		ch.resume = newResumeRing(s.c.Resume.Slot)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	// handshake
@@ -185,6 +191,7 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 		conn.Close()
 		tr.Del(trd)
 		rp.Put(rb)
+		handshakeFailures.WithLabelValues(_transportWebsocket, "request").Inc()
 		if err != io.EOF {
 			log.Errorf("http.ReadRequest(rr) error(%v)", err)
 		}
@@ -199,6 +206,7 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 		tr.Del(trd)
 		rp.Put(rb)
 		wp.Put(wb)
+		handshakeFailures.WithLabelValues(_transportWebsocket, "upgrade").Inc()
 		if err != io.EOF {
 			log.Errorf("websocket.NewServerConn error(%v)", err)
 		}
@@ -207,10 +215,12 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 	// must not setadv, only used in auth
 	step = 3
 	if p, err = ch.CliProto.Set(); err == nil {
-		if ch.Mid, ch.Key, rid, accepts, hb, err = s.authWebsocket(ctx, ws, p, req.Header.Get("Cookie")); err == nil {
+		if ch.Mid, ch.Key, rid, accepts, hb, replay, err = s.authWebsocket(ctx, ws, p, req.Header.Get("Cookie"), ch); err == nil {
 			ch.Watch(accepts...)
 			b = s.Bucket(ch.Key)
-			err = b.Put(rid, ch)
+			if err = b.Put(rid, ch); err == nil && replay != nil {
+				_ = ch.Push(replay)
+			}
 			if conf.Conf.Debug {
 				log.Infof("websocket connected key:%s mid:%d proto:%+v", ch.Key, ch.Mid, p)
 			}
@@ -222,41 +232,36 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 		rp.Put(rb)
 		wp.Put(wb)
 		tr.Del(trd)
+		handshakeFailures.WithLabelValues(_transportWebsocket, "auth").Inc()
 		if err != io.EOF && err != websocket.ErrMessageClose {
 			log.Errorf("key: %s remoteIP: %s step: %d ws handshake failed error(%v)", ch.Key, conn.RemoteAddr().String(), step, err)
 		}
 		return
 	}
+	connections.WithLabelValues(_transportWebsocket).Inc()
 	trd.Key = ch.Key
 	tr.Set(trd, hb)
-	white = whitelist.Contains(ch.Mid)
-	if white {
-		whitelist.Printf("key: %s[%s] auth\n", ch.Key, rid)
-	}
+	s.tracer.Event(ch, "auth", nil, nil)
 	// handshake ok start dispatch goroutine
 	step = 5
 	go s.dispatchWebsocket(ws, wp, wb, ch)
 	serverHeartbeat := s.RandServerHearbeat()
 	for {
 		if p, err = ch.CliProto.Set(); err != nil {
+			ringFull.WithLabelValues(_transportWebsocket).Inc()
 			break
 		}
-		if white {
-			whitelist.Printf("key: %s start read proto\n", ch.Key)
-		}
 		if err = p.ReadWebsocket(ws); err != nil {
 			break
 		}
-		if white {
-			whitelist.Printf("key: %s read proto:%v\n", ch.Key, p)
-		}
+		s.tracer.Event(ch, "read", p, nil)
 		if p.Op == protocol.OpHeartbeat {
 			tr.Set(trd, hb)
 			p.Op = protocol.OpHeartbeatReply
 			p.Body = nil
 			// NOTE: send server heartbeat for a long time
 			if now := time.Now(); now.Sub(lastHB) > serverHeartbeat {
-				if err1 := s.Heartbeat(ctx, ch.Mid, ch.Key); err1 == nil {
+				if err1 := s.Heartbeat(ctx, ch.Mid, ch.Key, ch.Seq()); err1 == nil {
 					lastHB = now
 				}
 			}
@@ -269,32 +274,25 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 				break
 			}
 		}
-		if white {
-			whitelist.Printf("key: %s process proto:%v\n", ch.Key, p)
-		}
+		s.tracer.Event(ch, "process", p, nil)
 		ch.CliProto.SetAdv()
 		ch.Signal()
-		if white {
-			whitelist.Printf("key: %s signal\n", ch.Key)
-		}
-	}
-	if white {
-		whitelist.Printf("key: %s server tcp error(%v)\n", ch.Key, err)
 	}
+	s.tracer.Event(ch, "serve", nil, err)
 	if err != nil && err != io.EOF && err != websocket.ErrMessageClose && !strings.Contains(err.Error(), "closed") {
 		log.Errorf("key: %s server ws failed error(%v)", ch.Key, err)
 	}
 	b.Del(ch)
 	tr.Del(trd)
 	ws.Close()
+	connections.WithLabelValues(_transportWebsocket).Dec()
 	ch.Close()
+	s.acker.Release(ch)
 	rp.Put(rb)
-	if err = s.Disconnect(ctx, ch.Mid, ch.Key); err != nil {
+	if err = s.Disconnect(ctx, ch.Mid, ch.Key, ch.Seq(), s.resumeSlot(ch)); err != nil {
 		log.Errorf("key: %s operator do disconnect error(%v)", ch.Key, err)
 	}
-	if white {
-		whitelist.Printf("key: %s disconnect error(%v)\n", ch.Key, err)
-	}
+	s.tracer.Event(ch, "disconnect", nil, err)
 	if conf.Conf.Debug {
 		log.Infof("websocket disconnected key: %s mid:%d", ch.Key, ch.Mid)
 	}
@@ -305,30 +303,22 @@ func (s *Server) ServeWebsocket(conn net.Conn, rp, wp *bytes.Pool, tr *xtime.Tim
 // invokes it in a go statement.
 func (s *Server) dispatchWebsocket(ws *websocket.Conn, wp *bytes.Pool, wb *bytes.Buffer, ch *Channel) {
 	var (
-		err    error
-		finish bool
-		online int32
-		white  = whitelist.Contains(ch.Mid)
+		err     error
+		finish  bool
+		online  int32
+		roomSeq int32
 	)
 	if conf.Conf.Debug {
 		log.Infof("key: %s start dispatch tcp goroutine", ch.Key)
 	}
 	for {
-		if white {
-			whitelist.Printf("key: %s wait proto ready\n", ch.Key)
-		}
 		var p = ch.Ready()
-		if white {
-			whitelist.Printf("key: %s proto ready\n", ch.Key)
-		}
 		if conf.Conf.Debug {
 			log.Infof("key:%s dispatch msg:%s", ch.Key, p.Body)
 		}
 		switch p {
 		case protocol.ProtoFinish:
-			if white {
-				whitelist.Printf("key: %s receive proto finish\n", ch.Key)
-			}
+			s.tracer.Event(ch, "finish", nil, nil)
 			if conf.Conf.Debug {
 				log.Infof("key: %s wakeup exit dispatch goroutine", ch.Key)
 			}
@@ -340,14 +330,12 @@ func (s *Server) dispatchWebsocket(ws *websocket.Conn, wp *bytes.Pool, wb *bytes
 				if p, err = ch.CliProto.Get(); err != nil {
 					break
 				}
-				if white {
-					whitelist.Printf("key: %s start write client proto%v\n", ch.Key, p)
-				}
 				if p.Op == protocol.OpHeartbeatReply {
 					if ch.Room != nil {
 						online = ch.Room.OnlineNum()
+						roomSeq = ch.Room.Seq()
 					}
-					if err = p.WriteWebsocketHeart(ws, online); err != nil {
+					if err = p.WriteWebsocketHeart(ws, online, ch.Seq(), roomSeq); err != nil {
 						goto failed
 					}
 				} else {
@@ -355,73 +343,69 @@ func (s *Server) dispatchWebsocket(ws *websocket.Conn, wp *bytes.Pool, wb *bytes
 						goto failed
 					}
 				}
-				if white {
-					whitelist.Printf("key: %s write client proto%v\n", ch.Key, p)
-				}
+				s.tracer.Event(ch, "reply", p, nil)
 				p.Body = nil // avoid memory leak
 				ch.CliProto.GetAdv()
 			}
 		default:
-			if white {
-				whitelist.Printf("key: %s start write server proto%v\n", ch.Key, p)
-			}
 			// server send
 			if err = p.WriteWebsocket(ws); err != nil {
 				goto failed
 			}
-			if white {
-				whitelist.Printf("key: %s write server proto%v\n", ch.Key, p)
-			}
+			closing := ch.sent(p)
+			s.tracer.Event(ch, "push", p, nil)
 			if conf.Conf.Debug {
 				log.Infof("websocket sent a message key:%s mid:%d proto:%+v", ch.Key, ch.Mid, p)
 			}
-		}
-		if white {
-			whitelist.Printf("key: %s start flush \n", ch.Key)
+			if closing {
+				// kicked or drained, close the conn and the reader tears the channel down
+				err = ws.Flush()
+				goto failed
+			}
 		}
 		// only hungry flush response
 		if err = ws.Flush(); err != nil {
 			break
 		}
-		if white {
-			whitelist.Printf("key: %s flush\n", ch.Key)
-		}
 	}
 failed:
-	if white {
-		whitelist.Printf("key: %s dispatch tcp error(%v)\n", ch.Key, err)
-	}
+	s.tracer.Event(ch, "dispatch", nil, err)
 	if err != nil && err != io.EOF && err != websocket.ErrMessageClose {
 		log.Errorf("key: %s dispatch ws error(%v)", ch.Key, err)
 	}
 	ws.Close()
 	wp.Put(wb)
-	// must ensure all channel message discard, for reader won't blocking Signal
+	// must ensure all channel message discard, for reader won't blocking Signal,
+	// the discarded msgs are kept in the resume slot
 	for !finish {
-		finish = (ch.Ready() == protocol.ProtoFinish)
+		p := ch.Ready()
+		finish = (p == protocol.ProtoFinish)
+		ch.sent(p)
 	}
+	ch.resume.drain()
 	if conf.Conf.Debug {
 		log.Infof("key: %s dispatch goroutine exit", ch.Key)
 	}
 }
 
-// auth for chime handshake with client, use rsa & aes.
-func (s *Server) authWebsocket(ctx context.Context, ws *websocket.Conn, p *protocol.Proto, cookie string) (mid int64, key, rid string, accepts []int32, hb time.Duration, err error) {
+// auth for chime handshake with client, use rsa & aes, or resume a session.
+func (s *Server) authWebsocket(ctx context.Context, ws *websocket.Conn, p *protocol.Proto, cookie string, ch *Channel) (mid int64, key, rid string, accepts []int32, hb time.Duration, replay *protocol.Proto, err error) {
 	for {
 		if err = p.ReadWebsocket(ws); err != nil {
 			return
 		}
-		if p.Op == protocol.OpAuth {
+		if p.Op == protocol.OpAuth || p.Op == protocol.OpResume {
 			break
 		} else {
 			log.Errorf("ws request operation(%d) not auth", p.Op)
 		}
 	}
-	if mid, key, rid, accepts, hb, err = s.Connect(ctx, p, cookie); err != nil {
+	if mid, key, rid, accepts, hb, replay, err = s.handshake(ctx, p, cookie, ch); err != nil {
+		if p.WriteWebsocket(ws) == nil {
+			_ = ws.Flush()
+		}
 		return
 	}
-	p.Op = protocol.OpAuthReply
-	p.Body = nil
 	if err = p.WriteWebsocket(ws); err != nil {
 		return
 	}
@@ -0,0 +1,146 @@
+package comet
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+	"github.com/wcaqrl/chime/internal/comet/errors"
+)
+
+const (
+	// max keys reported per interval
+	_slowReportKeys = 1000
+)
+
+// SlowConsumer apply the slow consumer policy to the channels whose signal
+// chan is full and count their drops.
+type SlowConsumer struct {
+	c     *conf.Slow
+	mutex sync.Mutex
+	keys  map[string]int64 // drops by key since last report
+	// counters since last report
+	dropped      int64
+	spilled      int64
+	disconnected int64
+}
+
+// NewSlowConsumer new a slow consumer policy.
+func NewSlowConsumer(c *conf.Slow) *SlowConsumer {
+	return &SlowConsumer{
+		c:    c,
+		keys: make(map[string]int64),
+	}
+}
+
+// push apply the policy to a proto the full signal chan rejected.
+func (s *SlowConsumer) push(ch *Channel, p *protocol.Proto) error {
+	switch s.c.Policy {
+	case conf.SlowSpill, conf.SlowDropOldest:
+		return s.spill(ch, p)
+	case conf.SlowDisconnect:
+		if n := atomic.AddInt32(&ch.drops, 1); int(n) >= s.c.Disconnect && ch.closer != nil &&
+			atomic.CompareAndSwapInt32(&ch.slowClosed, 0, 1) {
+			// the dispatch may be blocked on the conn, close it and the
+			// reader tears the channel down
+			_ = ch.closer.Close()
+			atomic.AddInt64(&s.disconnected, 1)
+			slowDisconnects.Inc()
+			log.Warningf("key: %s slow consumer disconnected after %d drops", ch.Key, n)
+		}
+	}
+	s.drop(ch)
+	return errors.ErrSignalFullMsgDropped
+}
+
+// spill queue the proto in the overflow list of the channel, the dispatch
+// takes it once the signal chan is empty so the order is kept.
+func (s *SlowConsumer) spill(ch *Channel, p *protocol.Proto) error {
+	ch.slowMutex.Lock()
+	defer ch.slowMutex.Unlock()
+	if len(ch.overflow) == 0 {
+		// drained meanwhile
+		select {
+		case ch.signal <- p:
+			return nil
+		default:
+		}
+	}
+	if len(ch.overflow) >= s.c.Spill {
+		if s.c.Policy != conf.SlowDropOldest || len(ch.overflow) == 0 {
+			s.drop(ch)
+			return errors.ErrSignalFullMsgDropped
+		}
+		ch.overflow[0] = nil
+		ch.overflow = ch.overflow[1:]
+		s.drop(ch)
+	}
+	ch.overflow = append(ch.overflow, p)
+	atomic.StoreInt32(&ch.spills, int32(len(ch.overflow)))
+	atomic.AddInt64(&s.spilled, 1)
+	signalSpills.Inc()
+	return nil
+}
+
+func (s *SlowConsumer) drop(ch *Channel) {
+	atomic.AddInt64(&s.dropped, 1)
+	signalDrops.WithLabelValues(s.c.Policy).Inc()
+	s.mutex.Lock()
+	if _, ok := s.keys[ch.Key]; ok || len(s.keys) < _slowReportKeys {
+		s.keys[ch.Key]++
+	}
+	s.mutex.Unlock()
+}
+
+// Stats return the counters since last call.
+func (s *SlowConsumer) Stats() (keys map[string]int64, dropped, spilled, disconnected int64) {
+	s.mutex.Lock()
+	keys = s.keys
+	s.keys = make(map[string]int64)
+	s.mutex.Unlock()
+	return keys, atomic.SwapInt64(&s.dropped, 0), atomic.SwapInt64(&s.spilled, 0), atomic.SwapInt64(&s.disconnected, 0)
+}
+
+// unspill take the oldest proto of the overflow list.
+func (c *Channel) unspill() (p *protocol.Proto) {
+	c.slowMutex.Lock()
+	if len(c.overflow) > 0 {
+		p = c.overflow[0]
+		c.overflow[0] = nil
+		if c.overflow = c.overflow[1:]; len(c.overflow) == 0 {
+			c.overflow = nil
+		}
+		atomic.StoreInt32(&c.spills, int32(len(c.overflow)))
+	}
+	c.slowMutex.Unlock()
+	return
+}
+
+// slowproc report the slow consumer counters to logic.
+func (s *Server) slowproc() {
+	tick := time.Duration(s.c.Slow.Report)
+	if tick <= 0 {
+		tick = 10 * time.Second
+	}
+	for {
+		time.Sleep(tick)
+		keys, dropped, spilled, disconnected := s.slow.Stats()
+		if dropped == 0 && spilled == 0 && disconnected == 0 {
+			continue
+		}
+		if _, err := s.rpcClient.SlowReport(context.Background(), &logic.SlowReportReq{
+			Server:       s.serverID,
+			Keys:         keys,
+			Dropped:      dropped,
+			Spilled:      spilled,
+			Disconnected: disconnected,
+		}); err != nil {
+			log.Errorf("s.rpcClient.SlowReport(dropped:%d spilled:%d disconnected:%d) error(%v)", dropped, spilled, disconnected, err)
+		}
+	}
+}
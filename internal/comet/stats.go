@@ -0,0 +1,107 @@
+package comet
+
+import (
+	"time"
+)
+
+// BucketStats the channels and rooms of a bucket.
+type BucketStats struct {
+	Channels int `json:"channels"`
+	Rooms    int `json:"rooms"`
+}
+
+// ChannelStats the state of a conn.
+type ChannelStats struct {
+	Key       string    `json:"key"`
+	Mid       int64     `json:"mid"`
+	IP        string    `json:"ip"`
+	Room      string    `json:"room,omitempty"`
+	Accepts   []int32   `json:"accepts"`
+	Queued    int       `json:"queued"`
+	Seq       int32     `json:"seq"`
+	Connected time.Time `json:"connected"`
+}
+
+// RoomMember a conn in a room.
+type RoomMember struct {
+	Key string `json:"key"`
+	Mid int64  `json:"mid"`
+}
+
+// RoomStats the members of a room on the comet.
+type RoomStats struct {
+	ID        string        `json:"id"`
+	Online    int32         `json:"online"`
+	AllOnline int32         `json:"all_online"`
+	Seq       int32         `json:"seq"`
+	Members   []*RoomMember `json:"members"`
+}
+
+// TimerStats the pending timers of a round timer.
+type TimerStats struct {
+	Size    int `json:"size"`
+	Pending int `json:"pending"`
+}
+
+// BucketStats get the stats of every bucket.
+func (s *Server) BucketStats() []*BucketStats {
+	stats := make([]*BucketStats, len(s.buckets))
+	for i, b := range s.buckets {
+		b.cLock.RLock()
+		stats[i] = &BucketStats{Channels: len(b.chs), Rooms: len(b.rooms)}
+		b.cLock.RUnlock()
+	}
+	return stats
+}
+
+// ChannelStats get the stats of a conn by key, nil if not connected.
+func (s *Server) ChannelStats(key string) *ChannelStats {
+	ch := s.Bucket(key).Channel(key)
+	if ch == nil {
+		return nil
+	}
+	stats := &ChannelStats{
+		Key:       ch.Key,
+		Mid:       ch.Mid,
+		IP:        ch.IP,
+		Accepts:   ch.Accepts(),
+		Queued:    ch.Queued(),
+		Seq:       ch.Seq(),
+		Connected: ch.Created(),
+	}
+	if room := ch.Room; room != nil {
+		stats.Room = room.ID
+	}
+	return stats
+}
+
+// RoomStats get at most limit members of a room, nil if no conn is in it.
+func (s *Server) RoomStats(rid string, limit int) *RoomStats {
+	var stats *RoomStats
+	for _, b := range s.buckets {
+		room := b.Room(rid)
+		if room == nil {
+			continue
+		}
+		if stats == nil {
+			stats = &RoomStats{ID: rid, AllOnline: room.AllOnline, Members: []*RoomMember{}}
+		}
+		stats.Online += room.Online
+		if seq := room.Seq(); seq > stats.Seq {
+			stats.Seq = seq
+		}
+		for _, ch := range room.Channels(limit - len(stats.Members)) {
+			stats.Members = append(stats.Members, &RoomMember{Key: ch.Key, Mid: ch.Mid})
+		}
+	}
+	return stats
+}
+
+// TimerStats get the stats of every round timer.
+func (s *Server) TimerStats() []*TimerStats {
+	stats := make([]*TimerStats, len(s.round.timers))
+	for i := range s.round.timers {
+		stats[i] = &TimerStats{Size: s.round.options.TimerSize, Pending: s.round.timers[i].Len()}
+	}
+	return stats
+}
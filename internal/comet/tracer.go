@@ -0,0 +1,260 @@
+package comet
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/comet/conf"
+)
+
+// TraceEvent a structured event of a traced conn.
+type TraceEvent struct {
+	Time  time.Time `json:"ts"`
+	Key   string    `json:"key"`
+	Mid   int64     `json:"mid"`
+	Room  string    `json:"room,omitempty"`
+	Event string    `json:"event"`
+	Op    int32     `json:"op,omitempty"`
+	Seq   int32     `json:"seq,omitempty"`
+	Len   int       `json:"len,omitempty"`
+	Err   string    `json:"err,omitempty"`
+}
+
+// TraceStats a trace enabled on a mid or key.
+type TraceStats struct {
+	Mid    int64     `json:"mid,omitempty"`
+	Key    string    `json:"key,omitempty"`
+	Expire time.Time `json:"expire"`
+}
+
+// TraceStream a live stream of the trace events, the events are dropped while
+// the stream is full.
+type TraceStream struct {
+	C   <-chan []byte
+	c   chan []byte
+	mid int64
+	key string
+}
+
+// Tracer trace the conns of the mids or keys enabled at runtime, until the
+// trace expires.
+type Tracer struct {
+	c      *conf.Trace
+	active int32 // traces enabled, the untraced conns skip the lock while zero
+	mutex  sync.RWMutex
+	mids   map[int64]time.Time  // mid -> expire
+	keys   map[string]time.Time // key -> expire
+	out    *os.File             // nil if the trace log is disabled or failed to open
+	size   int64                // bytes written to out
+	outMu  sync.Mutex
+	subs   map[*TraceStream]struct{}
+}
+
+// NewTracer new a tracer, the events are written to the trace log if any. A
+// trace log failing to open is disabled, the live streams still work.
+func NewTracer(c *conf.Trace) *Tracer {
+	t := &Tracer{
+		c:    c,
+		mids: make(map[int64]time.Time),
+		keys: make(map[string]time.Time),
+		subs: make(map[*TraceStream]struct{}),
+	}
+	if c.Log != "" {
+		t.outMu.Lock()
+		t.open()
+		t.outMu.Unlock()
+	}
+	go t.expireproc()
+	return t
+}
+
+// open the trace log, must be called with the out lock held.
+func (t *Tracer) open() {
+	f, err := os.OpenFile(t.c.Log, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		log.Errorf("trace log os.OpenFile(%s) error(%v)", t.c.Log, err)
+		return
+	}
+	t.out, t.size = f, 0
+	if fi, err := f.Stat(); err == nil {
+		t.size = fi.Size()
+	}
+}
+
+// write an event to the trace log, the log is rotated to a single backup
+// once it grows over the max size.
+func (t *Tracer) write(b []byte) {
+	t.outMu.Lock()
+	defer t.outMu.Unlock()
+	if t.out == nil {
+		return
+	}
+	if t.c.MaxSize > 0 && t.size+int64(len(b)) > t.c.MaxSize {
+		t.out.Close()
+		t.out = nil
+		if err := os.Rename(t.c.Log, t.c.Log+".1"); err != nil {
+			log.Errorf("trace log os.Rename(%s) error(%v)", t.c.Log, err)
+		}
+		if t.open(); t.out == nil {
+			return
+		}
+	}
+	n, _ := t.out.Write(b)
+	t.size += int64(n)
+}
+
+// Close close the trace log.
+func (t *Tracer) Close() (err error) {
+	t.outMu.Lock()
+	if t.out != nil {
+		err = t.out.Close()
+		t.out = nil
+	}
+	t.outMu.Unlock()
+	return
+}
+
+// Trace enable the trace of a mid or key for ttl, the default ttl if zero.
+func (t *Tracer) Trace(mid int64, key string, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = time.Duration(t.c.TTL)
+	}
+	expire := time.Now().Add(ttl)
+	t.mutex.Lock()
+	if mid > 0 {
+		t.mids[mid] = expire
+	}
+	if key != "" {
+		t.keys[key] = expire
+	}
+	t.update()
+	t.mutex.Unlock()
+	log.Infof("trace mid:%d key:%s ttl:%s", mid, key, ttl)
+}
+
+// Untrace disable the trace of a mid or key.
+func (t *Tracer) Untrace(mid int64, key string) {
+	t.mutex.Lock()
+	delete(t.mids, mid)
+	delete(t.keys, key)
+	t.update()
+	t.mutex.Unlock()
+	log.Infof("untrace mid:%d key:%s", mid, key)
+}
+
+// update must be called with the lock held.
+func (t *Tracer) update() {
+	atomic.StoreInt32(&t.active, int32(len(t.mids)+len(t.keys)))
+}
+
+// Traces get the enabled traces.
+func (t *Tracer) Traces() (res []*TraceStats) {
+	res = []*TraceStats{}
+	t.mutex.RLock()
+	for mid, expire := range t.mids {
+		res = append(res, &TraceStats{Mid: mid, Expire: expire})
+	}
+	for key, expire := range t.keys {
+		res = append(res, &TraceStats{Key: key, Expire: expire})
+	}
+	t.mutex.RUnlock()
+	return
+}
+
+// Traced check the conn is traced.
+func (t *Tracer) Traced(ch *Channel) (ok bool) {
+	if atomic.LoadInt32(&t.active) == 0 {
+		return false
+	}
+	now := time.Now()
+	t.mutex.RLock()
+	if expire, has := t.mids[ch.Mid]; has && ch.Mid > 0 && now.Before(expire) {
+		ok = true
+	} else if expire, has = t.keys[ch.Key]; has && now.Before(expire) {
+		ok = true
+	}
+	t.mutex.RUnlock()
+	return
+}
+
+// Event write an event of the conn if it is traced, p and err are optional.
+func (t *Tracer) Event(ch *Channel, event string, p *protocol.Proto, err error) {
+	if !t.Traced(ch) {
+		return
+	}
+	e := &TraceEvent{
+		Time:  time.Now(),
+		Key:   ch.Key,
+		Mid:   ch.Mid,
+		Event: event,
+	}
+	if room := ch.Room; room != nil {
+		e.Room = room.ID
+	}
+	if p != nil {
+		e.Op, e.Seq, e.Len = p.Op, p.Seq, len(p.Body)
+	}
+	if err != nil {
+		e.Err = err.Error()
+	}
+	b, _ := json.Marshal(e)
+	b = append(b, '\n')
+	t.write(b)
+	t.mutex.RLock()
+	for s := range t.subs {
+		if (s.mid > 0 && s.mid != e.Mid) || (s.key != "" && s.key != e.Key) {
+			continue
+		}
+		select {
+		case s.c <- b:
+		default:
+		}
+	}
+	t.mutex.RUnlock()
+}
+
+// Subscribe stream the events of the traced conns live, filtered by the mid
+// or key if set. The stream must be closed by Unsubscribe.
+func (t *Tracer) Subscribe(mid int64, key string) *TraceStream {
+	c := make(chan []byte, t.c.Stream)
+	s := &TraceStream{C: c, c: c, mid: mid, key: key}
+	t.mutex.Lock()
+	t.subs[s] = struct{}{}
+	t.mutex.Unlock()
+	return s
+}
+
+// Unsubscribe stop a live stream.
+func (t *Tracer) Unsubscribe(s *TraceStream) {
+	t.mutex.Lock()
+	delete(t.subs, s)
+	t.mutex.Unlock()
+}
+
+// expireproc remove the expired traces.
+func (t *Tracer) expireproc() {
+	for {
+		time.Sleep(time.Second)
+		now := time.Now()
+		t.mutex.Lock()
+		for mid, expire := range t.mids {
+			if !now.Before(expire) {
+				delete(t.mids, mid)
+				log.Infof("trace mid:%d expired", mid)
+			}
+		}
+		for key, expire := range t.keys {
+			if !now.Before(expire) {
+				delete(t.keys, key)
+				log.Infof("trace key:%s expired", key)
+			}
+		}
+		t.update()
+		t.mutex.Unlock()
+	}
+}
@@ -0,0 +1,100 @@
+package job
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wcaqrl/chime/internal/job/conf"
+)
+
+// the breaker states.
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+var breakerStates = []string{"closed", "open", "half-open"}
+
+// breaker a circuit breaker of a comet, it opens after consecutive failed
+// calls, probes the comet after the open timeout with a few calls at a time
+// and closes after as many successful probes.
+type breaker struct {
+	c        *conf.Breaker
+	mutex    sync.Mutex
+	state    int
+	failures int
+	probes   int
+	passed   int
+	opened   time.Time
+}
+
+func newBreaker(c *conf.Breaker) *breaker {
+	return &breaker{c: c}
+}
+
+// allow report whether a call may be made, a true must be followed by done.
+func (b *breaker) allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.opened) < time.Duration(b.c.OpenTimeout) {
+			return false
+		}
+		b.state, b.probes, b.passed = breakerHalfOpen, 0, 0
+		fallthrough
+	case breakerHalfOpen:
+		if b.probes >= b.c.HalfOpen {
+			return false
+		}
+		b.probes++
+	}
+	return true
+}
+
+// done record the result of an allowed call.
+func (b *breaker) done(ok bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case breakerClosed:
+		if ok {
+			b.failures = 0
+		} else if b.failures++; b.failures >= b.c.Failures {
+			b.open()
+		}
+	case breakerHalfOpen:
+		if b.probes > 0 {
+			b.probes--
+		}
+		if !ok {
+			b.open()
+		} else if b.passed++; b.passed >= b.c.HalfOpen {
+			b.state, b.failures = breakerClosed, 0
+		}
+	}
+}
+
+// probeAt get the time the next call may be allowed, the end of the open
+// timeout or zero if the breaker is not open.
+func (b *breaker) probeAt() (t time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.state == breakerOpen {
+		t = b.opened.Add(time.Duration(b.c.OpenTimeout))
+	}
+	return
+}
+
+func (b *breaker) open() {
+	b.state = breakerOpen
+	b.opened = time.Now()
+}
+
+// State the name of the breaker state.
+func (b *breaker) State() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return breakerStates[b.state]
+}
@@ -3,13 +3,17 @@ package job
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"net/url"
-	"sync/atomic"
+	"sync"
 	"time"
 
 	"github.com/bilibili/discovery/naming"
 	"github.com/wcaqrl/chime/api/comet"
+	pb "github.com/wcaqrl/chime/api/logic"
 	"github.com/wcaqrl/chime/internal/job/conf"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -47,6 +51,7 @@ func newCometClient(addr string) (comet.CometClient, error) {
 				Timeout:             grpcKeepAliveTimeout,
 				PermitWithoutStream: true,
 			}),
+			grpc.WithChainUnaryInterceptor(metric.UnaryClientInterceptor(cometDuration), tracing.UnaryClientInterceptor()),
 		}...,
 	)
 	if err != nil {
@@ -55,29 +60,112 @@ func newCometClient(addr string) (comet.CometClient, error) {
 	return comet.NewCometClient(conn), err
 }
 
+// cometReq a request to a comet, done is called once the call succeeded or
+// the msgs it was made of were dead lettered.
+type cometReq struct {
+	push      *comet.PushMsgReq
+	room      *comet.BroadcastRoomReq
+	broadcast *comet.BroadcastReq
+	kick      *comet.KickReq
+	migrate   *comet.MigrateReq
+	trace     *comet.TraceReq
+	msgs      []*pb.PushMsg
+	done      func()
+	// retry
+	attempts int
+	due      time.Time
+}
+
+// expired check every msg of the request expired, a retried one may.
+func (r *cometReq) expired() bool {
+	now := time.Now().Unix()
+	for _, msg := range r.msgs {
+		if msg.ExpireAt == 0 || now < msg.ExpireAt {
+			return false
+		}
+	}
+	return len(r.msgs) > 0
+}
+
+// deadLetterFunc write the msgs failed on a comet to the dead letter topic.
+type deadLetterFunc func(server string, msgs []*pb.PushMsg, attempts int, reason string)
+
+// lane the queues and workers of a priority, the bulk requests never hold
+// the workers of the realtime ones.
+type lane struct {
+	pushChan      []chan *cometReq
+	roomChan      []chan *cometReq
+	broadcastChan chan *cometReq
+}
+
+func newLane(size, chanSize int) *lane {
+	l := &lane{
+		pushChan:      make([]chan *cometReq, size),
+		roomChan:      make([]chan *cometReq, size),
+		broadcastChan: make(chan *cometReq, size),
+	}
+	for i := 0; i < size; i++ {
+		l.pushChan[i] = make(chan *cometReq, chanSize)
+		l.roomChan[i] = make(chan *cometReq, chanSize)
+	}
+	return l
+}
+
+// queued get the requests waiting in the queues.
+func (l *lane) queued() (push, room, broadcast int) {
+	for _, ch := range l.pushChan {
+		push += len(ch)
+	}
+	for _, ch := range l.roomChan {
+		room += len(ch)
+	}
+	return push, room, len(l.broadcastChan)
+}
+
+// bulk check every msg is of the bulk lane.
+func bulk(msgs ...*pb.PushMsg) bool {
+	for _, msg := range msgs {
+		if msg.Priority != pb.PushMsg_BULK {
+			return false
+		}
+	}
+	return len(msgs) > 0
+}
+
 // Comet is a comet.
 type Comet struct {
-	serverID      string
-	client        comet.CometClient
-	pushChan      []chan *comet.PushMsgReq
-	roomChan      []chan *comet.BroadcastRoomReq
-	broadcastChan chan *comet.BroadcastReq
-	pushChanNum   uint64
-	roomChanNum   uint64
-	routineSize   uint64
+	serverID string
+	client   comet.CometClient
+	realtime *lane
+	bulk     *lane
+	kickChan chan *cometReq
+	timeout  time.Duration
+	overflow string
+	breaker  *breaker
+	// retry
+	retry       *conf.Retry
+	deadLetter  deadLetterFunc
+	retryMutex  sync.Mutex
+	retries     retryHeap
+	retrySignal chan struct{}
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
 // NewComet new a comet.
-func NewComet(in *naming.Instance, c *conf.Comet) (*Comet, error) {
+func NewComet(in *naming.Instance, c *conf.Comet, bc *conf.Breaker, retry *conf.Retry, deadLetter deadLetterFunc) (*Comet, error) {
 	cmt := &Comet{
-		serverID:      in.Hostname,
-		pushChan:      make([]chan *comet.PushMsgReq, c.RoutineSize),
-		roomChan:      make([]chan *comet.BroadcastRoomReq, c.RoutineSize),
-		broadcastChan: make(chan *comet.BroadcastReq, c.RoutineSize),
-		routineSize:   uint64(c.RoutineSize),
+		serverID:    in.Hostname,
+		realtime:    newLane(c.RoutineSize, c.RoutineChan),
+		bulk:        newLane(c.BulkRoutineSize, c.BulkRoutineChan),
+		kickChan:    make(chan *cometReq, c.RoutineSize),
+		timeout:     time.Duration(c.Timeout),
+		overflow:    c.Overflow,
+		breaker:     newBreaker(bc),
+		retry:       retry,
+		deadLetter:  deadLetter,
+		retrySignal: make(chan struct{}, 1),
 	}
 	var grpcAddr string
 	for _, addr := range in.Addrs {
@@ -96,81 +184,211 @@ func NewComet(in *naming.Instance, c *conf.Comet) (*Comet, error) {
 	cmt.ctx, cmt.cancel = context.WithCancel(context.Background())
 
 	for i := 0; i < c.RoutineSize; i++ {
-		cmt.pushChan[i] = make(chan *comet.PushMsgReq, c.RoutineChan)
-		cmt.roomChan[i] = make(chan *comet.BroadcastRoomReq, c.RoutineChan)
-		go cmt.process(cmt.pushChan[i], cmt.roomChan[i], cmt.broadcastChan)
+		go cmt.process(cmt.realtime.pushChan[i], cmt.realtime.roomChan[i], cmt.realtime.broadcastChan, cmt.kickChan)
 	}
+	// the bulk workers do not kick, a nil chan is never ready
+	for i := 0; i < c.BulkRoutineSize; i++ {
+		go cmt.process(cmt.bulk.pushChan[i], cmt.bulk.roomChan[i], cmt.bulk.broadcastChan, nil)
+	}
+	go cmt.retryproc()
 	return cmt, nil
 }
 
-// Push push a user message.
-func (c *Comet) Push(arg *comet.PushMsgReq) (err error) {
-	idx := atomic.AddUint64(&c.pushChanNum, 1) % c.routineSize
-	c.pushChan[idx] <- arg
-	return
+// lane get the lane of the msgs, a request of bulk msgs only is bulk unless
+// the bulk lane has no workers.
+func (c *Comet) lane(msgs []*pb.PushMsg) *lane {
+	if len(c.bulk.pushChan) > 0 && bulk(msgs...) {
+		return c.bulk
+	}
+	return c.realtime
 }
 
-// BroadcastRoom broadcast a room message.
-func (c *Comet) BroadcastRoom(arg *comet.BroadcastRoomReq) (err error) {
-	idx := atomic.AddUint64(&c.roomChanNum, 1) % c.routineSize
-	c.roomChan[idx] <- arg
-	return
+// Push push a user message, the messages of a shard of the bus keep their
+// order within a lane.
+func (c *Comet) Push(arg *comet.PushMsgReq, shard uint32, msgs []*pb.PushMsg, done func()) (err error) {
+	l := c.lane(msgs)
+	return c.enqueue(l.pushChan[shard%uint32(len(l.pushChan))], &cometReq{push: arg, msgs: msgs, done: done})
+}
+
+// BroadcastRoom broadcast a room message, the messages of a room keep their
+// order within a lane.
+func (c *Comet) BroadcastRoom(arg *comet.BroadcastRoomReq, msgs []*pb.PushMsg, done func()) (err error) {
+	l := c.lane(msgs)
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(arg.RoomID))
+	return c.enqueue(l.roomChan[h.Sum64()%uint64(len(l.roomChan))], &cometReq{room: arg, msgs: msgs, done: done})
 }
 
 // Broadcast broadcast a message.
-func (c *Comet) Broadcast(arg *comet.BroadcastReq) (err error) {
-	c.broadcastChan <- arg
-	return
+func (c *Comet) Broadcast(arg *comet.BroadcastReq, msgs []*pb.PushMsg, done func()) (err error) {
+	return c.enqueue(c.lane(msgs).broadcastChan, &cometReq{broadcast: arg, msgs: msgs, done: done})
+}
+
+// Kick kick keys or a room.
+func (c *Comet) Kick(arg *comet.KickReq, msgs []*pb.PushMsg, done func()) (err error) {
+	return c.enqueue(c.kickChan, &cometReq{kick: arg, msgs: msgs, done: done})
+}
+
+// Migrate ask a number of entries to reconnect to other nodes, it shares the
+// queue of kick.
+func (c *Comet) Migrate(arg *comet.MigrateReq, msgs []*pb.PushMsg, done func()) (err error) {
+	return c.enqueue(c.kickChan, &cometReq{migrate: arg, msgs: msgs, done: done})
+}
+
+// Trace trace the conns of a mid or key, it shares the queue of kick.
+func (c *Comet) Trace(arg *comet.TraceReq, msgs []*pb.PushMsg, done func()) (err error) {
+	return c.enqueue(c.kickChan, &cometReq{trace: arg, msgs: msgs, done: done})
+}
+
+// enqueue queue the request, a full queue applies the overflow policy and a
+// removed comet dead letters it at once.
+func (c *Comet) enqueue(ch chan *cometReq, req *cometReq) error {
+	select {
+	case ch <- req:
+		return nil
+	case <-c.ctx.Done():
+		c.abandon(req, ErrComet.Error())
+		return ErrComet
+	default:
+	}
+	switch c.overflow {
+	case conf.OverflowBlock:
+		select {
+		case ch <- req:
+			return nil
+		case <-c.ctx.Done():
+			c.abandon(req, ErrComet.Error())
+			return ErrComet
+		}
+	case conf.OverflowDrop:
+		req.done()
+	default:
+		c.abandon(req, ErrCometFull.Error())
+	}
+	return ErrCometFull
 }
 
-func (c *Comet) process(pushChan chan *comet.PushMsgReq, roomChan chan *comet.BroadcastRoomReq, broadcastChan chan *comet.BroadcastReq) {
+func (c *Comet) process(pushChan, roomChan, broadcastChan, kickChan chan *cometReq) {
 	for {
 		select {
-		case broadcastArg := <-broadcastChan:
-			_, err := c.client.Broadcast(context.Background(), &comet.BroadcastReq{
-				Proto:   broadcastArg.Proto,
-				ProtoOp: broadcastArg.ProtoOp,
-				Speed:   broadcastArg.Speed,
-			})
-			if err != nil {
-				log.Errorf("c.client.Broadcast(%s, reply) serverId:%s error(%v)", broadcastArg, c.serverID, err)
-			}
-		case roomArg := <-roomChan:
-			_, err := c.client.BroadcastRoom(context.Background(), &comet.BroadcastRoomReq{
-				RoomID: roomArg.RoomID,
-				Proto:  roomArg.Proto,
-			})
-			if err != nil {
-				log.Errorf("c.client.BroadcastRoom(%s, reply) serverId:%s error(%v)", roomArg, c.serverID, err)
-			}
-		case pushArg := <-pushChan:
-			_, err := c.client.PushMsg(context.Background(), &comet.PushMsgReq{
-				Keys:    pushArg.Keys,
-				Proto:   pushArg.Proto,
-				ProtoOp: pushArg.ProtoOp,
-			})
-			if err != nil {
-				log.Errorf("c.client.PushMsg(%s, reply) serverId:%s error(%v)", pushArg, c.serverID, err)
-			}
+		case req := <-broadcastChan:
+			c.call(req)
+		case req := <-roomChan:
+			c.call(req)
+		case req := <-pushChan:
+			c.call(req)
+		case req := <-kickChan:
+			c.call(req)
 		case <-c.ctx.Done():
+			// the comet is removed, dead letter the queued requests
+			for _, ch := range []chan *cometReq{pushChan, roomChan, broadcastChan, kickChan} {
+				for n := len(ch); n > 0; n-- {
+					select {
+					case req := <-ch:
+						c.abandon(req, ErrComet.Error())
+					default:
+					}
+				}
+			}
 			return
 		}
 	}
 }
 
+// call send the request to the comet and complete it, a failed one is retried later.
+func (c *Comet) call(req *cometReq) {
+	if req.expired() {
+		pushExpired.Add(float64(len(req.msgs)))
+		req.done()
+		return
+	}
+	if !c.breaker.allow() {
+		c.retryProbe(req)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	var span *tracing.Span
+	if len(req.msgs) > 0 {
+		// a batched request is traced by its first msg and linked to the others
+		ctx, span = tracing.Start(tracing.ContextWithRemote(ctx, req.msgs[0].Traceparent), "job.Comet.call", tracing.KindInternal)
+		for _, m := range req.msgs[1:] {
+			span.AddLink(m.Traceparent)
+		}
+		span.SetAttr("msgs", len(req.msgs))
+	}
+	var err error
+	switch {
+	case req.broadcast != nil:
+		if _, err = c.client.Broadcast(ctx, req.broadcast); err != nil {
+			log.Errorf("c.client.Broadcast(%s, reply) serverId:%s error(%v)", req.broadcast, c.serverID, err)
+		}
+	case req.room != nil:
+		if _, err = c.client.BroadcastRoom(ctx, req.room); err != nil {
+			log.Errorf("c.client.BroadcastRoom(%s, reply) serverId:%s error(%v)", req.room, c.serverID, err)
+		}
+	case req.push != nil:
+		if _, err = c.client.PushMsg(ctx, req.push); err != nil {
+			log.Errorf("c.client.PushMsg(%s, reply) serverId:%s error(%v)", req.push, c.serverID, err)
+		}
+	case req.kick != nil:
+		if _, err = c.client.Kick(ctx, req.kick); err != nil {
+			log.Errorf("c.client.Kick(%s, reply) serverId:%s error(%v)", req.kick, c.serverID, err)
+		}
+	case req.migrate != nil:
+		if _, err = c.client.Migrate(ctx, req.migrate); err != nil {
+			log.Errorf("c.client.Migrate(%s, reply) serverId:%s error(%v)", req.migrate, c.serverID, err)
+		}
+	case req.trace != nil:
+		if _, err = c.client.Trace(ctx, req.trace); err != nil {
+			log.Errorf("c.client.Trace(%s, reply) serverId:%s error(%v)", req.trace, c.serverID, err)
+		}
+	}
+	span.SetError(err)
+	span.End()
+	c.breaker.done(err == nil)
+	if err != nil {
+		c.retryLater(req, err)
+		return
+	}
+	req.done()
+}
+
+// CometStats the health of a comet.
+type CometStats struct {
+	Breaker       string `json:"breaker"`
+	Push          int    `json:"push"`
+	Room          int    `json:"room"`
+	Broadcast     int    `json:"broadcast"`
+	Kick          int    `json:"kick"`
+	BulkPush      int    `json:"bulk_push"`
+	BulkRoom      int    `json:"bulk_room"`
+	BulkBroadcast int    `json:"bulk_broadcast"`
+	Retry         int    `json:"retry"`
+}
+
+// Stats get the breaker state and the queue depths.
+func (c *Comet) Stats() *CometStats {
+	s := &CometStats{
+		Breaker: c.breaker.State(),
+		Kick:    len(c.kickChan),
+	}
+	s.Push, s.Room, s.Broadcast = c.realtime.queued()
+	s.BulkPush, s.BulkRoom, s.BulkBroadcast = c.bulk.queued()
+	c.retryMutex.Lock()
+	s.Retry = len(c.retries)
+	c.retryMutex.Unlock()
+	return s
+}
+
 // Close close the resources.
 func (c *Comet) Close() (err error) {
 	finish := make(chan bool)
 	go func() {
 		for {
-			n := len(c.broadcastChan)
-			for _, ch := range c.pushChan {
-				n += len(ch)
-			}
-			for _, ch := range c.roomChan {
-				n += len(ch)
-			}
-			if n == 0 {
+			push, room, broadcast := c.realtime.queued()
+			bulkPush, bulkRoom, bulkBroadcast := c.bulk.queued()
+			if push+room+broadcast+bulkPush+bulkRoom+bulkBroadcast+len(c.kickChan) == 0 {
 				finish <- true
 				return
 			}
@@ -181,7 +399,8 @@ func (c *Comet) Close() (err error) {
 	case <-finish:
 		log.Info("close comet finish")
 	case <-time.After(5 * time.Second):
-		err = fmt.Errorf("close comet(server:%s push:%d room:%d broadcast:%d) timeout", c.serverID, len(c.pushChan), len(c.roomChan), len(c.broadcastChan))
+		s := c.Stats()
+		err = fmt.Errorf("close comet(server:%s push:%d room:%d broadcast:%d bulk:%d/%d/%d) timeout", c.serverID, s.Push, s.Room, s.Broadcast, s.BulkPush, s.BulkRoom, s.BulkBroadcast)
 	}
 	c.cancel()
 	return
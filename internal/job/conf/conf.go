@@ -13,6 +13,7 @@ import (
 	"github.com/bilibili/discovery/naming"
 	xcommon "github.com/wcaqrl/chime/pkg/common"
 	xtime "github.com/wcaqrl/chime/pkg/time"
+	"github.com/wcaqrl/chime/pkg/tracing"
 )
 
 var (
@@ -68,19 +69,30 @@ func Default() *Config {
 		Debug:     debug,
 		Logger:    &xcommon.Logger{Level: "info", Path: "./logs", Save: 7},
 		Env:       &Env{Region: region, Zone: zone, DeployEnv: deployEnv, Host: host},
-		Kafka:     &Kafka{Topic: "chime-push-topic", Group: "chime-push-group-job", Brokers: []string{}},
+		Kafka:     &Kafka{Topic: "chime-push-topic", BulkTopic: "chime-push-bulk-topic", Group: "chime-push-group-job", BulkGroup: "chime-push-group-job-bulk", Brokers: []string{}},
+		Bus:       &Bus{Type: "kafka", Block: xtime.Duration(time.Second), Claim: xtime.Duration(time.Minute), Buffer: 1024},
+		Redis:     &Redis{Network: "tcp", Addr: ":6379", Active: 64, Idle: 16, IdleTimeout: xtime.Duration(80 * time.Second)},
 		Discovery: &naming.Config{Region: region, Zone: zone, Env: deployEnv, Host: host},
-		Comet:     &Comet{RoutineChan: 1024, RoutineSize: 32},
+		Comet:     &Comet{RoutineChan: 1024, RoutineSize: 32, BulkRoutineChan: 1024, BulkRoutineSize: 8, Timeout: xtime.Duration(5 * time.Second), Overflow: OverflowDeadLetter},
+		Breaker:   &Breaker{Failures: 5, OpenTimeout: xtime.Duration(10 * time.Second), HalfOpen: 1},
+		Consumer:  &Consumer{Inflight: 4096},
+		Retry:     &Retry{Max: 5, Backoff: xtime.Duration(200 * time.Millisecond), MaxBackoff: xtime.Duration(10 * time.Second)},
+		DLQ:       &DLQ{Topic: "chime-push-dlq-topic", Group: "chime-push-group-redrive"},
+		Delay:     &Delay{Key: "chime_push_delay", Poll: xtime.Duration(time.Second), Batch: 256, Lease: xtime.Duration(time.Minute)},
 		Room: &Room{
 			Batch:  20,
 			Signal: xtime.Duration(time.Second),
 			Idle:   xtime.Duration(time.Minute * 15),
 		},
+		HTTPServer: &HTTPServer{Network: "tcp", Addr: ":3121"},
+		Metrics:    &Metrics{Addr: ":3128"},
+		Tracing:    &tracing.Config{Endpoint: "http://127.0.0.1:4318/v1/traces", Ratio: 1},
 	}
 }
 
 func initConfig() {
 	var (
+		err    error
 		tmpStr string
 		conf   *ini.IniFileConfigSource
 	)
@@ -96,11 +108,88 @@ func initConfig() {
 	}
 	// kafka
 	Conf.Kafka.Topic = conf.GetDefault("kafka.topic", "chime-push-topic")
+	Conf.Kafka.BulkTopic = conf.GetDefault("kafka.bulk_topic", "chime-push-bulk-topic")
 	Conf.Kafka.Group = conf.GetDefault("kafka.group", "chime-push-group-job")
+	Conf.Kafka.BulkGroup = conf.GetDefault("kafka.bulk_group", "chime-push-group-job-bulk")
+	Conf.Kafka.Version = conf.GetDefault("kafka.version", "")
 	tmpStr = conf.GetDefault("kafka.brokers", "")
 	if tmpStr != "" {
 		Conf.Kafka.Brokers = strings.Split(tmpStr, ",")
 	}
+	// bus
+	Conf.Bus.Type = conf.GetDefault("bus.type", "kafka")
+	Conf.Bus.NatsURL = conf.GetDefault("bus.nats_url", "nats://127.0.0.1:4222")
+	tmpStr = conf.GetDefault("bus.block", "1s")
+	if Conf.Bus.Block, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Bus.Block = xtime.Duration(time.Second)
+	}
+	tmpStr = conf.GetDefault("bus.claim", "1m")
+	if Conf.Bus.Claim, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Bus.Claim = xtime.Duration(time.Minute)
+	}
+	Conf.Bus.Buffer = conf.GetIntDefault("bus.buffer", 1024)
+	// comet
+	tmpStr = conf.GetDefault("comet.timeout", "5s")
+	if Conf.Comet.Timeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Comet.Timeout = xtime.Duration(5 * time.Second)
+	}
+	Conf.Comet.Overflow = conf.GetDefault("comet.overflow", OverflowDeadLetter)
+	Conf.Comet.BulkRoutineSize = conf.GetIntDefault("comet.bulk_routine_size", 8)
+	Conf.Comet.BulkRoutineChan = conf.GetIntDefault("comet.bulk_routine_chan", 1024)
+	// breaker
+	Conf.Breaker.Failures = conf.GetIntDefault("breaker.failures", 5)
+	tmpStr = conf.GetDefault("breaker.open_timeout", "10s")
+	if Conf.Breaker.OpenTimeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Breaker.OpenTimeout = xtime.Duration(10 * time.Second)
+	}
+	Conf.Breaker.HalfOpen = conf.GetIntDefault("breaker.half_open", 1)
+	// consumer
+	Conf.Consumer.Inflight = conf.GetIntDefault("consumer.inflight", 4096)
+	// retry
+	Conf.Retry.Max = conf.GetIntDefault("retry.max", 5)
+	tmpStr = conf.GetDefault("retry.backoff", "200ms")
+	if Conf.Retry.Backoff, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Retry.Backoff = xtime.Duration(200 * time.Millisecond)
+	}
+	tmpStr = conf.GetDefault("retry.max_backoff", "10s")
+	if Conf.Retry.MaxBackoff, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Retry.MaxBackoff = xtime.Duration(10 * time.Second)
+	}
+	// dlq
+	Conf.DLQ.Topic = conf.GetDefault("dlq.topic", "chime-push-dlq-topic")
+	Conf.DLQ.Group = conf.GetDefault("dlq.group", "chime-push-group-redrive")
+	// delay
+	Conf.Delay.Key = conf.GetDefault("delay.key", "chime_push_delay")
+	tmpStr = conf.GetDefault("delay.poll", "1s")
+	if Conf.Delay.Poll, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Delay.Poll = xtime.Duration(time.Second)
+	}
+	Conf.Delay.Batch = conf.GetIntDefault("delay.batch", 256)
+	tmpStr = conf.GetDefault("delay.lease", "1m")
+	if Conf.Delay.Lease, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Delay.Lease = xtime.Duration(time.Minute)
+	}
+	// http server
+	Conf.HTTPServer.Network = conf.GetDefault("http_server.network", "tcp")
+	Conf.HTTPServer.Addr = conf.GetDefault("http_server.addr", ":3121")
+	Conf.HTTPServer.Token = conf.GetDefault("http_server.token", "")
+	// metrics
+	Conf.Metrics.Addr = conf.GetDefault("metrics.addr", ":3128")
+	// tracing
+	Conf.Tracing.Exporter = conf.GetDefault("tracing.exporter", "")
+	Conf.Tracing.Endpoint = conf.GetDefault("tracing.endpoint", "http://127.0.0.1:4318/v1/traces")
+	Conf.Tracing.Ratio = conf.GetFloat64Default("tracing.ratio", 1)
+	// redis, used by the redis streams bus and the delay queue
+	Conf.Redis.Network = conf.GetDefault("redis.network", "tcp")
+	Conf.Redis.Addr = conf.GetDefault("redis.addr", ":6379")
+	Conf.Redis.Auth = conf.GetDefault("redis.auth", "")
+	Conf.Redis.Db = conf.GetIntDefault("redis.db", 0)
+	Conf.Redis.Active = conf.GetIntDefault("redis.active", 64)
+	Conf.Redis.Idle = conf.GetIntDefault("redis.idle", 16)
+	tmpStr = conf.GetDefault("redis.idle_timeout", "80s")
+	if Conf.Redis.IdleTimeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Redis.IdleTimeout = xtime.Duration(80 * time.Second)
+	}
 }
 
 func usage() {
@@ -126,9 +215,60 @@ type Config struct {
 	Logger    *xcommon.Logger
 	Env       *Env
 	Kafka     *Kafka
+	Bus       *Bus
+	Redis     *Redis
 	Discovery *naming.Config
 	Comet     *Comet
+	Breaker   *Breaker
+	Consumer  *Consumer
+	Retry     *Retry
+	DLQ       *DLQ
+	Delay     *Delay
 	Room      *Room
+
+	HTTPServer *HTTPServer
+	Metrics    *Metrics
+	Tracing    *tracing.Config
+}
+
+// Retry is comet call retry config.
+type Retry struct {
+	Max        int // attempts before dead lettered
+	Backoff    xtime.Duration
+	MaxBackoff xtime.Duration
+}
+
+// DLQ is dead letter config.
+type DLQ struct {
+	Topic string
+	Group string // the redrive consumer group
+}
+
+// Delay is delay queue config, the pushes are held in a redis sorted set
+// until their not before.
+type Delay struct {
+	Key   string
+	Poll  xtime.Duration
+	Batch int            // max pushes claimed per poll
+	Lease xtime.Duration // a claimed push not done within is claimed again
+}
+
+// Metrics is metrics listener config, disabled when the addr is empty.
+type Metrics struct {
+	Addr string
+}
+
+// HTTPServer is admin http server config, disabled when the addr or the token
+// is empty. An addr without host binds the internal ip.
+type HTTPServer struct {
+	Network string
+	Addr    string
+	Token   string
+}
+
+// Consumer is consumer config.
+type Consumer struct {
+	Inflight int // max messages consumed but not done
 }
 
 // Room is room config.
@@ -138,17 +278,62 @@ type Room struct {
 	Idle   xtime.Duration
 }
 
+// the policies of a full comet queue.
+const (
+	// OverflowBlock wait for the queue, the consumer blocks.
+	OverflowBlock = "block"
+	// OverflowDrop drop the request.
+	OverflowDrop = "drop"
+	// OverflowDeadLetter write the request to the dead letter topic.
+	OverflowDeadLetter = "deadletter"
+)
+
 // Comet is comet config.
 type Comet struct {
 	RoutineChan int
 	RoutineSize int
+	// the workers of the bulk pushes, the bulk ones are realtime if none
+	BulkRoutineChan int
+	BulkRoutineSize int
+	Timeout         xtime.Duration // grpc call timeout
+	Overflow        string         // block, drop or deadletter
+}
+
+// Breaker is comet circuit breaker config.
+type Breaker struct {
+	Failures    int            // consecutive failures opening the breaker
+	OpenTimeout xtime.Duration // open duration before probing
+	HalfOpen    int            // successful probes closing the breaker
 }
 
 // Kafka is kafka config.
 type Kafka struct {
-	Topic   string
-	Group   string
-	Brokers []string
+	Topic     string
+	BulkTopic string // consumed apart from the topic, none if empty
+	Group     string
+	BulkGroup string
+	Brokers   []string
+	Version   string // the bus headers need 0.11+
+}
+
+// Bus is message bus config, the kafka brokers, topic and group are taken from Kafka.
+type Bus struct {
+	Type    string // kafka, nats, redis or memory
+	NatsURL string
+	Block   xtime.Duration // redis streams read block
+	Claim   xtime.Duration // redis streams min idle of the entries claimed from a dead consumer
+	Buffer  int            // nats subscription and memory topic buffer
+}
+
+// Redis is redis config.
+type Redis struct {
+	Network     string
+	Addr        string
+	Auth        string
+	Db          int
+	Active      int
+	Idle        int
+	IdleTimeout xtime.Duration
 }
 
 // Env is env config.
@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"github.com/gomodule/redigo/redis"
+	"github.com/wcaqrl/chime/internal/job/conf"
+)
+
+// Dao dao.
+type Dao struct {
+	c     *conf.Config
+	redis *redis.Pool
+}
+
+// New new a dao on the redis pool of job.
+func New(c *conf.Config, pool *redis.Pool) *Dao {
+	return &Dao{
+		c:     c,
+		redis: pool,
+	}
+}
+
+// Close close the resource.
+func (d *Dao) Close() error {
+	return d.redis.Close()
+}
@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+	pb "github.com/wcaqrl/chime/api/logic"
+)
+
+var (
+	// claim the due members, their score moves to the end of the lease.
+	_claimDelayScript = redis.NewScript(1, `
+local members = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, member in ipairs(members) do
+	redis.call('ZADD', KEYS[1], ARGV[3], member)
+end
+return members
+`)
+)
+
+// Delayed a push claimed from the delay queue.
+type Delayed struct {
+	Msg    *pb.PushMsg
+	member []byte
+}
+
+func unixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// AddDelayed hold a push in the delay queue until its not before.
+func (d *Dao) AddDelayed(c context.Context, msg *pb.PushMsg) (err error) {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return
+	}
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = conn.Do("ZADD", d.c.Delay.Key, msg.NotBefore*1000, b); err != nil {
+		log.Errorf("conn.Do(ZADD %s,%s) error(%v)", d.c.Delay.Key, msg.Id, err)
+	}
+	return
+}
+
+// ClaimDelayed claim at most limit pushes due at now for the lease, the ones
+// not done within the lease are claimed again.
+func (d *Dao) ClaimDelayed(c context.Context, now time.Time, limit int, lease time.Duration) (res []*Delayed, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	members, err := redis.ByteSlices(_claimDelayScript.Do(conn, d.c.Delay.Key, unixMilli(now), limit, unixMilli(now.Add(lease))))
+	if err != nil {
+		log.Errorf("claimDelayScript.Do(%s) error(%v)", d.c.Delay.Key, err)
+		return
+	}
+	for _, member := range members {
+		msg := new(pb.PushMsg)
+		if err := proto.Unmarshal(member, msg); err != nil {
+			log.Errorf("proto.Unmarshal(%s) error(%v)", d.c.Delay.Key, err)
+			_ = d.DoneDelayed(c, &Delayed{member: member})
+			continue
+		}
+		res = append(res, &Delayed{Msg: msg, member: member})
+	}
+	return
+}
+
+// DoneDelayed remove a push claimed from the delay queue.
+func (d *Dao) DoneDelayed(c context.Context, dm *Delayed) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = conn.Do("ZREM", d.c.Delay.Key, dm.member); err != nil {
+		log.Errorf("conn.Do(ZREM %s) error(%v)", d.c.Delay.Key, err)
+	}
+	return
+}
+
+// DelayedCount get the pushes held in the delay queue.
+func (d *Dao) DelayedCount(c context.Context) (n int64, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if n, err = redis.Int64(conn.Do("ZCARD", d.c.Delay.Key)); err != nil {
+		log.Errorf("conn.Do(ZCARD %s) error(%v)", d.c.Delay.Key, err)
+	}
+	return
+}
@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/job/conf"
+)
+
+// testDao new a dao on the redis of CHIME_TEST_REDIS with its own delay key,
+// the test is skipped if it is not reachable.
+func testDao(t *testing.T) *Dao {
+	addr := os.Getenv("CHIME_TEST_REDIS")
+	if addr == "" {
+		addr = "127.0.0.1:6379"
+	}
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr, redis.DialConnectTimeout(200*time.Millisecond))
+		},
+	}
+	conn := pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("PING"); err != nil {
+		pool.Close()
+		t.Skipf("redis %s not available: %v", addr, err)
+	}
+	key := fmt.Sprintf("test_delay_%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		conn := pool.Get()
+		_, _ = conn.Do("DEL", key)
+		conn.Close()
+		pool.Close()
+	})
+	return New(&conf.Config{Delay: &conf.Delay{Key: key}}, pool)
+}
+
+func TestClaimDelayed(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	type claim struct {
+		at    time.Duration // claim time from now
+		limit int
+		want  []string
+		done  bool // mark the claimed pushes done
+	}
+	tests := []struct {
+		name   string
+		msgs   map[string]time.Duration // id -> not before from now
+		claims []claim
+	}{
+		{
+			name: "not due",
+			msgs: map[string]time.Duration{"a": time.Minute},
+			claims: []claim{
+				{at: 0, limit: 10, want: nil},
+			},
+		},
+		{
+			name: "due ones only",
+			msgs: map[string]time.Duration{"a": -time.Second, "b": 0, "c": time.Minute},
+			claims: []claim{
+				{at: 0, limit: 10, want: []string{"a", "b"}},
+			},
+		},
+		{
+			name: "limit",
+			msgs: map[string]time.Duration{"a": -3 * time.Second, "b": -2 * time.Second, "c": -time.Second},
+			claims: []claim{
+				{at: 0, limit: 2, want: []string{"a", "b"}},
+				{at: 0, limit: 2, want: []string{"c"}},
+			},
+		},
+		{
+			name: "leased until it expires",
+			msgs: map[string]time.Duration{"a": 0},
+			claims: []claim{
+				{at: 0, limit: 10, want: []string{"a"}},
+				{at: 30 * time.Second, limit: 10, want: nil},
+				{at: 2 * time.Minute, limit: 10, want: []string{"a"}},
+			},
+		},
+		{
+			name: "done is not claimed again",
+			msgs: map[string]time.Duration{"a": 0},
+			claims: []claim{
+				{at: 0, limit: 10, want: []string{"a"}, done: true},
+				{at: 2 * time.Minute, limit: 10, want: nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testDao(t)
+			ctx := context.Background()
+			for id, nb := range tt.msgs {
+				if err := d.AddDelayed(ctx, &pb.PushMsg{Id: id, NotBefore: now.Add(nb).Unix()}); err != nil {
+					t.Fatalf("AddDelayed(%s) error(%v)", id, err)
+				}
+			}
+			for i, c := range tt.claims {
+				res, err := d.ClaimDelayed(ctx, now.Add(c.at), c.limit, time.Minute)
+				if err != nil {
+					t.Fatalf("claim %d ClaimDelayed() error(%v)", i, err)
+				}
+				var got []string
+				for _, dm := range res {
+					got = append(got, dm.Msg.Id)
+					if c.done {
+						if err = d.DoneDelayed(ctx, dm); err != nil {
+							t.Fatalf("claim %d DoneDelayed(%s) error(%v)", i, dm.Msg.Id, err)
+						}
+					}
+				}
+				sort.Strings(got)
+				if !reflect.DeepEqual(got, c.want) {
+					t.Fatalf("claim %d got %v, want %v", i, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+)
+
+// the mapping written by logic, the job redis must be the logic one.
+const (
+	_prefixMidServer = "mid_%d" // mid -> key:server
+	_prefixKeyServer = "key_%s" // key -> server
+)
+
+func keyMidServer(mid int64) string {
+	return fmt.Sprintf(_prefixMidServer, mid)
+}
+
+func keyKeyServer(key string) string {
+	return fmt.Sprintf(_prefixKeyServer, key)
+}
+
+// ServersByKeys get the server of every key, empty if offline.
+func (d *Dao) ServersByKeys(c context.Context, keys []string) (res []string, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	var args []interface{}
+	for _, key := range keys {
+		args = append(args, keyKeyServer(key))
+	}
+	if res, err = redis.Strings(conn.Do("MGET", args...)); err != nil {
+		log.Errorf("conn.Do(MGET %v) error(%v)", args, err)
+	}
+	return
+}
+
+// KeysByMids get the server of every key of the mids.
+func (d *Dao) KeysByMids(c context.Context, mids []int64) (res map[string]string, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	for _, mid := range mids {
+		if err = conn.Send("HGETALL", keyMidServer(mid)); err != nil {
+			log.Errorf("conn.Send(HGETALL %d) error(%v)", mid, err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	res = make(map[string]string)
+	for range mids {
+		var keys map[string]string
+		if keys, err = redis.StringMap(conn.Receive()); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+		for key, server := range keys {
+			res[key] = server
+		}
+	}
+	return
+}
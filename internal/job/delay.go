@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/bus"
+	"github.com/wcaqrl/chime/internal/job/dao"
+	"github.com/wcaqrl/chime/pkg/tracing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// expired check the push expired, it is discarded rather than pushed.
+func expired(pushMsg *pb.PushMsg) bool {
+	if pushMsg.ExpireAt > 0 && time.Now().Unix() >= pushMsg.ExpireAt {
+		pushExpired.Inc()
+		return true
+	}
+	return false
+}
+
+// dispatch push a msg unless expired, a msg not due yet is held in the delay
+// queue.
+func (j *Job) dispatch(ctx context.Context, pushMsg *pb.PushMsg, d *delivery) (err error) {
+	if expired(pushMsg) {
+		log.Infof("push expired: %+v", pushMsg)
+		return
+	}
+	if pushMsg.NotBefore > time.Now().Unix() {
+		return j.delay(ctx, pushMsg)
+	}
+	return j.push(ctx, pushMsg, d)
+}
+
+// delay hold a push in the delay queue until its not before, it is dead
+// lettered if the queue failed to hold it.
+func (j *Job) delay(ctx context.Context, pushMsg *pb.PushMsg) (err error) {
+	if err = j.dao.AddDelayed(ctx, pushMsg); err != nil {
+		j.deadLetter(pushMsg.Server, []*pb.PushMsg{pushMsg}, 0, err.Error())
+		return
+	}
+	pushDelayed.Inc()
+	return
+}
+
+// Delayed get the pushes held in the delay queue.
+func (j *Job) Delayed(c context.Context) (int64, error) {
+	return j.dao.DelayedCount(c)
+}
+
+// delayproc push the delayed pushes once due. A claimed push is removed from
+// the queue once its comet calls returned, so a crash pushes it again after
+// the lease.
+func (j *Job) delayproc() {
+	poll := time.Duration(j.c.Delay.Poll)
+	if poll <= 0 {
+		poll = time.Second
+	}
+	for {
+		select {
+		case <-j.closed:
+			return
+		case <-time.After(poll):
+		}
+		for {
+			dms, err := j.dao.ClaimDelayed(context.Background(), time.Now(), j.c.Delay.Batch, time.Duration(j.c.Delay.Lease))
+			if err != nil {
+				break
+			}
+			for _, dm := range dms {
+				j.pushDelayed(dm)
+			}
+			if len(dms) < j.c.Delay.Batch {
+				break
+			}
+		}
+	}
+}
+
+func (j *Job) pushDelayed(dm *dao.Delayed) {
+	pushMsg := dm.Msg
+	d := newDelivery(bus.NewMessage(j.c.Delay.Key, pushMsg.Id, nil, func() {
+		_ = j.dao.DoneDelayed(context.Background(), dm)
+	}))
+	ctx, span := tracing.Start(tracing.ContextWithRemote(context.Background(), pushMsg.Traceparent), "job.push.delayed", tracing.KindInternal)
+	if span != nil {
+		span.SetAttr("type", pushMsg.Type.String())
+		pushMsg.Traceparent = span.Context().Traceparent()
+	}
+	err := j.dispatch(ctx, pushMsg, d)
+	if err != nil {
+		log.Errorf("j.dispatch(%v) error(%v)", pushMsg, err)
+	}
+	span.SetError(err)
+	span.End()
+	d.done()
+	log.Infof("push delayed: %s\t%+v", pushMsg.Id, pushMsg)
+}
@@ -0,0 +1,152 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/bus"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrDLQDisabled the dead letter topic is not configured.
+	ErrDLQDisabled = errors.New("dead letter topic disabled")
+	// ErrRedriving a redrive is already running.
+	ErrRedriving = errors.New("redrive is running")
+)
+
+// deadLetter write the msgs failed on a comet to the dead letter topic, keyed
+// by the comet so a redrive targets it only. Without the topic the msgs are
+// dropped.
+func (j *Job) deadLetter(server string, msgs []*pb.PushMsg, attempts int, reason string) {
+	if j.dlq == nil {
+		deadLetterDrops.Add(float64(len(msgs)))
+		log.Errorf("dead letter disabled, drop %d msgs server:%s attempts:%d reason:%s", len(msgs), server, attempts, reason)
+		return
+	}
+	now := time.Now().Unix()
+	for _, msg := range msgs {
+		dlm := &pb.DeadLetterMsg{
+			Msg:       msg,
+			Server:    server,
+			Reason:    reason,
+			Attempts:  int32(attempts),
+			Timestamp: now,
+		}
+		b, err := proto.Marshal(dlm)
+		if err != nil {
+			log.Errorf("proto.Marshal(%v) error(%v)", dlm, err)
+			continue
+		}
+		if err = j.dlq.Publish(context.Background(), &bus.Message{Topic: j.c.DLQ.Topic, Key: server, Value: b}); err != nil {
+			log.Errorf("j.dlq.Publish(%s) server:%s error(%v)", j.c.DLQ.Topic, server, err)
+		}
+	}
+}
+
+// Redrive push at most limit dead lettered msgs to their comet again, it stops
+// once no msg arrived for idle and returns after every redriven msg completed.
+// The msgs failing again are dead lettered again.
+func (j *Job) Redrive(limit int, idle time.Duration) (n int, err error) {
+	if j.dlqSub == nil {
+		return 0, ErrDLQDisabled
+	}
+	if !atomic.CompareAndSwapInt32(&j.redriving, 0, 1) {
+		return 0, ErrRedriving
+	}
+	defer atomic.StoreInt32(&j.redriving, 0)
+	o := newOffsets(j.c.Consumer.Inflight)
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for n < limit {
+		select {
+		case msg, ok := <-j.dlqSub.Messages():
+			if !ok {
+				limit = n
+				break
+			}
+			d := o.begin(msg)
+			j.redrive(msg, d)
+			d.done()
+			n++
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(idle)
+		case <-timer.C:
+			limit = n
+		}
+	}
+	// every slot of the in-flight messages is free once they all completed
+	for i := 0; i < cap(o.inflight); i++ {
+		o.inflight <- struct{}{}
+	}
+	log.Infof("redrive dead letters:%d", n)
+	return
+}
+
+// redrive push a dead lettered msg to the comet it failed on.
+func (j *Job) redrive(msg *bus.Message, d *delivery) {
+	dlm := new(pb.DeadLetterMsg)
+	if err := proto.Unmarshal(msg.Value, dlm); err != nil || dlm.Msg == nil {
+		log.Errorf("proto.Unmarshal(%v) error(%v)", msg, err)
+		return
+	}
+	if expired(dlm.Msg) {
+		log.Infof("redrive skip expired: %+v", dlm.Msg)
+		return
+	}
+	var (
+		err     error
+		pushMsg = dlm.Msg
+		msgs    = []*pb.PushMsg{pushMsg}
+	)
+	if dlm.Server == "" && pushMsg.Type == pb.PushMsg_PUSH {
+		// a delayed push failed to be held or resolved, dispatch it again
+		if err = j.dispatch(context.Background(), pushMsg, d); err != nil {
+			log.Errorf("redrive(%v) error(%v)", pushMsg, err)
+		}
+		return
+	}
+	c, ok := j.cometServers[dlm.Server]
+	if !ok {
+		j.deadLetter(dlm.Server, msgs, int(dlm.Attempts), ErrComet.Error())
+		return
+	}
+	d.add()
+	switch pushMsg.Type {
+	case pb.PushMsg_PUSH:
+		err = c.Push(pushKeysReq(pushMsg), msg.Shard(), msgs, d.done)
+	case pb.PushMsg_ROOM:
+		// the msg keeps its seq, the room does not move back to it
+		p := rawProto(pushMsg.Operation, pushMsg.Seq, pushMsg.Msg, pushMsg.ExpireAt)
+		err = c.BroadcastRoom(broadcastRoomReq(pushMsg.Room, 0, p.Body, msgs), msgs, d.done)
+	case pb.PushMsg_BROADCAST:
+		err = c.Broadcast(broadcastReq(pushMsg, len(j.cometServers)), msgs, d.done)
+	case pb.PushMsg_KICK:
+		err = c.Kick(kickReq(pushMsg), msgs, d.done)
+	case pb.PushMsg_MIGRATE:
+		// the load it was computed from is stale
+		d.done()
+		log.Infof("redrive skip migrate: %+v", pushMsg)
+		return
+	case pb.PushMsg_TRACE:
+		// the trace window has passed
+		d.done()
+		log.Infof("redrive skip trace: %+v", pushMsg)
+		return
+	default:
+		d.done()
+		log.Errorf("redrive no match push type: %s", pushMsg.Type)
+		return
+	}
+	if err != nil {
+		log.Errorf("redrive(%v) serverID:%s error(%v)", pushMsg, dlm.Server, err)
+	}
+	log.Infof("redrive: %s/%d/%d\t%s\t%+v", msg.Topic, msg.Partition, msg.Offset, dlm.Server, pushMsg)
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/pkg/admin"
+)
+
+func (s *Server) comets(c *gin.Context) {
+	admin.Result(c, s.job.CometStats(), admin.OK)
+}
+
+func (s *Server) delayed(c *gin.Context) {
+	n, err := s.job.Delayed(c.Request.Context())
+	if err != nil {
+		admin.Errors(c, admin.ServerErr, err.Error())
+		return
+	}
+	admin.Result(c, map[string]int64{"delayed": n}, admin.OK)
+}
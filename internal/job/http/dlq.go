@@ -0,0 +1,37 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/pkg/admin"
+)
+
+const (
+	_defaultRedriveIdle = 3 * time.Second
+)
+
+func (s *Server) redrive(c *gin.Context) {
+	var arg struct {
+		Limit int    `form:"limit" binding:"required,min=1"`
+		Idle  string `form:"idle"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		admin.Errors(c, admin.RequestErr, err.Error())
+		return
+	}
+	idle := _defaultRedriveIdle
+	if arg.Idle != "" {
+		var err error
+		if idle, err = time.ParseDuration(arg.Idle); err != nil || idle <= 0 {
+			admin.Errors(c, admin.RequestErr, "invalid idle")
+			return
+		}
+	}
+	n, err := s.job.Redrive(arg.Limit, idle)
+	if err != nil {
+		admin.Errors(c, admin.ServerErr, err.Error())
+		return
+	}
+	admin.Result(c, map[string]int{"redriven": n}, admin.OK)
+}
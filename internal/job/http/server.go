@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/wcaqrl/chime/internal/job"
+	"github.com/wcaqrl/chime/internal/job/conf"
+	"github.com/wcaqrl/chime/pkg/admin"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const _shutdownTimeout = 5 * time.Second
+
+// Server is admin http server.
+type Server struct {
+	engine *gin.Engine
+	srv    *http.Server
+	job    *job.Job
+}
+
+// New new a http server, every route is guarded by the token.
+func New(c *conf.HTTPServer, j *job.Job) *Server {
+	engine := gin.New()
+	engine.Use(admin.Logger, admin.Recover, admin.Auth(c.Token))
+	s := &Server{
+		engine: engine,
+		srv:    &http.Server{Addr: admin.BindAddr(c.Addr), Handler: engine},
+		job:    j,
+	}
+	s.initRouter()
+	go func() {
+		log.Infof("start admin http listen: %s", s.srv.Addr)
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			panic(err)
+		}
+	}()
+	return s
+}
+
+func (s *Server) initRouter() {
+	group := s.engine.Group("/chime")
+	group.POST("/dlq/redrive", s.redrive)
+	group.GET("/comets", s.comets)
+	group.GET("/delayed", s.delayed)
+}
+
+// Close close the server, it waits the running requests a while.
+func (s *Server) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), _shutdownTimeout)
+	defer cancel()
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Errorf("admin http shutdown error(%v)", err)
+	}
+}
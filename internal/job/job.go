@@ -8,78 +8,186 @@ import (
 
 	"github.com/bilibili/discovery/naming"
 	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
 	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/bus"
 	"github.com/wcaqrl/chime/internal/job/conf"
+	"github.com/wcaqrl/chime/internal/job/dao"
+	"github.com/wcaqrl/chime/pkg/tracing"
 
-	cluster "github.com/bsm/sarama-cluster"
 	log "github.com/sirupsen/logrus"
 )
 
 // Job is push job.
 type Job struct {
 	c            *conf.Config
-	consumer     *cluster.Consumer
+	dao          *dao.Dao
+	consumer     bus.Subscriber
+	offsets      *offsets
 	cometServers map[string]*Comet
+	closed       chan struct{}
+
+	// bulk topic, nil when disabled
+	bulk        bus.Subscriber
+	bulkOffsets *offsets
 
 	rooms      map[string]*Room
 	roomsMutex sync.RWMutex
+	// dead letter, nil when disabled
+	dlq       bus.Publisher
+	dlqSub    bus.Subscriber
+	redriving int32
 }
 
 // New new a push job.
 func New(c *conf.Config) *Job {
+	pool := newRedis(c.Redis)
+	bc := newBusConfig(c, pool)
 	j := &Job{
 		c:        c,
-		consumer: newKafkaSub(c.Kafka),
+		dao:      dao.New(c, pool),
+		consumer: newBusSub(bc, c.Kafka.Group, c.Env.Host, c.Kafka.Topic),
+		offsets:  newOffsets(c.Consumer.Inflight),
+		closed:   make(chan struct{}),
 		rooms:    make(map[string]*Room),
 	}
+	if c.Kafka.BulkTopic != "" {
+		j.bulk = newBusSub(bc, c.Kafka.BulkGroup, c.Env.Host, c.Kafka.BulkTopic)
+		j.bulkOffsets = newOffsets(c.Consumer.Inflight)
+	}
+	if c.DLQ.Topic != "" {
+		j.dlq = newBusPub(bc)
+		j.dlqSub = newBusSub(bc, c.DLQ.Group, c.Env.Host, c.DLQ.Topic)
+	}
 	j.watchComet(c.Discovery)
+	go j.delayproc()
 	return j
 }
 
-func newKafkaSub(c *conf.Kafka) *cluster.Consumer {
-	config := cluster.NewConfig()
-	config.Consumer.Return.Errors = true
-	config.Group.Return.Notifications = true
-	consumer, err := cluster.NewConsumer(c.Brokers, c.Group, []string{c.Topic}, config)
+func newBusConfig(c *conf.Config, pool *redis.Pool) *bus.Config {
+	bc := &bus.Config{
+		Type:         c.Bus.Type,
+		Brokers:      c.Kafka.Brokers,
+		KafkaVersion: c.Kafka.Version,
+		NatsURL:      c.Bus.NatsURL,
+		Block:        time.Duration(c.Bus.Block),
+		Claim:        time.Duration(c.Bus.Claim),
+		Buffer:       c.Bus.Buffer,
+	}
+	if c.Bus.Type == bus.TypeRedis {
+		bc.Redis = pool
+	}
+	return bc
+}
+
+func newBusSub(bc *bus.Config, group, name, topic string) bus.Subscriber {
+	consumer, err := bus.NewSubscriber(bc, group, name, []string{topic})
 	if err != nil {
 		panic(err)
 	}
 	return consumer
 }
 
+func newBusPub(bc *bus.Config) bus.Publisher {
+	pub, err := bus.NewPublisher(bc)
+	if err != nil {
+		panic(err)
+	}
+	return pub
+}
+
+func newRedis(c *conf.Redis) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     c.Idle,
+		MaxActive:   c.Active,
+		IdleTimeout: time.Duration(c.IdleTimeout),
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial(c.Network, c.Addr,
+				redis.DialDatabase(c.Db),
+				redis.DialPassword(c.Auth),
+			)
+		},
+	}
+}
+
 // Close close resounces.
-func (j *Job) Close() error {
+func (j *Job) Close() (err error) {
+	close(j.closed)
+	if j.dlqSub != nil {
+		if err = j.dlqSub.Close(); err != nil {
+			log.Errorf("j.dlqSub.Close() error(%v)", err)
+		}
+	}
+	if j.dlq != nil {
+		if err = j.dlq.Close(); err != nil {
+			log.Errorf("j.dlq.Close() error(%v)", err)
+		}
+	}
+	if j.bulk != nil {
+		if err = j.bulk.Close(); err != nil {
+			log.Errorf("j.bulk.Close() error(%v)", err)
+		}
+	}
 	if j.consumer != nil {
-		return j.consumer.Close()
+		err = j.consumer.Close()
 	}
-	return nil
+	// the redis bus shares the pool
+	if e := j.dao.Close(); e != nil {
+		log.Errorf("j.dao.Close() error(%v)", e)
+	}
+	return
 }
 
-// Consume messages, watch signals
+// Consume messages, a message is marked done only after all its comet calls
+// returned, so a crash redelivers the in-flight ones. The bulk topic is
+// consumed apart, its backlog never holds the realtime messages.
 func (j *Job) Consume() {
-	for {
-		select {
-		case err := <-j.consumer.Errors():
-			log.Errorf("consumer error(%v)", err)
-		case n := <-j.consumer.Notifications():
-			log.Infof("consumer rebalanced(%v)", n)
-		case msg, ok := <-j.consumer.Messages():
-			if !ok {
-				return
-			}
-			j.consumer.MarkOffset(msg, "")
-			// process push message
-			pushMsg := new(pb.PushMsg)
-			if err := proto.Unmarshal(msg.Value, pushMsg); err != nil {
-				log.Errorf("proto.Unmarshal(%v) error(%v)", msg, err)
-				continue
-			}
-			if err := j.push(context.Background(), pushMsg); err != nil {
-				log.Errorf("j.push(%v) error(%v)", pushMsg, err)
-			}
-			log.Infof("consume: %s/%d/%d\t%s\t%+v", msg.Topic, msg.Partition, msg.Offset, msg.Key, pushMsg)
+	if j.bulk != nil {
+		go j.consume(j.bulk, j.bulkOffsets)
+	}
+	j.consume(j.consumer, j.offsets)
+}
+
+func (j *Job) consume(sub bus.Subscriber, o *offsets) {
+	for msg := range sub.Messages() {
+		bus.ObserveLag(msg)
+		d := o.begin(msg)
+		// process push message
+		pushMsg := new(pb.PushMsg)
+		if err := proto.Unmarshal(msg.Value, pushMsg); err != nil {
+			log.Errorf("proto.Unmarshal(%v) error(%v)", msg, err)
+			d.done()
+			continue
+		}
+		// the comet calls are traced as the children of the consume
+		ctx := bus.ContextWithTrace(context.Background(), msg)
+		if pushMsg.Traceparent != "" {
+			ctx = tracing.ContextWithRemote(ctx, pushMsg.Traceparent)
 		}
+		ctx, span := tracing.Start(ctx, "job.push", tracing.KindConsumer)
+		if span != nil {
+			span.SetAttr("type", pushMsg.Type.String())
+			pushMsg.Traceparent = span.Context().Traceparent()
+		}
+		err := j.dispatch(ctx, pushMsg, d)
+		if err != nil {
+			log.Errorf("j.dispatch(%v) error(%v)", pushMsg, err)
+		}
+		span.SetError(err)
+		span.End()
+		d.done()
+		log.Infof("consume: %s/%d/%d\t%s\t%+v", msg.Topic, msg.Partition, msg.Offset, msg.Key, pushMsg)
+	}
+}
+
+// CometStats get the stats of every comet.
+func (j *Job) CometStats() map[string]*CometStats {
+	comets := j.cometServers
+	stats := make(map[string]*CometStats, len(comets))
+	for serverID, c := range comets {
+		stats[serverID] = c.Stats()
 	}
+	return stats
 }
 
 func (j *Job) watchComet(c *naming.Config) {
@@ -129,7 +237,7 @@ func (j *Job) newAddress(insMap map[string][]*naming.Instance) error {
 			comets[in.Hostname] = old
 			continue
 		}
-		c, err := NewComet(in, j.c.Comet)
+		c, err := NewComet(in, j.c.Comet, j.c.Breaker, j.c.Retry, j.deadLetter)
 		if err != nil {
 			log.Errorf("watchComet NewComet(%+v) error(%v)", in, err)
 			return err
@@ -0,0 +1,38 @@
+package job
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wcaqrl/chime/pkg/metric"
+)
+
+const _subsystem = "job"
+
+var (
+	roomBatch = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "room_batch_size",
+		Help:      "Msgs merged in a room batch.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	})
+	pushExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "push_expired_total",
+		Help:      "Pushes discarded as expired before calling comet.",
+	})
+	pushDelayed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "push_delayed_total",
+		Help:      "Pushes held in the delay queue until their not before.",
+	})
+	deadLetterDrops = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metric.Namespace,
+		Subsystem: _subsystem,
+		Name:      "dead_letter_drops_total",
+		Help:      "Failed msgs dropped as the dead letter topic is disabled.",
+	})
+	cometDuration = metric.NewGRPCHistogram(_subsystem, "comet_call_duration_seconds", "Latencies of the calls to comet.")
+)
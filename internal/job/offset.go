@@ -0,0 +1,98 @@
+package job
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/wcaqrl/chime/internal/bus"
+)
+
+type partitionKey struct {
+	topic string
+	shard uint32
+}
+
+// offsets mark the consumed messages done in the order of their partition, or
+// of their key on the buses without partitions, once all their comet calls
+// returned, and bound the in-flight messages.
+type offsets struct {
+	inflight   chan struct{}
+	mutex      sync.Mutex
+	partitions map[partitionKey]*list.List
+}
+
+func newOffsets(inflight int) *offsets {
+	return &offsets{
+		inflight:   make(chan struct{}, inflight),
+		partitions: make(map[partitionKey]*list.List),
+	}
+}
+
+// delivery a consumed message waiting for its comet calls.
+type delivery struct {
+	o        *offsets
+	msg      *bus.Message
+	pending  int32
+	finished bool
+}
+
+// begin track a message, blocks while the in-flight messages are full.
+// The delivery holds itself until done is called once by the consumer.
+func (o *offsets) begin(msg *bus.Message) *delivery {
+	o.inflight <- struct{}{}
+	d := &delivery{o: o, msg: msg, pending: 1}
+	key := partitionKey{topic: msg.Topic, shard: msg.Shard()}
+	o.mutex.Lock()
+	l, ok := o.partitions[key]
+	if !ok {
+		l = list.New()
+		o.partitions[key] = l
+	}
+	l.PushBack(d)
+	o.mutex.Unlock()
+	return d
+}
+
+// newDelivery track a message not consumed from a partition, it is done on
+// its own regardless of the order.
+func newDelivery(msg *bus.Message) *delivery {
+	return &delivery{msg: msg, pending: 1}
+}
+
+// add wait for one more comet call.
+func (d *delivery) add() {
+	atomic.AddInt32(&d.pending, 1)
+}
+
+// done a comet call returned.
+func (d *delivery) done() {
+	if atomic.AddInt32(&d.pending, -1) == 0 {
+		if d.o == nil {
+			d.msg.Done()
+			return
+		}
+		d.o.finish(d)
+	}
+}
+
+// finish mark the leading finished messages of the partition done.
+func (o *offsets) finish(d *delivery) {
+	var dones []*delivery
+	key := partitionKey{topic: d.msg.Topic, shard: d.msg.Shard()}
+	o.mutex.Lock()
+	d.finished = true
+	l := o.partitions[key]
+	for e := l.Front(); e != nil && e.Value.(*delivery).finished; e = l.Front() {
+		dones = append(dones, l.Remove(e).(*delivery))
+	}
+	if l.Len() == 0 {
+		// the keys of the buses without partitions come and go
+		delete(o.partitions, key)
+	}
+	o.mutex.Unlock()
+	for _, d := range dones {
+		d.msg.Done()
+		<-o.inflight
+	}
+}
@@ -0,0 +1,105 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wcaqrl/chime/internal/bus"
+)
+
+func TestOffsetsOrder(t *testing.T) {
+	type msg struct {
+		key       string
+		partition int32
+	}
+	tests := []struct {
+		name   string
+		msgs   []msg
+		finish []int // indexes of msgs in the order their calls return
+		want   []int // indexes of msgs in the order they are marked done
+	}{
+		{
+			name:   "in order",
+			msgs:   []msg{{"a", 0}, {"a", 0}, {"a", 0}},
+			finish: []int{0, 1, 2},
+			want:   []int{0, 1, 2},
+		},
+		{
+			name:   "reversed waits for the first",
+			msgs:   []msg{{"a", 0}, {"a", 0}, {"a", 0}},
+			finish: []int{2, 1, 0},
+			want:   []int{0, 1, 2},
+		},
+		{
+			name:   "gap holds the later ones",
+			msgs:   []msg{{"a", 0}, {"a", 0}, {"a", 0}, {"a", 0}},
+			finish: []int{0, 2, 3, 1},
+			want:   []int{0, 1, 2, 3},
+		},
+		{
+			name:   "partitions are independent",
+			msgs:   []msg{{"a", 0}, {"b", 1}, {"a", 0}, {"b", 1}},
+			finish: []int{1, 3, 2, 0},
+			want:   []int{1, 3, 0, 2},
+		},
+		{
+			name:   "keys without partition are independent",
+			msgs:   []msg{{"a", bus.NoPartition}, {"b", bus.NoPartition}, {"a", bus.NoPartition}},
+			finish: []int{2, 1, 0},
+			want:   []int{1, 0, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			o := newOffsets(len(tt.msgs))
+			ds := make([]*delivery, len(tt.msgs))
+			for i, m := range tt.msgs {
+				i := i
+				bm := bus.NewMessage("topic", m.key, nil, func() { got = append(got, i) })
+				bm.Partition = m.partition
+				ds[i] = o.begin(bm)
+			}
+			for _, i := range tt.finish {
+				ds[i].done()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("done order %v, want %v", got, tt.want)
+			}
+			if n := len(o.inflight); n != 0 {
+				t.Fatalf("inflight %d after all done, want 0", n)
+			}
+			if n := len(o.partitions); n != 0 {
+				t.Fatalf("partitions %d after all done, want 0", n)
+			}
+		})
+	}
+}
+
+func TestDeliveryPending(t *testing.T) {
+	tests := []struct {
+		name  string
+		calls int // comet calls added to the delivery
+		dones int // done calls, the consumer one included
+		want  bool
+	}{
+		{"consumer only", 0, 1, true},
+		{"calls pending", 2, 2, false},
+		{"calls returned", 2, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var done bool
+			d := newDelivery(bus.NewMessage("topic", "key", nil, func() { done = true }))
+			for i := 0; i < tt.calls; i++ {
+				d.add()
+			}
+			for i := 0; i < tt.dones; i++ {
+				d.done()
+			}
+			if done != tt.want {
+				t.Fatalf("done %v, want %v", done, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package job
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/wcaqrl/chime/api/comet"
 	pb "github.com/wcaqrl/chime/api/logic"
 	"github.com/wcaqrl/chime/api/protocol"
@@ -11,65 +13,179 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func (j *Job) push(ctx context.Context, pushMsg *pb.PushMsg) (err error) {
+// push dispatch a message to the target comets, d waits for every comet call.
+func (j *Job) push(ctx context.Context, pushMsg *pb.PushMsg, d *delivery) (err error) {
 	switch pushMsg.Type {
 	case pb.PushMsg_PUSH:
-		err = j.pushKeys(pushMsg.Operation, pushMsg.Server, pushMsg.Keys, pushMsg.Msg)
+		if pushMsg.Server == "" {
+			err = j.pushResolved(ctx, pushMsg, d)
+		} else {
+			err = j.pushKeys(pushMsg, d)
+		}
 	case pb.PushMsg_ROOM:
-		err = j.getRoom(pushMsg.Room).Push(pushMsg.Operation, pushMsg.Msg)
+		d.add()
+		// an idle room exiting rejects the msg, retry on a new one
+		for err = errRoomClosed; err == errRoomClosed; {
+			err = j.getRoom(pushMsg.Room).Push(pushMsg, d.done)
+		}
+		if err == ErrRoomFull {
+			// broadcast it unmerged, every comet call retries or dead letters it
+			p := rawProto(pushMsg.Operation, pushMsg.Seq, pushMsg.Msg, pushMsg.ExpireAt)
+			err = j.broadcastRoomRawBytes(pushMsg.Room, pushMsg.Seq, p.Body, []*pb.PushMsg{pushMsg}, d.done)
+		} else if err != nil {
+			d.done()
+		}
 	case pb.PushMsg_BROADCAST:
-		err = j.broadcast(pushMsg.Operation, pushMsg.Msg, pushMsg.Speed)
+		err = j.broadcast(pushMsg, d)
+	case pb.PushMsg_KICK:
+		err = j.kick(pushMsg, d)
+	case pb.PushMsg_MIGRATE:
+		err = j.migrate(pushMsg, d)
+	case pb.PushMsg_TRACE:
+		err = j.trace(pushMsg, d)
 	default:
 		err = fmt.Errorf("no match push type: %s", pushMsg.Type)
 	}
 	return
 }
 
-// pushKeys push a message to a batch of subkeys.
-func (j *Job) pushKeys(operation int32, serverID string, subKeys []string, body []byte) (err error) {
+// rawProto wrap a message into a raw proto, the comets write it as is.
+func rawProto(operation, seq int32, body []byte, expireAt int64) *protocol.Proto {
 	buf := bytes.NewWriterSize(len(body) + 64)
 	p := &protocol.Proto{
 		Ver:  1,
 		Op:   operation,
+		Seq:  seq,
 		Body: body,
 	}
 	p.WriteTo(buf)
 	p.Body = buf.Buffer()
 	p.Op = protocol.OpRaw
-	var args = comet.PushMsgReq{
-		Keys:    subKeys,
-		ProtoOp: operation,
-		Proto:   p,
+	p.ExpireAt = expireAt
+	return p
+}
+
+func pushKeysReq(pushMsg *pb.PushMsg) *comet.PushMsgReq {
+	req := &comet.PushMsgReq{
+		Keys:    pushMsg.Keys,
+		ProtoOp: pushMsg.Operation,
+		Proto:   rawProto(pushMsg.Operation, pushMsg.Seq, pushMsg.Msg, pushMsg.ExpireAt),
+		Ack:     pushMsg.Ack,
+		Id:      pushMsg.Id,
+	}
+	req.Proto.Bulk = bulk(pushMsg)
+	return req
+}
+
+func broadcastReq(pushMsg *pb.PushMsg, comets int) *comet.BroadcastReq {
+	speed := pushMsg.Speed
+	if comets > 0 {
+		speed /= int32(comets)
+	}
+	req := &comet.BroadcastReq{
+		ProtoOp: pushMsg.Operation,
+		Proto:   rawProto(pushMsg.Operation, 0, pushMsg.Msg, pushMsg.ExpireAt),
+		Speed:   speed,
+		Id:      pushMsg.Id,
+	}
+	req.Proto.Bulk = bulk(pushMsg)
+	return req
+}
+
+// broadcastRoomReq the body merges the msgs, whose ids the comets report the
+// deliveries of. The batch expires with its last expiring msg, it is bulk if
+// all its msgs are.
+func broadcastRoomReq(roomID string, seq int32, body []byte, msgs []*pb.PushMsg) *comet.BroadcastRoomReq {
+	req := &comet.BroadcastRoomReq{
+		RoomID: roomID,
+		Proto: &protocol.Proto{
+			Ver:  1,
+			Op:   protocol.OpRaw,
+			Seq:  seq,
+			Body: body,
+		},
 	}
-	if c, ok := j.cometServers[serverID]; ok {
-		if err = c.Push(&args); err != nil {
-			log.Errorf("c.Push(%v) serverID:%s error(%v)", args, serverID, err)
+	for i, msg := range msgs {
+		if msg.Id != "" {
+			req.Ids = append(req.Ids, msg.Id)
 		}
-		log.Infof("pushKey:%s comets:%d", serverID, len(j.cometServers))
+		if i == 0 || (req.Proto.ExpireAt > 0 && (msg.ExpireAt == 0 || msg.ExpireAt > req.Proto.ExpireAt)) {
+			req.Proto.ExpireAt = msg.ExpireAt
+		}
+	}
+	req.Proto.Bulk = bulk(msgs...)
+	return req
+}
+
+func kickReq(pushMsg *pb.PushMsg) *comet.KickReq {
+	return &comet.KickReq{
+		Keys:   pushMsg.Keys,
+		RoomID: pushMsg.Room,
+		Reason: pushMsg.Reason,
+		Msg:    string(pushMsg.Msg),
+	}
+}
+
+// pushKeys push a message to a batch of subkeys.
+func (j *Job) pushKeys(pushMsg *pb.PushMsg, d *delivery) (err error) {
+	args := pushKeysReq(pushMsg)
+	if c, ok := j.cometServers[pushMsg.Server]; ok {
+		d.add()
+		if err = c.Push(args, d.msg.Shard(), []*pb.PushMsg{pushMsg}, d.done); err != nil {
+			log.Errorf("c.Push(%v) serverID:%s error(%v)", args, pushMsg.Server, err)
+		}
+		log.Infof("pushKey:%s comets:%d", pushMsg.Server, len(j.cometServers))
 	}
 	return
 }
 
-// broadcast broadcast a message to all.
-func (j *Job) broadcast(operation int32, body []byte, speed int32) (err error) {
-	buf := bytes.NewWriterSize(len(body) + 64)
-	p := &protocol.Proto{
-		Ver:  1,
-		Op:   operation,
-		Body: body,
+// pushResolved push a delayed message to the servers its keys and mids are
+// connected to now, it is dead lettered if they failed to resolve.
+func (j *Job) pushResolved(ctx context.Context, pushMsg *pb.PushMsg, d *delivery) (err error) {
+	defer func() {
+		if err != nil {
+			j.deadLetter(pushMsg.Server, []*pb.PushMsg{pushMsg}, 0, err.Error())
+		}
+	}()
+	serverKeys := make(map[string][]string)
+	if len(pushMsg.Keys) > 0 {
+		var servers []string
+		if servers, err = j.dao.ServersByKeys(ctx, pushMsg.Keys); err != nil {
+			return
+		}
+		for i, key := range pushMsg.Keys {
+			if server := servers[i]; server != "" && key != "" {
+				serverKeys[server] = append(serverKeys[server], key)
+			}
+		}
 	}
-	p.WriteTo(buf)
-	p.Body = buf.Buffer()
-	p.Op = protocol.OpRaw
-	comets := j.cometServers
-	speed /= int32(len(comets))
-	var args = comet.BroadcastReq{
-		ProtoOp: operation,
-		Proto:   p,
-		Speed:   speed,
+	if len(pushMsg.Mids) > 0 {
+		var keyServers map[string]string
+		if keyServers, err = j.dao.KeysByMids(ctx, pushMsg.Mids); err != nil {
+			return
+		}
+		for key, server := range keyServers {
+			if server != "" && key != "" {
+				serverKeys[server] = append(serverKeys[server], key)
+			}
+		}
 	}
+	for server, keys := range serverKeys {
+		msg := proto.Clone(pushMsg).(*pb.PushMsg)
+		msg.Server, msg.Keys, msg.Mids = server, keys, nil
+		_ = j.pushKeys(msg, d)
+	}
+	log.Infof("push resolved:%s servers:%d", pushMsg.Id, len(serverKeys))
+	return
+}
+
+// broadcast broadcast a message to all.
+func (j *Job) broadcast(pushMsg *pb.PushMsg, d *delivery) (err error) {
+	comets := j.cometServers
+	args := broadcastReq(pushMsg, len(comets))
 	for serverID, c := range comets {
-		if err = c.Broadcast(&args); err != nil {
+		d.add()
+		if err = c.Broadcast(args, []*pb.PushMsg{pushMsg}, d.done); err != nil {
 			log.Errorf("c.Broadcast(%v) serverID:%s error(%v)", args, serverID, err)
 		}
 	}
@@ -77,19 +193,72 @@ func (j *Job) broadcast(operation int32, body []byte, speed int32) (err error) {
 	return
 }
 
-// broadcastRoomRawBytes broadcast aggregation messages to room.
-func (j *Job) broadcastRoomRawBytes(roomID string, body []byte) (err error) {
-	args := comet.BroadcastRoomReq{
-		RoomID: roomID,
-		Proto: &protocol.Proto{
-			Ver:  1,
-			Op:   protocol.OpRaw,
-			Body: body,
-		},
+// kick disconnect the keys of a comet, or every entry of a room on all comets.
+func (j *Job) kick(pushMsg *pb.PushMsg, d *delivery) (err error) {
+	args := kickReq(pushMsg)
+	if pushMsg.Room == "" {
+		if c, ok := j.cometServers[pushMsg.Server]; ok {
+			d.add()
+			if err = c.Kick(args, []*pb.PushMsg{pushMsg}, d.done); err != nil {
+				log.Errorf("c.Kick(%v) serverID:%s error(%v)", args, pushMsg.Server, err)
+			}
+		}
+		return
+	}
+	comets := j.cometServers
+	for serverID, c := range comets {
+		d.add()
+		if err = c.Kick(args, []*pb.PushMsg{pushMsg}, d.done); err != nil {
+			log.Errorf("c.Kick(%v) roomID:%s serverID:%s error(%v)", args, pushMsg.Room, serverID, err)
+		}
+	}
+	log.Infof("kick room:%s comets:%d", pushMsg.Room, len(comets))
+	return
+}
+
+// migrate ask a comet to migrate a number of conns.
+func (j *Job) migrate(pushMsg *pb.PushMsg, d *delivery) (err error) {
+	args := &comet.MigrateReq{Count: pushMsg.Count, Nodes: pushMsg.Nodes}
+	if c, ok := j.cometServers[pushMsg.Server]; ok {
+		d.add()
+		if err = c.Migrate(args, []*pb.PushMsg{pushMsg}, d.done); err != nil {
+			log.Errorf("c.Migrate(%v) serverID:%s error(%v)", args, pushMsg.Server, err)
+		}
+	}
+	return
+}
+
+// trace trace the conns of a mid or key on all comets.
+func (j *Job) trace(pushMsg *pb.PushMsg, d *delivery) (err error) {
+	args := &comet.TraceReq{Mid: pushMsg.Mid, Ttl: pushMsg.Ttl}
+	if len(pushMsg.Keys) > 0 {
+		args.Key = pushMsg.Keys[0]
+	}
+	comets := j.cometServers
+	for serverID, c := range comets {
+		d.add()
+		if err = c.Trace(args, []*pb.PushMsg{pushMsg}, d.done); err != nil {
+			log.Errorf("c.Trace(%v) serverID:%s error(%v)", args, serverID, err)
+		}
 	}
+	log.Infof("trace mid:%d key:%s comets:%d", args.Mid, args.Key, len(comets))
+	return
+}
+
+// broadcastRoomRawBytes broadcast aggregation messages to room, done is called
+// once every comet call returned, msgs are the merged ones.
+func (j *Job) broadcastRoomRawBytes(roomID string, seq int32, body []byte, msgs []*pb.PushMsg, done func()) (err error) {
+	args := broadcastRoomReq(roomID, seq, body, msgs)
 	comets := j.cometServers
+	pending := int32(len(comets)) + 1
+	cometDone := func() {
+		if atomic.AddInt32(&pending, -1) == 0 {
+			done()
+		}
+	}
+	defer cometDone()
 	for serverID, c := range comets {
-		if err = c.BroadcastRoom(&args); err != nil {
+		if err = c.BroadcastRoom(args, msgs, cometDone); err != nil {
 			log.Errorf("c.BroadcastRoom(%v) roomID:%s serverID:%s error(%v)", args, roomID, serverID, err)
 		}
 	}
@@ -0,0 +1,109 @@
+package job
+
+import (
+	"container/heap"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// retryHeap the failed comet requests ordered by their due time.
+type retryHeap []*cometReq
+
+func (h retryHeap) Len() int            { return len(h) }
+func (h retryHeap) Less(i, j int) bool  { return h[i].due.Before(h[j].due) }
+func (h retryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *retryHeap) Push(x interface{}) { *h = append(*h, x.(*cometReq)) }
+func (h *retryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
+
+// retryLater schedule a failed request with exponential backoff, or dead
+// letter it once the max attempts reached.
+func (c *Comet) retryLater(req *cometReq, err error) {
+	if req.attempts++; req.attempts >= c.retry.Max {
+		c.abandon(req, err.Error())
+		return
+	}
+	backoff := time.Duration(c.retry.Backoff) << uint(req.attempts-1)
+	if max := time.Duration(c.retry.MaxBackoff); backoff > max || backoff <= 0 {
+		backoff = max
+	}
+	c.schedule(req, time.Now().Add(backoff))
+}
+
+// retryProbe schedule a request refused by the open breaker once the comet
+// is probed again, it does not count as an attempt. A comet which stays
+// down is removed and its requests dead lettered.
+func (c *Comet) retryProbe(req *cometReq) {
+	due := c.breaker.probeAt()
+	if now := time.Now(); due.Before(now) {
+		// half open with all the probes in flight
+		due = now.Add(time.Duration(c.retry.Backoff))
+	}
+	c.schedule(req, due)
+}
+
+func (c *Comet) schedule(req *cometReq, due time.Time) {
+	req.due = due
+	c.retryMutex.Lock()
+	heap.Push(&c.retries, req)
+	c.retryMutex.Unlock()
+	select {
+	case c.retrySignal <- struct{}{}:
+	default:
+	}
+}
+
+// abandon dead letter the msgs of a request and complete it.
+func (c *Comet) abandon(req *cometReq, reason string) {
+	log.Errorf("comet serverId:%s dead letter %d msgs after %d attempts: %s", c.serverID, len(req.msgs), req.attempts, reason)
+	c.deadLetter(c.serverID, req.msgs, req.attempts, reason)
+	req.done()
+}
+
+// retryproc call the failed requests once due.
+func (c *Comet) retryproc() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		var req *cometReq
+		wait := time.Hour
+		c.retryMutex.Lock()
+		if len(c.retries) > 0 {
+			if wait = time.Until(c.retries[0].due); wait <= 0 {
+				req = heap.Pop(&c.retries).(*cometReq)
+			}
+		}
+		c.retryMutex.Unlock()
+		if req != nil {
+			c.call(req)
+			continue
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-c.retrySignal:
+		case <-c.ctx.Done():
+			c.retryMutex.Lock()
+			reqs := c.retries
+			c.retries = nil
+			c.retryMutex.Unlock()
+			for _, req := range reqs {
+				c.abandon(req, ErrComet.Error())
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package job
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/job/conf"
+	xtime "github.com/wcaqrl/chime/pkg/time"
+)
+
+func newTestComet(retry *conf.Retry, bc *conf.Breaker, deadLetter deadLetterFunc) *Comet {
+	return &Comet{
+		serverID:    "test",
+		breaker:     newBreaker(bc),
+		retry:       retry,
+		deadLetter:  deadLetter,
+		retrySignal: make(chan struct{}, 1),
+	}
+}
+
+func TestRetryHeap(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		dues []time.Duration
+		want []time.Duration
+	}{
+		{"empty", nil, nil},
+		{"sorted", []time.Duration{1, 2, 3}, []time.Duration{1, 2, 3}},
+		{"reversed", []time.Duration{3, 2, 1}, []time.Duration{1, 2, 3}},
+		{"mixed", []time.Duration{5, 1, 4, 2, 3}, []time.Duration{1, 2, 3, 4, 5}},
+		{"duplicates", []time.Duration{2, 1, 2, 1}, []time.Duration{1, 1, 2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h retryHeap
+			for _, d := range tt.dues {
+				heap.Push(&h, &cometReq{due: now.Add(d * time.Second)})
+			}
+			for i, want := range tt.want {
+				req := heap.Pop(&h).(*cometReq)
+				if got := req.due.Sub(now); got != want*time.Second {
+					t.Fatalf("pop %d due %s, want %s", i, got, want*time.Second)
+				}
+			}
+			if h.Len() != 0 {
+				t.Fatalf("heap len %d after pops, want 0", h.Len())
+			}
+		})
+	}
+}
+
+func TestRetryLater(t *testing.T) {
+	retry := &conf.Retry{
+		Max:        5,
+		Backoff:    xtime.Duration(time.Second),
+		MaxBackoff: xtime.Duration(4 * time.Second),
+	}
+	tests := []struct {
+		name      string
+		attempts  int
+		backoff   time.Duration
+		abandoned bool
+	}{
+		{"first failure", 0, time.Second, false},
+		{"doubles", 1, 2 * time.Second, false},
+		{"doubles again", 2, 4 * time.Second, false},
+		{"capped", 3, 4 * time.Second, false},
+		{"max attempts", 4, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var abandoned, done bool
+			c := newTestComet(retry, &conf.Breaker{}, func(server string, msgs []*pb.PushMsg, attempts int, reason string) {
+				abandoned = true
+			})
+			req := &cometReq{attempts: tt.attempts, done: func() { done = true }}
+			start := time.Now()
+			c.retryLater(req, ErrCometFull)
+			if req.attempts != tt.attempts+1 {
+				t.Fatalf("attempts %d, want %d", req.attempts, tt.attempts+1)
+			}
+			if abandoned != tt.abandoned || done != tt.abandoned {
+				t.Fatalf("abandoned %v done %v, want %v", abandoned, done, tt.abandoned)
+			}
+			if tt.abandoned {
+				if c.retries.Len() != 0 {
+					t.Fatalf("retries %d, want 0", c.retries.Len())
+				}
+				return
+			}
+			if c.retries.Len() != 1 {
+				t.Fatalf("retries %d, want 1", c.retries.Len())
+			}
+			if d := req.due.Sub(start); d < tt.backoff || d > tt.backoff+time.Second {
+				t.Fatalf("backoff %s, want %s", d, tt.backoff)
+			}
+		})
+	}
+}
+
+func TestRetryProbe(t *testing.T) {
+	bc := &conf.Breaker{Failures: 1, OpenTimeout: xtime.Duration(10 * time.Second), HalfOpen: 1}
+	retry := &conf.Retry{Max: 3, Backoff: xtime.Duration(time.Second), MaxBackoff: xtime.Duration(time.Minute)}
+	tests := []struct {
+		name string
+		open bool
+		due  time.Duration
+	}{
+		{"open waits for the probe", true, 10 * time.Second},
+		{"half open backs off", false, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestComet(retry, bc, nil)
+			start := time.Now()
+			if tt.open {
+				c.breaker.allow()
+				c.breaker.done(false)
+			}
+			req := &cometReq{attempts: 2}
+			c.retryProbe(req)
+			if req.attempts != 2 {
+				t.Fatalf("attempts %d, want 2", req.attempts)
+			}
+			if d := req.due.Sub(start); d < tt.due-time.Second || d > tt.due+time.Second {
+				t.Fatalf("due in %s, want %s", d, tt.due)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package job
 
 import (
 	"errors"
+	"sync"
 	"time"
 
+	pb "github.com/wcaqrl/chime/api/logic"
 	"github.com/wcaqrl/chime/api/protocol"
 	"github.com/wcaqrl/chime/internal/job/conf"
 	"github.com/wcaqrl/chime/pkg/bytes"
@@ -18,7 +20,9 @@ var (
 	// ErrRoomFull room chan full.
 	ErrRoomFull = errors.New("room proto chan full")
 
-	roomReadyProto = new(protocol.Proto)
+	errRoomClosed = errors.New("room closed")
+
+	roomReadyMsg = new(roomMsg)
 )
 
 // Room room.
@@ -26,7 +30,16 @@ type Room struct {
 	c     *conf.Room
 	job   *Job
 	id    string
-	proto chan *protocol.Proto
+	proto chan *roomMsg
+
+	mutex  sync.Mutex
+	closed bool
+}
+
+type roomMsg struct {
+	p      *protocol.Proto
+	origin *pb.PushMsg
+	done   func()
 }
 
 // NewRoom new a room struct, store channel room info.
@@ -35,21 +48,28 @@ func NewRoom(job *Job, id string, c *conf.Room) (r *Room) {
 		c:     c,
 		id:    id,
 		job:   job,
-		proto: make(chan *protocol.Proto, c.Batch*2),
+		proto: make(chan *roomMsg, c.Batch*2),
 	}
 	go r.pushproc(c.Batch, time.Duration(c.Signal))
 	return
 }
 
-// Push push msg to the room, if chan full discard it.
-func (r *Room) Push(op int32, msg []byte) (err error) {
+// Push push msg to the room, ErrRoomFull if the chan is full, done is called
+// once the merged batch was broadcasted.
+func (r *Room) Push(pushMsg *pb.PushMsg, done func()) (err error) {
 	var p = &protocol.Proto{
 		Ver:  1,
-		Op:   op,
-		Body: msg,
+		Op:   pushMsg.Operation,
+		Seq:  pushMsg.Seq,
+		Body: pushMsg.Msg,
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed {
+		return errRoomClosed
 	}
 	select {
-	case r.proto <- p:
+	case r.proto <- &roomMsg{p: p, origin: pushMsg, done: done}:
 	default:
 		err = ErrRoomFull
 	}
@@ -59,25 +79,29 @@ func (r *Room) Push(op int32, msg []byte) (err error) {
 // pushproc merge proto and push msgs in batch.
 func (r *Room) pushproc(batch int, sigTime time.Duration) {
 	var (
-		n    int
-		last time.Time
-		p    *protocol.Proto
-		buf  = bytes.NewWriterSize(int(protocol.MaxBodySize))
+		n     int
+		last  time.Time
+		m     *roomMsg
+		msgs  []*pb.PushMsg
+		dones []func()
+		buf   = bytes.NewWriterSize(int(protocol.MaxBodySize))
 	)
 	log.Infof("start room:%s goroutine", r.id)
 	td := time.AfterFunc(sigTime, func() {
 		select {
-		case r.proto <- roomReadyProto:
+		case r.proto <- roomReadyMsg:
 		default:
 		}
 	})
 	defer td.Stop()
 	for {
-		if p = <-r.proto; p == nil {
+		if m = <-r.proto; m == nil {
 			break // exit
-		} else if p != roomReadyProto {
+		} else if m != roomReadyMsg {
 			// merge buffer ignore error, always nil
-			p.WriteTo(buf)
+			m.p.WriteTo(buf)
+			msgs = append(msgs, m.origin)
+			dones = append(dones, m.done)
 			if n++; n == 1 {
 				last = time.Now()
 				td.Reset(sigTime)
@@ -92,7 +116,8 @@ func (r *Room) pushproc(batch int, sigTime time.Duration) {
 				break
 			}
 		}
-		_ = r.job.broadcastRoomRawBytes(r.id, buf.Buffer())
+		r.flush(buf, msgs, dones)
+		msgs, dones = nil, nil
 		// TODO use reset buffer
 		// after push to room channel, renew a buffer, let old buffer gc
 		buf = bytes.NewWriterSize(buf.Size())
@@ -104,9 +129,45 @@ func (r *Room) pushproc(batch int, sigTime time.Duration) {
 		}
 	}
 	r.job.delRoom(r.id)
+	r.mutex.Lock()
+	r.closed = true
+	r.mutex.Unlock()
+	// flush the msgs pushed while exiting, they must not be lost
+	for drained := false; !drained; {
+		select {
+		case m = <-r.proto:
+			if m != roomReadyMsg {
+				m.p.WriteTo(buf)
+				msgs = append(msgs, m.origin)
+				dones = append(dones, m.done)
+			}
+		default:
+			drained = true
+		}
+	}
+	if len(dones) > 0 {
+		r.flush(buf, msgs, dones)
+	}
 	log.Infof("room:%s goroutine exit", r.id)
 }
 
+// flush broadcast the merged msgs and complete them once all comets returned,
+// the batch carries the highest seq of its msgs.
+func (r *Room) flush(buf *bytes.Writer, msgs []*pb.PushMsg, dones []func()) {
+	var seq int32
+	for _, msg := range msgs {
+		if msg.Seq > seq {
+			seq = msg.Seq
+		}
+	}
+	roomBatch.Observe(float64(len(msgs)))
+	_ = r.job.broadcastRoomRawBytes(r.id, seq, buf.Buffer(), msgs, func() {
+		for _, done := range dones {
+			done()
+		}
+	})
+}
+
 func (j *Job) delRoom(roomID string) {
 	j.roomsMutex.Lock()
 	delete(j.rooms, roomID)
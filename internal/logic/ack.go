@@ -0,0 +1,19 @@
+package logic
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+// AckReport accumulate the push ack counters reported by a comet.
+func (l *Logic) AckReport(c context.Context, server string, delivered, dropped, expired int64) (err error) {
+	log.Infof("ack report server:%s delivered:%d dropped:%d expired:%d", server, delivered, dropped, expired)
+	return l.dao.IncrAckStats(c, server, delivered, dropped, expired)
+}
+
+// AckStats get the push ack counters of all comets.
+func (l *Logic) AckStats(c context.Context) (res map[string]*model.AckStats, err error) {
+	return l.dao.AckStats(c)
+}
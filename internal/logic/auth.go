@@ -0,0 +1,194 @@
+package logic
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/conf"
+	"github.com/wcaqrl/chime/pkg/token"
+)
+
+const (
+	// AuthJSON trust the raw json token, only for development.
+	AuthJSON = "json"
+	// AuthHMAC verify a token signed by pkg/token.
+	AuthHMAC = "hmac"
+	// AuthJWT verify a HS256/RS256 json web token.
+	AuthJWT = "jwt"
+)
+
+var (
+	// ErrUnauthenticated token rejected by the authenticator.
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+// Claims is the identity derived from a verified token.
+type Claims struct {
+	Mid      int64   `json:"mid"`
+	Key      string  `json:"key"`
+	RoomID   string  `json:"room_id"`
+	Platform string  `json:"platform"`
+	Accepts  []int32 `json:"accepts"`
+	jwt.RegisteredClaims
+}
+
+// Authenticator verify a client token and derive the claims from it.
+type Authenticator interface {
+	Authenticate(c context.Context, token []byte) (*Claims, error)
+}
+
+// NewAuthenticator new an authenticator by the auth config.
+func NewAuthenticator(c *conf.Auth) (Authenticator, error) {
+	switch c.Type {
+	case AuthJSON:
+		log.Warningf("auth type %q trusts client claims, never use it in production", AuthJSON)
+		return &jsonAuthenticator{c: c}, nil
+	case AuthHMAC:
+		secret, err := authSecret(c)
+		if err != nil {
+			return nil, err
+		}
+		return &hmacAuthenticator{c: c, secret: secret}, nil
+	case AuthJWT, "":
+		return newJWTAuthenticator(c)
+	}
+	return nil, fmt.Errorf("unknown auth type: %s", c.Type)
+}
+
+func authSecret(c *conf.Auth) ([]byte, error) {
+	if c.SecretFile != "" {
+		b, err := ioutil.ReadFile(c.SecretFile)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strings.TrimSpace(string(b))), nil
+	}
+	if c.Secret == "" {
+		return nil, fmt.Errorf("auth type %s requires a secret", c.Type)
+	}
+	return []byte(c.Secret), nil
+}
+
+func unauthenticated(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrUnauthenticated, fmt.Sprintf(format, args...))
+}
+
+// validate check the registered claims against the auth config.
+func validate(c *conf.Auth, claims *Claims) error {
+	var (
+		now    = time.Now()
+		leeway = time.Duration(c.Leeway)
+	)
+	if claims.ExpiresAt == nil {
+		if c.RequireExp {
+			return unauthenticated("token has no expiry")
+		}
+	} else if now.After(claims.ExpiresAt.Add(leeway)) {
+		return unauthenticated("token expired")
+	}
+	if claims.NotBefore != nil && now.Add(leeway).Before(claims.NotBefore.Time) {
+		return unauthenticated("token not valid yet")
+	}
+	if c.Issuer != "" && claims.Issuer != c.Issuer {
+		return unauthenticated("token issuer %q not accepted", claims.Issuer)
+	}
+	if c.Audience != "" && !claims.VerifyAudience(c.Audience, true) {
+		return unauthenticated("token audience not accepted")
+	}
+	if claims.Mid <= 0 {
+		return unauthenticated("token has no mid")
+	}
+	return nil
+}
+
+// jsonAuthenticator trust whatever the client claims, the claims are still
+// validated.
+type jsonAuthenticator struct {
+	c *conf.Auth
+}
+
+func (a *jsonAuthenticator) Authenticate(c context.Context, token []byte) (*Claims, error) {
+	claims := new(Claims)
+	if err := json.Unmarshal(token, claims); err != nil {
+		return nil, unauthenticated("json.Unmarshal error(%v)", err)
+	}
+	if err := validate(a.c, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// hmacAuthenticator verify a json payload signed with a shared secret.
+type hmacAuthenticator struct {
+	c      *conf.Auth
+	secret []byte
+}
+
+func (a *hmacAuthenticator) Authenticate(c context.Context, tk []byte) (*Claims, error) {
+	payload, err := token.Verify(a.secret, tk)
+	if err != nil {
+		return nil, unauthenticated("%v", err)
+	}
+	claims := new(Claims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, unauthenticated("json.Unmarshal error(%v)", err)
+	}
+	if err = validate(a.c, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// jwtAuthenticator verify a json web token with a local key.
+type jwtAuthenticator struct {
+	c      *conf.Auth
+	parser *jwt.Parser
+	key    interface{}
+}
+
+func newJWTAuthenticator(c *conf.Auth) (a *jwtAuthenticator, err error) {
+	a = &jwtAuthenticator{
+		c:      c,
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{c.Algorithm}), jwt.WithoutClaimsValidation()),
+	}
+	switch c.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		a.key, err = authSecret(c)
+	case jwt.SigningMethodRS256.Alg():
+		var (
+			b   []byte
+			pub *rsa.PublicKey
+		)
+		if b, err = ioutil.ReadFile(c.PublicKeyFile); err != nil {
+			return
+		}
+		if pub, err = jwt.ParseRSAPublicKeyFromPEM(b); err != nil {
+			return
+		}
+		a.key = pub
+	default:
+		err = fmt.Errorf("unsupported jwt algorithm: %s", c.Algorithm)
+	}
+	return
+}
+
+func (a *jwtAuthenticator) Authenticate(c context.Context, tk []byte) (*Claims, error) {
+	claims := new(Claims)
+	if _, err := a.parser.ParseWithClaims(string(tk), claims, func(*jwt.Token) (interface{}, error) {
+		return a.key, nil
+	}); err != nil {
+		return nil, unauthenticated("%v", err)
+	}
+	if err := validate(a.c, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/wcaqrl/chime/internal/logic/conf"
+	xtime "github.com/wcaqrl/chime/pkg/time"
+)
+
+func TestJWTAuthenticate(t *testing.T) {
+	const secret = "test-secret"
+	a, err := NewAuthenticator(&conf.Auth{
+		Type:       AuthJWT,
+		Algorithm:  jwt.SigningMethodHS256.Alg(),
+		Secret:     secret,
+		Issuer:     "chime",
+		Audience:   "app",
+		RequireExp: true,
+		Leeway:     xtime.Duration(5 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error(%v)", err)
+	}
+	now := time.Now()
+	at := func(d time.Duration) *jwt.NumericDate { return jwt.NewNumericDate(now.Add(d)) }
+	claims := func(fn func(*Claims)) *Claims {
+		c := &Claims{
+			Mid: 1,
+			Key: "key",
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "chime",
+				Audience:  jwt.ClaimStrings{"app"},
+				ExpiresAt: at(time.Minute),
+			},
+		}
+		if fn != nil {
+			fn(c)
+		}
+		return c
+	}
+	sign := func(method jwt.SigningMethod, key interface{}, c *Claims) string {
+		s, err := jwt.NewWithClaims(method, c).SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() error(%v)", err)
+		}
+		return s
+	}
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"valid", sign(jwt.SigningMethodHS256, []byte(secret), claims(nil)), true},
+		{"expired within leeway", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.ExpiresAt = at(-2 * time.Second) })), true},
+		{"expired", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.ExpiresAt = at(-time.Minute) })), false},
+		{"no expiry", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.ExpiresAt = nil })), false},
+		{"not valid yet", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.NotBefore = at(time.Minute) })), false},
+		{"not before within leeway", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.NotBefore = at(2 * time.Second) })), true},
+		{"wrong issuer", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.Issuer = "other" })), false},
+		{"wrong audience", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.Audience = jwt.ClaimStrings{"other"} })), false},
+		{"no mid", sign(jwt.SigningMethodHS256, []byte(secret), claims(func(c *Claims) { c.Mid = 0 })), false},
+		{"wrong secret", sign(jwt.SigningMethodHS256, []byte("other"), claims(nil)), false},
+		{"other algorithm", sign(jwt.SigningMethodHS384, []byte(secret), claims(nil)), false},
+		{"none algorithm", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(nil)), false},
+		{"malformed", "not.a.token", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := a.Authenticate(context.Background(), []byte(tt.token))
+			if !tt.ok {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("Authenticate() error(%v), want ErrUnauthenticated", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error(%v)", err)
+			}
+			if c.Mid != 1 || c.Key != "key" {
+				t.Fatalf("Authenticate() claims mid:%d key:%s, want mid:1 key:key", c.Mid, c.Key)
+			}
+		})
+	}
+}
+
+func TestNewJWTAuthenticator(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *conf.Auth
+		ok   bool
+	}{
+		{"hs256", &conf.Auth{Type: AuthJWT, Algorithm: "HS256", Secret: "secret"}, true},
+		{"hs256 without secret", &conf.Auth{Type: AuthJWT, Algorithm: "HS256"}, false},
+		{"rs256 without key", &conf.Auth{Type: AuthJWT, Algorithm: "RS256", PublicKeyFile: "/nonexistent"}, false},
+		{"unsupported algorithm", &conf.Auth{Type: AuthJWT, Algorithm: "none", Secret: "secret"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthenticator(tt.c)
+			if (err == nil) != tt.ok {
+				t.Fatalf("NewAuthenticator() error(%v), want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/bilibili/discovery/naming"
 	"github.com/wcaqrl/chime/pkg/pather"
 	xtime "github.com/wcaqrl/chime/pkg/time"
+	"github.com/wcaqrl/chime/pkg/tracing"
 )
 
 var (
@@ -109,12 +110,25 @@ func initConfig() {
 	if Conf.HTTPServer.WriteTimeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
 		Conf.HTTPServer.WriteTimeout = xtime.Duration(1e9)
 	}
+	// metrics
+	Conf.Metrics.Addr = conf.GetDefault("metrics.addr", ":3118")
+	// tracing
+	Conf.Tracing.Exporter = conf.GetDefault("tracing.exporter", "")
+	Conf.Tracing.Endpoint = conf.GetDefault("tracing.endpoint", "http://127.0.0.1:4318/v1/traces")
+	Conf.Tracing.Ratio = conf.GetFloat64Default("tracing.ratio", 1)
 	// kafka
 	Conf.Kafka.Topic = conf.GetDefault("kafka.topic", "chime-push-topic")
+	Conf.Kafka.BulkTopic = conf.GetDefault("kafka.bulk_topic", "chime-push-bulk-topic")
+	Conf.Kafka.Version = conf.GetDefault("kafka.version", "")
 	tmpStr = conf.GetDefault("kafka.brokers", "")
 	if tmpStr != "" {
 		Conf.Kafka.Brokers = strings.Split(tmpStr, ",")
 	}
+	// bus
+	Conf.Bus.Type = conf.GetDefault("bus.type", "kafka")
+	Conf.Bus.NatsURL = conf.GetDefault("bus.nats_url", "nats://127.0.0.1:4222")
+	Conf.Bus.MaxLen = int64(conf.GetIntDefault("bus.max_len", 100000))
+	Conf.Bus.Buffer = conf.GetIntDefault("bus.buffer", 1024)
 	// redis
 	Conf.Redis.Network = conf.GetDefault("redis.network", "tcp")
 	Conf.Redis.Addr = conf.GetDefault("redis.addr", ":6379")
@@ -160,6 +174,82 @@ func initConfig() {
 	Conf.Backoff.Jitter = float32(conf.GetFloat64Default("backoff.jitter", 1.8))
 	// regions
 	Conf.Regions = parseRegions(conf)
+	// auth
+	Conf.Auth.Type = conf.GetDefault("auth.type", "jwt")
+	Conf.Auth.Algorithm = conf.GetDefault("auth.algorithm", "HS256")
+	Conf.Auth.Secret = conf.GetDefault("auth.secret", "")
+	Conf.Auth.SecretFile = conf.GetDefault("auth.secret_file", "")
+	Conf.Auth.PublicKeyFile = conf.GetDefault("auth.public_key_file", "")
+	Conf.Auth.Issuer = conf.GetDefault("auth.issuer", "")
+	Conf.Auth.Audience = conf.GetDefault("auth.audience", "")
+	Conf.Auth.RequireExp = conf.GetBoolDefault("auth.require_exp", true)
+	tmpStr = conf.GetDefault("auth.leeway", "30s")
+	if Conf.Auth.Leeway, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Auth.Leeway = xtime.Duration(30 * 1e9)
+	}
+	// upstream
+	Conf.Upstream.Topic = conf.GetDefault("upstream.topic", "chime-upstream-topic")
+	Conf.Upstream.Webhook = conf.GetDefault("upstream.webhook", "")
+	Conf.Upstream.Buffer = conf.GetIntDefault("upstream.buffer", 1024)
+	Conf.Upstream.Worker = conf.GetIntDefault("upstream.worker", 8)
+	Conf.Upstream.Retry = conf.GetIntDefault("upstream.retry", 3)
+	tmpStr = conf.GetDefault("upstream.backoff", "100ms")
+	if Conf.Upstream.Backoff, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Upstream.Backoff = xtime.Duration(100 * 1e6)
+	}
+	tmpStr = conf.GetDefault("upstream.timeout", "1s")
+	if Conf.Upstream.Timeout, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Upstream.Timeout = xtime.Duration(1e9)
+	}
+	Conf.Upstream.Routes = parseRoutes(conf)
+	// offline
+	Conf.Offline.Max = conf.GetIntDefault("offline.max", 100)
+	tmpStr = conf.GetDefault("offline.expire", "72h")
+	if Conf.Offline.Expire, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Offline.Expire = xtime.Duration(72 * 3600 * 1e9)
+	}
+	tmpStr = conf.GetDefault("offline.lease", "10s")
+	if Conf.Offline.Lease, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Offline.Lease = xtime.Duration(10 * 1e9)
+	}
+	// presence
+	Conf.Presence.Topic = conf.GetDefault("presence.topic", "")
+	tmpStr = conf.GetDefault("presence.debounce", "5s")
+	if Conf.Presence.Debounce, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Presence.Debounce = xtime.Duration(5 * 1e9)
+	}
+	tmpStr = conf.GetDefault("presence.sweep", "10s")
+	if Conf.Presence.Sweep, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Presence.Sweep = xtime.Duration(10 * 1e9)
+	}
+	Conf.Presence.Buffer = conf.GetIntDefault("presence.buffer", 1024)
+	// resume
+	Conf.Resume.Secret = conf.GetDefault("resume.secret", "")
+	// rebalance
+	Conf.Rebalance.Enable = conf.GetBoolDefault("rebalance.enable", false)
+	tmpStr = conf.GetDefault("rebalance.interval", "1m")
+	if Conf.Rebalance.Interval, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Rebalance.Interval = xtime.Duration(time.Minute)
+	}
+	Conf.Rebalance.Threshold = conf.GetFloat64Default("rebalance.threshold", 0.2)
+	Conf.Rebalance.MaxRatio = conf.GetIntDefault("rebalance.max_ratio", 5)
+	// push
+	tmpStr = conf.GetDefault("push.idempotency", "10m")
+	if Conf.Push.Idempotency, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Push.Idempotency = xtime.Duration(10 * time.Minute)
+	}
+	tmpStr = conf.GetDefault("push.status", "1h")
+	if Conf.Push.Status, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Push.Status = xtime.Duration(time.Hour)
+	}
+	tmpStr = conf.GetDefault("push.room_seq", "24h")
+	if Conf.Push.RoomSeq, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Push.RoomSeq = xtime.Duration(24 * time.Hour)
+	}
+	tmpStr = conf.GetDefault("push.key_seq", "24h")
+	if Conf.Push.KeySeq, err = xtime.UnmarshalDuration(tmpStr); err != nil {
+		Conf.Push.KeySeq = xtime.Duration(24 * time.Hour)
+	}
 }
 
 func usage() {
@@ -187,6 +277,8 @@ func Default() *Config {
 		Logger:    &xcommon.Logger{Level: "info", Path: "./logs", Save: 7},
 		Env:       &Env{Region: region, Zone: zone, DeployEnv: deployEnv, Host: host, Weight: weight},
 		Discovery: &naming.Config{Region: region, Zone: zone, Env: deployEnv, Host: host},
+		Metrics:   &Metrics{Addr: ":3118"},
+		Tracing:   &tracing.Config{Endpoint: "http://127.0.0.1:4318/v1/traces", Ratio: 1},
 		HTTPServer: &HTTPServer{
 			Network:      "tcp",
 			Addr:         "3111",
@@ -205,10 +297,43 @@ func Default() *Config {
 			KeepAliveTimeout:  xtime.Duration(time.Second * 20),
 		},
 		Kafka:   &Kafka{},
+		Bus:     &Bus{Type: "kafka", MaxLen: 100000, Buffer: 1024},
 		Redis:   &Redis{},
 		Node:    &Node{},
 		Backoff: &Backoff{MaxDelay: 300, BaseDelay: 3, Factor: 1.8, Jitter: 1.3},
 		Regions: map[string][]string{},
+		Auth:    &Auth{Type: "jwt", Algorithm: "HS256", RequireExp: true, Leeway: xtime.Duration(30 * time.Second)},
+		Upstream: &Upstream{
+			Topic:   "chime-upstream-topic",
+			Buffer:  1024,
+			Worker:  8,
+			Retry:   3,
+			Backoff: xtime.Duration(100 * time.Millisecond),
+			Timeout: xtime.Duration(time.Second),
+			Routes:  map[int32]string{},
+		},
+		Offline: &Offline{
+			Max:    100,
+			Expire: xtime.Duration(72 * time.Hour),
+			Lease:  xtime.Duration(10 * time.Second),
+		},
+		Presence: &Presence{
+			Debounce: xtime.Duration(5 * time.Second),
+			Sweep:    xtime.Duration(10 * time.Second),
+			Buffer:   1024,
+		},
+		Resume: &Resume{},
+		Rebalance: &Rebalance{
+			Interval:  xtime.Duration(time.Minute),
+			Threshold: 0.2,
+			MaxRatio:  5,
+		},
+		Push: &Push{
+			Idempotency: xtime.Duration(10 * time.Minute),
+			Status:      xtime.Duration(time.Hour),
+			RoomSeq:     xtime.Duration(24 * time.Hour),
+			KeySeq:      xtime.Duration(24 * time.Hour),
+		},
 	}
 }
 
@@ -223,6 +348,21 @@ func parseRegions(conf *ini.IniFileConfigSource) (regions map[string][]string) {
 	return
 }
 
+// parseRoutes parse upstream routes like: upstream_routes.1000 = kafka
+func parseRoutes(conf *ini.IniFileConfigSource) (routes map[int32]string) {
+	routes = make(map[int32]string)
+	for _, key := range conf.Keys() {
+		if strings.HasPrefix(key, "upstream_routes.") {
+			op, err := strconv.ParseInt(strings.TrimPrefix(key, "upstream_routes."), 10, 32)
+			if err != nil {
+				continue
+			}
+			routes[int32(op)] = conf.GetDefault(key, "")
+		}
+	}
+	return
+}
+
 // Config config.
 type Config struct {
 	Debug      bool
@@ -233,10 +373,20 @@ type Config struct {
 	RPCServer  *RPCServer
 	HTTPServer *HTTPServer
 	Kafka      *Kafka
+	Bus        *Bus
 	Redis      *Redis
 	Node       *Node
 	Backoff    *Backoff
 	Regions    map[string][]string
+	Auth       *Auth
+	Upstream   *Upstream
+	Offline    *Offline
+	Presence   *Presence
+	Resume     *Resume
+	Rebalance  *Rebalance
+	Push       *Push
+	Metrics    *Metrics
+	Tracing    *tracing.Config
 }
 
 // Env is env config.
@@ -268,6 +418,70 @@ type Backoff struct {
 	Jitter    float32
 }
 
+// Auth is client token auth config, jwt by default. The json type trusts the
+// client claims and must be opted into.
+type Auth struct {
+	Type          string
+	Algorithm     string
+	Secret        string
+	SecretFile    string
+	PublicKeyFile string
+	Issuer        string
+	Audience      string
+	RequireExp    bool
+	Leeway        xtime.Duration
+}
+
+// Upstream is client upstream message routing config.
+// Routes map an operation to "kafka", "webhook" or a webhook url.
+type Upstream struct {
+	Topic   string
+	Webhook string
+	Buffer  int
+	Worker  int
+	Retry   int
+	Backoff xtime.Duration
+	Timeout xtime.Duration
+	Routes  map[int32]string
+}
+
+// Offline is offline message store config.
+type Offline struct {
+	Max    int
+	Expire xtime.Duration
+	Lease  xtime.Duration // a connect of the mid replays the msgs once within
+}
+
+// Presence is presence event config, disabled when the topic is empty.
+type Presence struct {
+	Topic    string
+	Debounce xtime.Duration // an offline is published after it, shared by the logics in redis
+	Sweep    xtime.Duration // interval of the expiry and debounce sweeps
+	Buffer   int            // events waiting for the bus
+}
+
+// Resume is session resume config, the secret signs the tokens of the resume
+// slots, none is opened when it is empty.
+type Resume struct {
+	Secret string
+}
+
+// Rebalance is comet rebalancing config.
+type Rebalance struct {
+	Enable    bool
+	Interval  xtime.Duration
+	Threshold float64 // tolerated overload over the weighted share of conns
+	MaxRatio  int     // max percent of the conns of a comet migrated per minute
+}
+
+// Push is push status config.
+type Push struct {
+	Idempotency xtime.Duration // window the retries of an idempotency key are de-duped in
+	Status      xtime.Duration // expire of the delivery status of a push
+	RoomSeq     xtime.Duration // expire of the seq of an idle room, it restarts from 1
+	KeySeq      xtime.Duration // expire of the seq of a key gone offline, it restarts from 1
+}
+
 // Redis .
 type Redis struct {
 	Network      string
@@ -285,8 +499,19 @@ type Redis struct {
 
 // Kafka .
 type Kafka struct {
-	Topic   string
-	Brokers []string
+	Topic     string
+	BulkTopic string // the bulk pushes, job must consume it; the topic if empty
+	Brokers   []string
+	Version   string // the bus headers need 0.11+
+}
+
+// Bus is message bus config, the kafka brokers are taken from Kafka and
+// the redis streams share the Redis pool.
+type Bus struct {
+	Type    string // kafka, nats, redis or memory
+	NatsURL string
+	MaxLen  int64 // redis stream approximate max length
+	Buffer  int   // memory topic buffer
 }
 
 // RPCClient is RPC client config.
@@ -307,6 +532,11 @@ type RPCServer struct {
 	KeepAliveTimeout  xtime.Duration
 }
 
+// Metrics is metrics listener config, disabled when the addr is empty.
+type Metrics struct {
+	Addr string
+}
+
 // HTTPServer is http server config.
 type HTTPServer struct {
 	Network      string
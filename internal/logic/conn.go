@@ -2,7 +2,6 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/wcaqrl/chime/api/protocol"
@@ -11,60 +10,128 @@ import (
 	"github.com/google/uuid"
 )
 
-// Connect connected a conn.
-func (l *Logic) Connect(c context.Context, server, cookie string, token []byte) (mid int64, key, roomID string, accepts []int32, hb int64, err error) {
-	var params struct {
-		Mid      int64   `json:"mid"`
-		Key      string  `json:"key"`
-		RoomID   string  `json:"room_id"`
-		Platform string  `json:"platform"`
-		Accepts  []int32 `json:"accepts"`
-	}
-	if err = json.Unmarshal(token, &params); err != nil {
-		log.Errorf("json.Unmarshal(%s) error(%v)", token, err)
+// Connect connected a conn, with a resume slot it opens the slot of the
+// session and returns its token. The offline msgs of the mid after the last
+// seq the client received are returned for the comet to push, the key seq is
+// the one the key pushes of the conn go on after.
+func (l *Logic) Connect(c context.Context, server, cookie string, token []byte, lastSeq int64, resume *model.ResumeSlot) (mid int64, key, roomID string, accepts []int32, hb int64, resumeToken string, offline []byte, keySeq int32, err error) {
+	claims, err := l.auth.Authenticate(c, token)
+	if err != nil {
+		log.Errorf("l.auth.Authenticate() server:%s error(%v)", server, err)
 		return
 	}
-	mid = params.Mid
-	roomID = params.RoomID
-	accepts = params.Accepts
+	mid = claims.Mid
+	roomID = claims.RoomID
+	accepts = claims.Accepts
 	hb = int64(l.c.Node.Heartbeat) * int64(l.c.Node.HeartbeatMax)
-	if key = params.Key; key == "" {
+	if key = claims.Key; key == "" {
 		key = uuid.New().String()
 	}
-	if err = l.dao.AddMapping(c, mid, key, server); err != nil {
+	info := &model.KeyInfo{
+		Mid:       mid,
+		Room:      roomID,
+		Platform:  claims.Platform,
+		Connected: time.Now().Unix(),
+	}
+	if err = l.dao.AddMapping(c, mid, key, server, info); err != nil {
 		log.Errorf("l.dao.AddMapping(%d,%s,%s) error(%v)", mid, key, server, err)
+		return
+	}
+	if keySeq, err = l.dao.KeySeq(c, key); err != nil {
+		return
+	}
+	resumeToken = l.openResume(c, mid, key, roomID, accepts, resume)
+	if mid > 0 {
+		offline = l.takeOffline(c, mid, lastSeq)
+		if l.presence != nil {
+			l.presence.Online(c, &model.PresenceEvent{Mid: mid, Key: key, Server: server, Platform: claims.Platform, Room: roomID})
+		}
 	}
-	log.Infof("conn connected key:%s server:%s mid:%d token:%s", key, server, mid, token)
+	log.Infof("conn connected key:%s server:%s mid:%d platform:%s", key, server, mid, claims.Platform)
 	return
 }
 
-// Disconnect disconnect a conn.
-func (l *Logic) Disconnect(c context.Context, mid int64, key, server string) (has bool, err error) {
+// Disconnect disconnect a conn, the key seq is kept for the next conn of the
+// key.
+func (l *Logic) Disconnect(c context.Context, mid int64, key, server string, keySeq int32) (has bool, err error) {
+	l.setKeySeq(c, key, keySeq)
 	if has, err = l.dao.DelMapping(c, mid, key, server); err != nil {
 		log.Errorf("l.dao.DelMapping(%d,%s) error(%v)", mid, key, server)
 		return
 	}
+	if l.resumeSecret != nil {
+		_ = l.dao.DelResumeSlot(c, key)
+	}
+	if l.presence != nil && mid > 0 {
+		l.presence.Offline(c, key, model.PresenceOffline)
+	}
 	log.Infof("conn disconnected key:%s server:%s mid:%d", key, server, mid)
 	return
 }
 
-// Heartbeat heartbeat a conn.
-func (l *Logic) Heartbeat(c context.Context, mid int64, key, server string) (err error) {
+// Detach disconnect a conn into a resume slot kept for ttl, the client resumes
+// it on any comet with the token of the slot. A session resumed already on
+// another comet is left alone.
+func (l *Logic) Detach(c context.Context, mid int64, key, server string, slot *model.ResumeSlot, ttl time.Duration, keySeq int32) (err error) {
+	l.setKeySeq(c, key, keySeq)
+	tk, err := l.dao.ResumeSlotToken(c, key)
+	if err != nil {
+		return
+	}
+	if tk != "" && tk != slot.Token {
+		log.Infof("conn detach skipped key:%s server:%s mid:%d resumed already", key, server, mid)
+		return
+	}
+	// the presence is released first as it unmaps the key from its mid
+	if l.presence != nil && mid > 0 {
+		l.presence.Offline(c, key, model.PresenceOffline)
+	}
+	slot.Mid = mid
+	if err = l.dao.DetachResumeSlot(c, key, slot, ttl); err != nil {
+		log.Errorf("l.dao.DetachResumeSlot(%d,%s,%s) error(%v)", mid, key, server, err)
+		return
+	}
+	log.Infof("conn detached key:%s server:%s mid:%d seq:%d", key, server, mid, slot.Seq)
+	return
+}
+
+// Heartbeat heartbeat a conn, the key seq is kept for the next conn of the
+// key in case the comet is gone without a disconnect, the pushes after the
+// last heartbeat may reuse their seqs then.
+func (l *Logic) Heartbeat(c context.Context, mid int64, key, server string, keySeq int32) (err error) {
+	l.setKeySeq(c, key, keySeq)
 	has, err := l.dao.ExpireMapping(c, mid, key)
 	if err != nil {
 		log.Errorf("l.dao.ExpireMapping(%d,%s,%s) error(%v)", mid, key, server, err)
 		return
 	}
 	if !has {
-		if err = l.dao.AddMapping(c, mid, key, server); err != nil {
+		if err = l.dao.AddMapping(c, mid, key, server, nil); err != nil {
 			log.Errorf("l.dao.AddMapping(%d,%s,%s) error(%v)", mid, key, server, err)
 			return
 		}
 	}
+	if l.resumeSecret != nil {
+		_ = l.dao.ExpireResumeSlot(c, key)
+	}
+	if l.presence != nil {
+		l.presence.Heartbeat(c, mid, key, server)
+	}
 	log.Infof("conn heartbeat key:%s server:%s mid:%d", key, server, mid)
 	return
 }
 
+// setKeySeq keep the seq of the last push of a key, a conn without any push
+// leaves it alone.
+func (l *Logic) setKeySeq(c context.Context, key string, seq int32) {
+	if seq <= 0 {
+		return
+	}
+	if err := l.dao.SetKeySeq(c, key, seq, time.Duration(l.c.Push.KeySeq)); err != nil {
+		log.Errorf("l.dao.SetKeySeq(%s,%d) error(%v)", key, seq, err)
+	}
+}
+
 // RenewOnline renew a server online.
 func (l *Logic) RenewOnline(c context.Context, server string, roomCount map[string]int32) (map[string]int32, error) {
 	online := &model.Online{
@@ -75,11 +142,29 @@ func (l *Logic) RenewOnline(c context.Context, server string, roomCount map[stri
 	if err := l.dao.AddServerOnline(context.Background(), server, online); err != nil {
 		return nil, err
 	}
-	return l.roomCount, nil
+	return l.onlineRooms(), nil
 }
 
 // Receive receive a message.
-func (l *Logic) Receive(c context.Context, mid int64, proto *protocol.Proto) (err error) {
-	log.Infof("receive mid:%d message:%+v", mid, proto)
+func (l *Logic) Receive(c context.Context, mid int64, key, server, room string, proto *protocol.Proto) (err error) {
+	route := l.upstream.Route(proto.Op)
+	if route == "" {
+		log.Infof("receive mid:%d key:%s message:%+v", mid, key, proto)
+		return
+	}
+	msg := &model.UpstreamMsg{
+		Mid:       mid,
+		Key:       key,
+		Server:    server,
+		Room:      room,
+		Ver:       proto.Ver,
+		Op:        proto.Op,
+		Seq:       proto.Seq,
+		Body:      proto.Body,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if err = l.upstream.Push(route, msg); err != nil {
+		log.Errorf("l.upstream.Push(%s) mid:%d key:%s op:%d error(%v)", route, mid, key, proto.Op, err)
+	}
 	return
 }
@@ -5,35 +5,45 @@ import (
 	"time"
 
 	"github.com/gomodule/redigo/redis"
+	"github.com/wcaqrl/chime/internal/bus"
 	"github.com/wcaqrl/chime/internal/logic/conf"
-	kafka "gopkg.in/Shopify/sarama.v1"
 )
 
 // Dao dao.
 type Dao struct {
 	c           *conf.Config
-	kafkaPub    kafka.SyncProducer
+	bus         bus.Publisher
 	redis       *redis.Pool
 	redisExpire int32
+	// offline
+	offlineExpire int32
+	offlineLease  int64 // millis
 }
 
 // New new a dao and return.
 func New(c *conf.Config) *Dao {
 	d := &Dao{
 		c:           c,
-		kafkaPub:    newKafkaPub(c.Kafka),
 		redis:       newRedis(c.Redis),
 		redisExpire: int32(time.Duration(c.Redis.Expire) / time.Second),
+
+		offlineExpire: int32(time.Duration(c.Offline.Expire) / time.Second),
+		offlineLease:  int64(time.Duration(c.Offline.Lease) / time.Millisecond),
 	}
+	d.bus = newBusPub(c, d.redis)
 	return d
 }
 
-func newKafkaPub(c *conf.Kafka) kafka.SyncProducer {
-	kc := kafka.NewConfig()
-	kc.Producer.RequiredAcks = kafka.WaitForAll // Wait for all in-sync replicas to ack the message
-	kc.Producer.Retry.Max = 10                  // Retry up to 10 times to produce the message
-	kc.Producer.Return.Successes = true
-	pub, err := kafka.NewSyncProducer(c.Brokers, kc)
+func newBusPub(c *conf.Config, pool *redis.Pool) bus.Publisher {
+	pub, err := bus.NewPublisher(&bus.Config{
+		Type:         c.Bus.Type,
+		Brokers:      c.Kafka.Brokers,
+		KafkaVersion: c.Kafka.Version,
+		NatsURL:      c.Bus.NatsURL,
+		Redis:        pool,
+		MaxLen:       c.Bus.MaxLen,
+		Buffer:       c.Bus.Buffer,
+	})
 	if err != nil {
 		panic(err)
 	}
@@ -63,7 +73,11 @@ func newRedis(c *conf.Redis) *redis.Pool {
 
 // Close close the resource.
 func (d *Dao) Close() error {
-	return d.redis.Close()
+	busErr := d.bus.Close()
+	if err := d.redis.Close(); err != nil {
+		return err
+	}
+	return busErr
 }
 
 // Ping dao ping.
@@ -2,80 +2,255 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 
 	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/bus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+	"github.com/wcaqrl/chime/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 	"github.com/golang/protobuf/proto"
-	sarama "gopkg.in/Shopify/sarama.v1"
 )
 
-// PushMsg push a message to databus.
-func (d *Dao) PushMsg(c context.Context, op int32, server string, keys []string, msg []byte) (err error) {
+// pushPriority get the lane of a push, realtime if none.
+func pushPriority(opts *model.PushOptions) pb.PushMsg_Priority {
+	if opts.Priority == model.PriorityBulk {
+		return pb.PushMsg_BULK
+	}
+	return pb.PushMsg_REALTIME
+}
+
+// pushTopic get the topic of a push, a bulk one goes to the bulk topic if any.
+func (d *Dao) pushTopic(opts *model.PushOptions) string {
+	if opts.Priority == model.PriorityBulk && d.c.Kafka.BulkTopic != "" {
+		return d.c.Kafka.BulkTopic
+	}
+	return d.c.Kafka.Topic
+}
+
+// PushMsg push a message to the bus.
+func (d *Dao) PushMsg(c context.Context, op int32, server string, keys []string, msg []byte, opts *model.PushOptions) (err error) {
 	pushMsg := &pb.PushMsg{
-		Type:      pb.PushMsg_PUSH,
-		Operation: op,
-		Server:    server,
-		Keys:      keys,
-		Msg:       msg,
+		Type:        pb.PushMsg_PUSH,
+		Operation:   op,
+		Server:      server,
+		Keys:        keys,
+		Msg:         msg,
+		Ack:         opts.Ack,
+		Traceparent: tracing.Traceparent(c),
+		Id:          opts.ID,
+		NotBefore:   opts.NotBefore,
+		ExpireAt:    opts.ExpireAt,
+		Priority:    pushPriority(opts),
 	}
 	b, err := proto.Marshal(pushMsg)
 	if err != nil {
 		return
 	}
-	m := &sarama.ProducerMessage{
-		Key:   sarama.StringEncoder(keys[0]),
-		Topic: d.c.Kafka.Topic,
-		Value: sarama.ByteEncoder(b),
+	m := &bus.Message{
+		Topic: d.pushTopic(opts),
+		Key:   keys[0],
+		Value: b,
 	}
-	if _, _, err = d.kafkaPub.SendMessage(m); err != nil {
+	if err = d.bus.Publish(c, m); err != nil {
 		log.Errorf("PushMsg.send(push pushMsg:%v) error(%v)", pushMsg, err)
 	}
 	return
 }
 
+// PushDelayedMsg push a delayed message by keys or mids to the bus, job
+// resolves their servers when it is sent.
+func (d *Dao) PushDelayedMsg(c context.Context, op int32, keys []string, mids []int64, msg []byte, opts *model.PushOptions) (err error) {
+	pushMsg := &pb.PushMsg{
+		Type:        pb.PushMsg_PUSH,
+		Operation:   op,
+		Keys:        keys,
+		Mids:        mids,
+		Msg:         msg,
+		Ack:         opts.Ack,
+		Traceparent: tracing.Traceparent(c),
+		Id:          opts.ID,
+		NotBefore:   opts.NotBefore,
+		ExpireAt:    opts.ExpireAt,
+		Priority:    pushPriority(opts),
+	}
+	b, err := proto.Marshal(pushMsg)
+	if err != nil {
+		return
+	}
+	m := &bus.Message{
+		Topic: d.pushTopic(opts),
+		Key:   opts.ID,
+		Value: b,
+	}
+	if err = d.bus.Publish(c, m); err != nil {
+		log.Errorf("PushDelayedMsg.send(push pushMsg:%v) error(%v)", pushMsg, err)
+	}
+	return
+}
+
 // BroadcastRoomMsg push a message to databus.
-func (d *Dao) BroadcastRoomMsg(c context.Context, op int32, room string, msg []byte) (err error) {
+func (d *Dao) BroadcastRoomMsg(c context.Context, op int32, room string, seq int32, msg []byte, opts *model.PushOptions) (err error) {
 	pushMsg := &pb.PushMsg{
-		Type:      pb.PushMsg_ROOM,
-		Operation: op,
-		Room:      room,
-		Msg:       msg,
+		Type:        pb.PushMsg_ROOM,
+		Operation:   op,
+		Room:        room,
+		Msg:         msg,
+		Seq:         seq,
+		Traceparent: tracing.Traceparent(c),
+		Id:          opts.ID,
+		NotBefore:   opts.NotBefore,
+		ExpireAt:    opts.ExpireAt,
+		Priority:    pushPriority(opts),
 	}
 	b, err := proto.Marshal(pushMsg)
 	if err != nil {
 		return
 	}
-	m := &sarama.ProducerMessage{
-		Key:   sarama.StringEncoder(room),
-		Topic: d.c.Kafka.Topic,
-		Value: sarama.ByteEncoder(b),
+	m := &bus.Message{
+		Topic: d.pushTopic(opts),
+		Key:   room,
+		Value: b,
 	}
-	if _, _, err = d.kafkaPub.SendMessage(m); err != nil {
+	if err = d.bus.Publish(c, m); err != nil {
 		log.Errorf("PushMsg.send(broadcast_room pushMsg:%v) error(%v)", pushMsg, err)
 	}
 	return
 }
 
 // BroadcastMsg push a message to databus.
-func (d *Dao) BroadcastMsg(c context.Context, op, speed int32, msg []byte) (err error) {
+func (d *Dao) BroadcastMsg(c context.Context, op, speed int32, msg []byte, opts *model.PushOptions) (err error) {
 	pushMsg := &pb.PushMsg{
-		Type:      pb.PushMsg_BROADCAST,
-		Operation: op,
-		Speed:     speed,
-		Msg:       msg,
+		Type:        pb.PushMsg_BROADCAST,
+		Operation:   op,
+		Speed:       speed,
+		Msg:         msg,
+		Traceparent: tracing.Traceparent(c),
+		Id:          opts.ID,
+		NotBefore:   opts.NotBefore,
+		ExpireAt:    opts.ExpireAt,
+		Priority:    pushPriority(opts),
 	}
 	b, err := proto.Marshal(pushMsg)
 	if err != nil {
 		return
 	}
-	m := &sarama.ProducerMessage{
-		Key:   sarama.StringEncoder(strconv.FormatInt(int64(op), 10)),
-		Topic: d.c.Kafka.Topic,
-		Value: sarama.ByteEncoder(b),
+	m := &bus.Message{
+		Topic: d.pushTopic(opts),
+		Key:   strconv.FormatInt(int64(op), 10),
+		Value: b,
 	}
-	if _, _, err = d.kafkaPub.SendMessage(m); err != nil {
+	if err = d.bus.Publish(c, m); err != nil {
 		log.Errorf("PushMsg.send(broadcast pushMsg:%v) error(%v)", pushMsg, err)
 	}
 	return
 }
+
+// PushUpstream push a client upstream message to databus.
+func (d *Dao) PushUpstream(c context.Context, topic, key string, msg []byte) (err error) {
+	m := &bus.Message{
+		Topic: topic,
+		Key:   key,
+		Value: msg,
+	}
+	if err = d.bus.Publish(c, m); err != nil {
+		log.Errorf("PushUpstream.send(topic:%s key:%s) error(%v)", topic, key, err)
+	}
+	return
+}
+
+// KickMsg push a kick message of the keys on a server, or of a room, to databus.
+func (d *Dao) KickMsg(c context.Context, server string, keys []string, room string, reason int32, msg string) (err error) {
+	pushMsg := &pb.PushMsg{
+		Type:   pb.PushMsg_KICK,
+		Server: server,
+		Keys:   keys,
+		Room:   room,
+		Reason: reason,
+		Msg:    []byte(msg),
+	}
+	b, err := proto.Marshal(pushMsg)
+	if err != nil {
+		return
+	}
+	key := room
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	m := &bus.Message{
+		Topic: d.c.Kafka.Topic,
+		Key:   key,
+		Value: b,
+	}
+	if err = d.bus.Publish(c, m); err != nil {
+		log.Errorf("PushMsg.send(kick pushMsg:%v) error(%v)", pushMsg, err)
+	}
+	return
+}
+
+// MigrateMsg ask a server to migrate a number of conns to the suggested nodes.
+func (d *Dao) MigrateMsg(c context.Context, server string, count int32, nodes []string) (err error) {
+	pushMsg := &pb.PushMsg{
+		Type:   pb.PushMsg_MIGRATE,
+		Server: server,
+		Count:  count,
+		Nodes:  nodes,
+	}
+	b, err := proto.Marshal(pushMsg)
+	if err != nil {
+		return
+	}
+	m := &bus.Message{
+		Topic: d.c.Kafka.Topic,
+		Key:   server,
+		Value: b,
+	}
+	if err = d.bus.Publish(c, m); err != nil {
+		log.Errorf("PushMsg.send(migrate pushMsg:%v) error(%v)", pushMsg, err)
+	}
+	return
+}
+
+// PushPresence push a presence event to databus.
+func (d *Dao) PushPresence(c context.Context, ev *model.PresenceEvent) (err error) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	m := &bus.Message{
+		Topic: d.c.Presence.Topic,
+		Key:   strconv.FormatInt(ev.Mid, 10),
+		Value: b,
+	}
+	if err = d.bus.Publish(c, m); err != nil {
+		log.Errorf("PushPresence.send(%+v) error(%v)", ev, err)
+	}
+	return
+}
+
+// TraceMsg ask every server to trace the conns of a mid or key for ttl
+// seconds, a negative ttl stops the trace.
+func (d *Dao) TraceMsg(c context.Context, mid int64, key string, ttl int64) (err error) {
+	pushMsg := &pb.PushMsg{
+		Type: pb.PushMsg_TRACE,
+		Mid:  mid,
+		Ttl:  ttl,
+	}
+	if key != "" {
+		pushMsg.Keys = []string{key}
+	}
+	b, err := proto.Marshal(pushMsg)
+	if err != nil {
+		return
+	}
+	m := &bus.Message{
+		Topic: d.c.Kafka.Topic,
+		Key:   strconv.FormatInt(mid, 10) + key,
+		Value: b,
+	}
+	if err = d.bus.Publish(c, m); err != nil {
+		log.Errorf("PushMsg.send(trace pushMsg:%v) error(%v)", pushMsg, err)
+	}
+	return
+}
@@ -0,0 +1,135 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_prefixOfflineSeq  = "ofs_%d" // mid -> offline seq
+	_prefixOfflineMsg  = "ofl_%d" // mid -> offline messages sorted by seq
+	_prefixOfflineTake = "oft_%d" // mid -> taken within the lease
+)
+
+var (
+	// drop the msgs acked by the last seq and take the ones due unless taken
+	// within the lease if any, the expired ones are dropped and the ones not due yet
+	// stay.
+	_takeOfflineScript = redis.NewScript(2, `
+if tonumber(ARGV[1]) > 0 then
+	redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+end
+if ARGV[3] ~= '0' and not redis.call('SET', KEYS[2], '1', 'PX', ARGV[3], 'NX') then
+	return {}
+end
+local now = tonumber(ARGV[2])
+local res = {}
+for _, m in ipairs(redis.call('ZRANGE', KEYS[1], 0, -1)) do
+	local ok, msg = pcall(cjson.decode, m)
+	if not ok or (msg.exp and msg.exp > 0 and now >= msg.exp) then
+		redis.call('ZREM', KEYS[1], m)
+	elseif not (msg.nbf and msg.nbf > now) then
+		res[#res + 1] = m
+	end
+end
+return res
+`)
+)
+
+func keyOfflineSeq(mid int64) string {
+	return fmt.Sprintf(_prefixOfflineSeq, mid)
+}
+
+func keyOfflineMsg(mid int64) string {
+	return fmt.Sprintf(_prefixOfflineMsg, mid)
+}
+
+func keyOfflineTake(mid int64) string {
+	return fmt.Sprintf(_prefixOfflineTake, mid)
+}
+
+// AddOfflineMsgs store a message for the offline mids, it is not replayed
+// before notBefore nor at or after expireAt if not zero.
+func (d *Dao) AddOfflineMsgs(c context.Context, mids []int64, op int32, msg []byte, notBefore, expireAt int64) (err error) {
+	if len(mids) == 0 {
+		return
+	}
+	conn := d.redis.Get()
+	defer conn.Close()
+	// alloc a seq for every mid in one round trip
+	for _, mid := range mids {
+		if err = conn.Send("INCR", keyOfflineSeq(mid)); err != nil {
+			log.Errorf("conn.Send(INCR %d) error(%v)", mid, err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	seqs := make([]int64, len(mids))
+	for i := range mids {
+		if seqs[i], err = redis.Int64(conn.Receive()); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	// store, trim and expire the messages
+	now := time.Now().Unix()
+	for i, mid := range mids {
+		b, _ := json.Marshal(&model.OfflineMsg{Seq: int32(seqs[i]), Op: op, Msg: msg, Timestamp: now, NotBefore: notBefore, ExpireAt: expireAt})
+		key := keyOfflineMsg(mid)
+		if err = conn.Send("ZADD", key, seqs[i], b); err != nil {
+			log.Errorf("conn.Send(ZADD %d) error(%v)", mid, err)
+			return
+		}
+		if err = conn.Send("ZREMRANGEBYRANK", key, 0, -(d.c.Offline.Max + 1)); err != nil {
+			log.Errorf("conn.Send(ZREMRANGEBYRANK %d) error(%v)", mid, err)
+			return
+		}
+		if err = conn.Send("EXPIRE", key, d.offlineExpire); err != nil {
+			log.Errorf("conn.Send(EXPIRE %d) error(%v)", mid, err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < len(mids)*3; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// TakeOfflineMsgs take the offline messages of a mid due now, the ones up
+// to the last seq the client received and the expired ones are dropped. The
+// others are kept until a later connect acks them, a connect of the mid
+// within the lease of the last take gets none.
+func (d *Dao) TakeOfflineMsgs(c context.Context, mid, lastSeq int64) (msgs []*model.OfflineMsg, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	bs, err := redis.ByteSlices(_takeOfflineScript.Do(conn, keyOfflineMsg(mid), keyOfflineTake(mid), lastSeq, time.Now().Unix(), d.offlineLease))
+	if err != nil {
+		log.Errorf("takeOfflineScript.Do(%d) error(%v)", mid, err)
+		return
+	}
+	for _, b := range bs {
+		msg := new(model.OfflineMsg)
+		if err := json.Unmarshal(b, msg); err != nil {
+			log.Errorf("TakeOfflineMsgs json.Unmarshal(%s) error(%v)", b, err)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTakeOfflineMsgs(t *testing.T) {
+	d := testDao(t)
+	ctx := context.Background()
+	type msg struct {
+		nbf time.Duration // not before from now, none if zero
+		exp time.Duration // expire at from now, none if zero
+	}
+	type take struct {
+		lastSeq int64
+		want    []int32 // seqs taken
+	}
+	tests := []struct {
+		name  string
+		lease time.Duration
+		msgs  []msg
+		takes []take
+	}{
+		{
+			name:  "all due",
+			msgs:  []msg{{}, {}, {}},
+			takes: []take{{0, []int32{1, 2, 3}}},
+		},
+		{
+			name:  "kept until acked",
+			msgs:  []msg{{}, {}, {}},
+			takes: []take{{0, []int32{1, 2, 3}}, {2, []int32{3}}, {3, nil}},
+		},
+		{
+			name:  "not due does not hold the later ones",
+			msgs:  []msg{{}, {nbf: time.Hour}, {}},
+			takes: []take{{0, []int32{1, 3}}, {3, nil}},
+		},
+		{
+			name:  "expired dropped",
+			msgs:  []msg{{exp: -time.Second}, {}, {exp: time.Hour}},
+			takes: []take{{0, []int32{2, 3}}},
+		},
+		{
+			name:  "taken once within the lease",
+			lease: time.Minute,
+			msgs:  []msg{{}, {}},
+			takes: []take{{0, []int32{1, 2}}, {0, nil}},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mid := time.Now().UnixNano() + int64(i)
+			defer func() {
+				conn := d.redis.Get()
+				_, _ = conn.Do("DEL", keyOfflineSeq(mid), keyOfflineMsg(mid), keyOfflineTake(mid))
+				conn.Close()
+			}()
+			d.offlineLease = int64(tt.lease / time.Millisecond)
+			now := time.Now()
+			for _, m := range tt.msgs {
+				var nbf, exp int64
+				if m.nbf != 0 {
+					nbf = now.Add(m.nbf).Unix()
+				}
+				if m.exp != 0 {
+					exp = now.Add(m.exp).Unix()
+				}
+				if err := d.AddOfflineMsgs(ctx, []int64{mid}, 1000, []byte("msg"), nbf, exp); err != nil {
+					t.Fatalf("AddOfflineMsgs() error(%v)", err)
+				}
+			}
+			for j, tk := range tt.takes {
+				msgs, err := d.TakeOfflineMsgs(ctx, mid, tk.lastSeq)
+				if err != nil {
+					t.Fatalf("take %d TakeOfflineMsgs() error(%v)", j, err)
+				}
+				var got []int32
+				for _, m := range msgs {
+					got = append(got, m.Seq)
+				}
+				if !reflect.DeepEqual(got, tk.want) {
+					t.Fatalf("take %d got %v, want %v", j, got, tk.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_keyPresenceExpire  = "presence_expire"  // key -> heartbeat deadline
+	_keyPresenceInfo    = "presence_info"    // key -> presence event
+	_keyPresenceOffline = "presence_offline" // mid -> debounce deadline of its offline
+	_keyPresencePending = "presence_pending" // mid -> debounced offline event
+)
+
+var (
+	// renew the heartbeat deadline of a tracked key.
+	_renewPresenceScript = redis.NewScript(1, `
+if not redis.call('ZSCORE', KEYS[1], ARGV[2]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+)
+
+// AddPresence track a connected key and return the number of keys of its mid.
+func (d *Dao) AddPresence(c context.Context, ev *model.PresenceEvent) (keys int, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	b, _ := json.Marshal(ev)
+	if err = conn.Send("ZADD", _keyPresenceExpire, time.Now().Unix()+int64(d.redisExpire), ev.Key); err != nil {
+		log.Errorf("conn.Send(ZADD %s) error(%v)", ev.Key, err)
+		return
+	}
+	if err = conn.Send("HSET", _keyPresenceInfo, ev.Key, b); err != nil {
+		log.Errorf("conn.Send(HSET %s) error(%v)", ev.Key, err)
+		return
+	}
+	if err = conn.Send("HLEN", keyMidServer(ev.Mid)); err != nil {
+		log.Errorf("conn.Send(HLEN %d) error(%v)", ev.Mid, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	if keys, err = redis.Int(conn.Receive()); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+	}
+	return
+}
+
+// RenewPresence renew the heartbeat deadline of a key, false if not tracked.
+func (d *Dao) RenewPresence(c context.Context, key string) (ok bool, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if ok, err = redis.Bool(_renewPresenceScript.Do(conn, _keyPresenceExpire, time.Now().Unix()+int64(d.redisExpire), key)); err != nil {
+		log.Errorf("renewPresence(%s) error(%v)", key, err)
+	}
+	return
+}
+
+// DelPresence untrack a key and return its presence and the number of keys
+// left of its mid, ev is nil if the key was already untracked.
+// NOTE: the mid mapping of the key is deleted too, for an expired key leaves it behind.
+func (d *Dao) DelPresence(c context.Context, key string) (ev *model.PresenceEvent, keys int, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if err = conn.Send("ZREM", _keyPresenceExpire, key); err != nil {
+		log.Errorf("conn.Send(ZREM %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("HGET", _keyPresenceInfo, key); err != nil {
+		log.Errorf("conn.Send(HGET %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("HDEL", _keyPresenceInfo, key); err != nil {
+		log.Errorf("conn.Send(HDEL %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	var (
+		claimed bool
+		b       []byte
+	)
+	if claimed, err = redis.Bool(conn.Receive()); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	if b, err = redis.Bytes(conn.Receive()); err != nil && err != redis.ErrNil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	if _, err = conn.Receive(); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	// another logic has claimed the key
+	if !claimed || len(b) == 0 {
+		return
+	}
+	ev = new(model.PresenceEvent)
+	if err = json.Unmarshal(b, ev); err != nil {
+		log.Errorf("DelPresence json.Unmarshal(%s) error(%v)", b, err)
+		return nil, 0, err
+	}
+	if err = conn.Send("HDEL", keyMidServer(ev.Mid), key); err != nil {
+		log.Errorf("conn.Send(HDEL %d,%s) error(%v)", ev.Mid, key, err)
+		return
+	}
+	if err = conn.Send("HLEN", keyMidServer(ev.Mid)); err != nil {
+		log.Errorf("conn.Send(HLEN %d) error(%v)", ev.Mid, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	if _, err = conn.Receive(); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	if keys, err = redis.Int(conn.Receive()); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+	}
+	return
+}
+
+// ExpiredPresences get the keys whose heartbeat deadline passed.
+func (d *Dao) ExpiredPresences(c context.Context, limit int) (keys []string, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if keys, err = redis.Strings(conn.Do("ZRANGEBYSCORE", _keyPresenceExpire, "-inf", time.Now().Unix(), "LIMIT", 0, limit)); err != nil {
+		log.Errorf("conn.Do(ZRANGEBYSCORE %s) error(%v)", _keyPresenceExpire, err)
+	}
+	return
+}
+
+// AddPresenceOffline hold the offline of a mid until the deadline, any logic
+// publishes it then unless the mid came back.
+func (d *Dao) AddPresenceOffline(c context.Context, ev *model.PresenceEvent, deadline int64) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	b, _ := json.Marshal(ev)
+	if err = conn.Send("HSET", _keyPresencePending, ev.Mid, b); err != nil {
+		log.Errorf("conn.Send(HSET %d) error(%v)", ev.Mid, err)
+		return
+	}
+	if err = conn.Send("ZADD", _keyPresenceOffline, deadline, ev.Mid); err != nil {
+		log.Errorf("conn.Send(ZADD %d) error(%v)", ev.Mid, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// TakePresenceOffline claim the held offline of a mid, ev is nil if none or
+// claimed by another logic.
+func (d *Dao) TakePresenceOffline(c context.Context, mid int64) (ev *model.PresenceEvent, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	claimed, err := redis.Bool(conn.Do("ZREM", _keyPresenceOffline, mid))
+	if err != nil {
+		log.Errorf("conn.Do(ZREM %d) error(%v)", mid, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+	if err = conn.Send("HGET", _keyPresencePending, mid); err != nil {
+		log.Errorf("conn.Send(HGET %d) error(%v)", mid, err)
+		return
+	}
+	if err = conn.Send("HDEL", _keyPresencePending, mid); err != nil {
+		log.Errorf("conn.Send(HDEL %d) error(%v)", mid, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	b, err := redis.Bytes(conn.Receive())
+	if err != nil && err != redis.ErrNil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	if _, err = conn.Receive(); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	if len(b) == 0 {
+		return
+	}
+	ev = new(model.PresenceEvent)
+	if err = json.Unmarshal(b, ev); err != nil {
+		log.Errorf("TakePresenceOffline json.Unmarshal(%s) error(%v)", b, err)
+		return nil, err
+	}
+	return
+}
+
+// DuePresenceOfflines get the mids whose held offline is due.
+func (d *Dao) DuePresenceOfflines(c context.Context, limit int) (mids []int64, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if mids, err = redis.Int64s(conn.Do("ZRANGEBYSCORE", _keyPresenceOffline, "-inf", time.Now().Unix(), "LIMIT", 0, limit)); err != nil {
+		log.Errorf("conn.Do(ZRANGEBYSCORE %s) error(%v)", _keyPresenceOffline, err)
+	}
+	return
+}
+
+// KeyPresences get the presence of keys in one pipelined round trip.
+func (d *Dao) KeyPresences(c context.Context, keys []string, info bool) (res []*model.KeyPresence, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	for _, key := range keys {
+		if err = conn.Send("GET", keyKeyServer(key)); err != nil {
+			log.Errorf("conn.Send(GET %s) error(%v)", key, err)
+			return
+		}
+		if info {
+			if err = conn.Send("GET", keyKeyInfo(key)); err != nil {
+				log.Errorf("conn.Send(GET %s) error(%v)", key, err)
+				return
+			}
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	res = make([]*model.KeyPresence, 0, len(keys))
+	for _, key := range keys {
+		var server string
+		if server, err = redis.String(conn.Receive()); err != nil && err != redis.ErrNil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+		kp := &model.KeyPresence{Key: key, Server: server, Online: server != ""}
+		if info {
+			var b []byte
+			if b, err = redis.Bytes(conn.Receive()); err != nil && err != redis.ErrNil {
+				log.Errorf("conn.Receive() error(%v)", err)
+				return
+			}
+			fillKeyInfo(kp, b)
+		}
+		res = append(res, kp)
+	}
+	err = nil
+	return
+}
+
+// MidPresences get the presence of mids, one pipelined round trip for the
+// mappings and another one for the key infos if required.
+func (d *Dao) MidPresences(c context.Context, mids []int64, info bool) (res []*model.MidPresence, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	for _, mid := range mids {
+		if err = conn.Send("HGETALL", keyMidServer(mid)); err != nil {
+			log.Errorf("conn.Send(HGETALL %d) error(%v)", mid, err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	var kps []*model.KeyPresence
+	res = make([]*model.MidPresence, 0, len(mids))
+	for _, mid := range mids {
+		var keys map[string]string
+		if keys, err = redis.StringMap(conn.Receive()); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+		mp := &model.MidPresence{Mid: mid, Online: len(keys) > 0, Keys: make([]*model.KeyPresence, 0, len(keys))}
+		for key, server := range keys {
+			kp := &model.KeyPresence{Key: key, Mid: mid, Server: server, Online: true}
+			mp.Keys = append(mp.Keys, kp)
+			kps = append(kps, kp)
+		}
+		res = append(res, mp)
+	}
+	if !info || len(kps) == 0 {
+		return
+	}
+	args := make([]interface{}, 0, len(kps))
+	for _, kp := range kps {
+		args = append(args, keyKeyInfo(kp.Key))
+	}
+	bs, err := redis.ByteSlices(conn.Do("MGET", args...))
+	if err != nil {
+		log.Errorf("conn.Do(MGET %d keys) error(%v)", len(args), err)
+		return
+	}
+	for i, b := range bs {
+		fillKeyInfo(kps[i], b)
+	}
+	return
+}
+
+func fillKeyInfo(kp *model.KeyPresence, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	info := new(model.KeyInfo)
+	if err := json.Unmarshal(b, info); err != nil {
+		log.Errorf("fillKeyInfo json.Unmarshal(%s) error(%v)", b, err)
+		return
+	}
+	if kp.Mid == 0 {
+		kp.Mid = info.Mid
+	}
+	kp.Room = info.Room
+	kp.Platform = info.Platform
+	kp.Connected = info.Connected
+}
@@ -0,0 +1,198 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_prefixIdempotency  = "idem_%s" // idempotency key -> push id, prefixed by p: until it is pushed
+	_pendingIdempotency = "p:"
+	_prefixPushStatus   = "pst_%s" // push id -> delivery status
+	_fieldPushServer    = "s:"     // prefix of the status fields counting the deliveries of a comet
+)
+
+var (
+	// bind an idempotency key to a pending push id unless bound already, it
+	// returns the value bound.
+	_setIdempotencyScript = redis.NewScript(1, `
+local v = redis.call('GET', KEYS[1])
+if v then
+	return v
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return ARGV[1]
+`)
+	// commit the pending push id of an idempotency key for the window.
+	_commitIdempotencyScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+	// release an idempotency key still bound to the pending push id.
+	_delIdempotencyScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
+	// count the deliveries a comet reported, ignored once the status expired.
+	_incrPushScript = redis.NewScript(1, `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HINCRBY', KEYS[1], 'delivered', ARGV[2])
+redis.call('HINCRBY', KEYS[1], 'dropped', ARGV[3])
+redis.call('HINCRBY', KEYS[1], 's:' .. ARGV[1], ARGV[2])
+return 1
+`)
+)
+
+func keyIdempotency(key string) string {
+	return fmt.Sprintf(_prefixIdempotency, key)
+}
+
+func keyPushStatus(id string) string {
+	return fmt.Sprintf(_prefixPushStatus, id)
+}
+
+// SetIdempotency bind an idempotency key to the pending push id for the
+// window, it returns the id the key is bound to and whether that push is
+// still pending.
+func (d *Dao) SetIdempotency(c context.Context, key, id string, window time.Duration) (bound string, pending bool, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if bound, err = redis.String(_setIdempotencyScript.Do(conn, keyIdempotency(key), _pendingIdempotency+id, int64(window/time.Millisecond))); err != nil {
+		log.Errorf("setIdempotency(%s) error(%v)", keyIdempotency(key), err)
+		return
+	}
+	if strings.HasPrefix(bound, _pendingIdempotency) {
+		return strings.TrimPrefix(bound, _pendingIdempotency), true, nil
+	}
+	return bound, false, nil
+}
+
+// CommitIdempotency bind an idempotency key to the push id once pushed, the
+// retries within the window get the id.
+func (d *Dao) CommitIdempotency(c context.Context, key, id string, window time.Duration) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = _commitIdempotencyScript.Do(conn, keyIdempotency(key), _pendingIdempotency+id, id, int64(window/time.Millisecond)); err != nil {
+		log.Errorf("commitIdempotency(%s) error(%v)", keyIdempotency(key), err)
+	}
+	return
+}
+
+// DelIdempotency release an idempotency key still pending the push id, the
+// push failed.
+func (d *Dao) DelIdempotency(c context.Context, key, id string) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = _delIdempotencyScript.Do(conn, keyIdempotency(key), _pendingIdempotency+id); err != nil {
+		log.Errorf("delIdempotency(%s) error(%v)", keyIdempotency(key), err)
+	}
+	return
+}
+
+// AddPushStatus record the fan-out of a push for expire.
+func (d *Dao) AddPushStatus(c context.Context, st *model.PushStatus, expire time.Duration) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	key := keyPushStatus(st.ID)
+	if err = conn.Send("HSET", key, "type", st.Type, "targets", st.Targets, "comets", st.Comets,
+		"offline", st.Offline, "created", st.Created); err != nil {
+		log.Errorf("conn.Send(HSET %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", key, int64(expire/time.Second)); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// IncrPushDeliveries count the deliveries of the pushes reported by a comet.
+func (d *Dao) IncrPushDeliveries(c context.Context, server string, counts map[string]*pb.DeliveryCount) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if err = _incrPushScript.Load(conn); err != nil {
+		log.Errorf("incrPushScript.Load() error(%v)", err)
+		return
+	}
+	for id, count := range counts {
+		if err = _incrPushScript.SendHash(conn, keyPushStatus(id), server, count.Delivered, count.Dropped); err != nil {
+			log.Errorf("incrPushScript.SendHash(%s) error(%v)", id, err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < len(counts); i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// PushStatus get the status of a push, nil if none or expired.
+func (d *Dao) PushStatus(c context.Context, id string) (st *model.PushStatus, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	fields, err := redis.StringMap(conn.Do("HGETALL", keyPushStatus(id)))
+	if err != nil {
+		log.Errorf("conn.Do(HGETALL %s) error(%v)", keyPushStatus(id), err)
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+	st = &model.PushStatus{ID: id, Servers: make(map[string]int64)}
+	for field, value := range fields {
+		n, _ := strconv.ParseInt(value, 10, 64)
+		switch field {
+		case "type":
+			st.Type = value
+		case "targets":
+			st.Targets = n
+		case "comets":
+			st.Comets = n
+		case "offline":
+			st.Offline = n
+		case "created":
+			st.Created = n
+		case "delivered":
+			st.Delivered = n
+		case "dropped":
+			st.Dropped = n
+		default:
+			if strings.HasPrefix(field, _fieldPushServer) {
+				st.Servers[strings.TrimPrefix(field, _fieldPushServer)] = n
+			}
+		}
+	}
+	return
+}
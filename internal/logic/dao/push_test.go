@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/wcaqrl/chime/internal/logic/conf"
+)
+
+// testDao new a dao on the redis of CHIME_TEST_REDIS, the test is skipped if
+// it is not reachable.
+func testDao(t *testing.T) *Dao {
+	addr := os.Getenv("CHIME_TEST_REDIS")
+	if addr == "" {
+		addr = "127.0.0.1:6379"
+	}
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr, redis.DialConnectTimeout(200*time.Millisecond))
+		},
+	}
+	conn := pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("PING"); err != nil {
+		pool.Close()
+		t.Skipf("redis %s not available: %v", addr, err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return &Dao{c: conf.Default(), redis: pool, redisExpire: 60, offlineExpire: 60}
+}
+
+func TestIdempotency(t *testing.T) {
+	d := testDao(t)
+	ctx := context.Background()
+	type step struct {
+		op      string // set, commit or del
+		id      string
+		bound   string
+		pending bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "first push binds pending",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+			},
+		},
+		{
+			name: "retry while pending gets the pending id",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+				{op: "set", id: "b", bound: "a", pending: true},
+			},
+		},
+		{
+			name: "retry after commit gets the pushed id",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+				{op: "commit", id: "a"},
+				{op: "set", id: "b", bound: "a", pending: false},
+			},
+		},
+		{
+			name: "failed push releases the key",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+				{op: "del", id: "a"},
+				{op: "set", id: "b", bound: "b", pending: true},
+			},
+		},
+		{
+			name: "stale del keeps the key of another push",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+				{op: "del", id: "b"},
+				{op: "set", id: "c", bound: "a", pending: true},
+			},
+		},
+		{
+			name: "commit of another push is ignored",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+				{op: "commit", id: "b"},
+				{op: "set", id: "c", bound: "a", pending: true},
+			},
+		},
+		{
+			name: "del after commit keeps the key",
+			steps: []step{
+				{op: "set", id: "a", bound: "a", pending: true},
+				{op: "commit", id: "a"},
+				{op: "del", id: "a"},
+				{op: "set", id: "b", bound: "a", pending: false},
+			},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := fmt.Sprintf("test_%d_%d", time.Now().UnixNano(), i)
+			defer func() {
+				conn := d.redis.Get()
+				_, _ = conn.Do("DEL", keyIdempotency(key))
+				conn.Close()
+			}()
+			for j, s := range tt.steps {
+				var err error
+				switch s.op {
+				case "set":
+					var (
+						bound   string
+						pending bool
+					)
+					if bound, pending, err = d.SetIdempotency(ctx, key, s.id, time.Minute); err == nil && (bound != s.bound || pending != s.pending) {
+						t.Fatalf("step %d SetIdempotency(%s) = %s pending:%v, want %s pending:%v", j, s.id, bound, pending, s.bound, s.pending)
+					}
+				case "commit":
+					err = d.CommitIdempotency(ctx, key, s.id, time.Minute)
+				case "del":
+					err = d.DelIdempotency(ctx, key, s.id)
+				}
+				if err != nil {
+					t.Fatalf("step %d %s(%s) error(%v)", j, s.op, s.id, err)
+				}
+			}
+		})
+	}
+}
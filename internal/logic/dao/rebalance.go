@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	_keyRebalanceLock  = "rebalance_lock"
+	_prefixMigrated    = "migrated_%s" // server -> conns migrated in the window
+	_migratedWindowSec = 60
+)
+
+func keyMigrated(server string) string {
+	return fmt.Sprintf(_prefixMigrated, server)
+}
+
+// RebalanceLock take the rebalance lock for ttl, false if another logic holds it.
+func (d *Dao) RebalanceLock(c context.Context, owner string, ttl time.Duration) (ok bool, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	reply, err := redis.String(conn.Do("SET", _keyRebalanceLock, owner, "NX", "PX", int64(ttl/time.Millisecond)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		log.Errorf("conn.Do(SET NX %s) error(%v)", _keyRebalanceLock, err)
+		return
+	}
+	return reply == "OK", nil
+}
+
+// Migrated get the conns of a server migrated in the current minute.
+func (d *Dao) Migrated(c context.Context, server string) (n int64, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if n, err = redis.Int64(conn.Do("GET", keyMigrated(server))); err != nil {
+		if err == redis.ErrNil {
+			return 0, nil
+		}
+		log.Errorf("conn.Do(GET %s) error(%v)", keyMigrated(server), err)
+	}
+	return
+}
+
+// AddMigrated count the conns of a server migrated in the current minute.
+func (d *Dao) AddMigrated(c context.Context, server string, n int64) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	total, err := redis.Int64(conn.Do("INCRBY", keyMigrated(server), n))
+	if err != nil {
+		log.Errorf("conn.Do(INCRBY %s) error(%v)", keyMigrated(server), err)
+		return
+	}
+	// the window starts with its first migration
+	if total == n {
+		if _, err = conn.Do("EXPIRE", keyMigrated(server), _migratedWindowSec); err != nil {
+			log.Errorf("conn.Do(EXPIRE %s) error(%v)", keyMigrated(server), err)
+		}
+	}
+	return
+}
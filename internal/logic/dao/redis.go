@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/wcaqrl/chime/internal/logic/model"
 	log "github.com/sirupsen/logrus"
@@ -14,9 +15,12 @@ import (
 )
 
 const (
-	_prefixMidServer    = "mid_%d" // mid -> key:server
-	_prefixKeyServer    = "key_%s" // key -> server
-	_prefixServerOnline = "ol_%s"  // server -> online
+	_prefixMidServer    = "mid_%d"   // mid -> key:server
+	_prefixKeyServer    = "key_%s"   // key -> server
+	_prefixServerOnline = "ol_%s"    // server -> online
+	_prefixKeyInfo      = "kinfo_%s" // key -> connect info
+	_prefixRoomSeq      = "rseq_%s"  // room -> seq of the last room msg
+	_prefixKeySeq       = "kseq_%s"  // key -> seq of the last key push
 )
 
 func keyMidServer(mid int64) string {
@@ -31,6 +35,18 @@ func keyServerOnline(key string) string {
 	return fmt.Sprintf(_prefixServerOnline, key)
 }
 
+func keyKeyInfo(key string) string {
+	return fmt.Sprintf(_prefixKeyInfo, key)
+}
+
+func keyRoomSeq(room string) string {
+	return fmt.Sprintf(_prefixRoomSeq, room)
+}
+
+func keyKeySeq(key string) string {
+	return fmt.Sprintf(_prefixKeySeq, key)
+}
+
 // pingRedis check redis connection.
 func (d *Dao) pingRedis(c context.Context) (err error) {
 	conn := d.redis.Get()
@@ -39,14 +55,27 @@ func (d *Dao) pingRedis(c context.Context) (err error) {
 	return
 }
 
-// AddMapping add a mapping.
+// AddMapping add a mapping, the info is kept when nil.
 // Mapping:
 //	mid -> key_server
 //	key -> server
-func (d *Dao) AddMapping(c context.Context, mid int64, key, server string) (err error) {
+//	key -> info
+func (d *Dao) AddMapping(c context.Context, mid int64, key, server string, info *model.KeyInfo) (err error) {
 	conn := d.redis.Get()
 	defer conn.Close()
 	var n = 2
+	if info != nil {
+		b, _ := json.Marshal(info)
+		if err = conn.Send("SET", keyKeyInfo(key), b); err != nil {
+			log.Errorf("conn.Send(SET %d,%s,%s) error(%v)", mid, key, b, err)
+			return
+		}
+		if err = conn.Send("EXPIRE", keyKeyInfo(key), d.redisExpire); err != nil {
+			log.Errorf("conn.Send(EXPIRE %d,%s) error(%v)", mid, key, err)
+			return
+		}
+		n += 2
+	}
 	if mid > 0 {
 		if err = conn.Send("HSET", keyMidServer(mid), key, server); err != nil {
 			log.Errorf("conn.Send(HSET %d,%s,%s) error(%v)", mid, server, key, err)
@@ -91,6 +120,12 @@ func (d *Dao) ExpireMapping(c context.Context, mid int64, key string) (has bool,
 		}
 		n++
 	}
+	if err = conn.Send("EXPIRE", keyKeyInfo(key), d.redisExpire); err != nil {
+		log.Errorf("conn.Send(EXPIRE %d,%s) error(%v)", mid, key, err)
+		return
+	}
+	n++
+	// NOTE: the key mapping must be the last reply, has is taken from it
 	if err = conn.Send("EXPIRE", keyKeyServer(key), d.redisExpire); err != nil {
 		log.Errorf("conn.Send(EXPIRE %d,%s) error(%v)", mid, key, err)
 		return
@@ -120,6 +155,11 @@ func (d *Dao) DelMapping(c context.Context, mid int64, key, server string) (has
 		}
 		n++
 	}
+	if err = conn.Send("DEL", keyKeyInfo(key)); err != nil {
+		log.Errorf("conn.Send(DEL %d,%s,%s) error(%v)", mid, key, server, err)
+		return
+	}
+	n++
 	if err = conn.Send("DEL", keyKeyServer(key)); err != nil {
 		log.Errorf("conn.Send(HDEL %d,%s,%s) error(%v)", mid, key, server, err)
 		return
@@ -277,3 +317,65 @@ func (d *Dao) DelServerOnline(c context.Context, server string) (err error) {
 	}
 	return
 }
+
+// IncrRoomSeq alloc the seq of a room msg, the seq expires once the room is
+// idle for expire.
+func (d *Dao) IncrRoomSeq(c context.Context, room string, expire time.Duration) (seq int32, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	key := keyRoomSeq(room)
+	if err = conn.Send("INCR", key); err != nil {
+		log.Errorf("conn.Send(INCR %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", key, int64(expire/time.Second)); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	n, err := redis.Int64(conn.Receive())
+	if err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	if _, err = conn.Receive(); err != nil {
+		log.Errorf("conn.Receive() error(%v)", err)
+		return
+	}
+	return int32(n), nil
+}
+
+// raise the seq of a key, a late report of an older conn never takes it back.
+var _setKeySeqScript = redis.NewScript(1, `
+if tonumber(ARGV[1]) > tonumber(redis.call('GET', KEYS[1]) or '0') then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+
+// SetKeySeq keep the seq of the last push of a key, the seq expires once the
+// key is offline for expire.
+func (d *Dao) SetKeySeq(c context.Context, key string, seq int32, expire time.Duration) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = _setKeySeqScript.Do(conn, keyKeySeq(key), seq, int64(expire/time.Second)); err != nil {
+		log.Errorf("setKeySeq(%s) error(%v)", keyKeySeq(key), err)
+	}
+	return
+}
+
+// KeySeq get the seq of the last push of a key, 0 if none.
+func (d *Dao) KeySeq(c context.Context, key string) (seq int32, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	n, err := redis.Int64(conn.Do("GET", keyKeySeq(key)))
+	if err != nil && err != redis.ErrNil {
+		log.Errorf("conn.Do(GET %s) error(%v)", keyKeySeq(key), err)
+		return
+	}
+	return int32(n), nil
+}
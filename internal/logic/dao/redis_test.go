@@ -0,0 +1,45 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestKeySeq(t *testing.T) {
+	d := testDao(t)
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		sets []int32
+		want int32
+	}{
+		{"none", nil, 0},
+		{"set", []int32{5}, 5},
+		{"raised", []int32{5, 9}, 9},
+		{"late report of an older conn", []int32{9, 5}, 9},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := fmt.Sprintf("test_%d_%d", time.Now().UnixNano(), i)
+			defer func() {
+				conn := d.redis.Get()
+				_, _ = conn.Do("DEL", keyKeySeq(key))
+				conn.Close()
+			}()
+			for _, seq := range tt.sets {
+				if err := d.SetKeySeq(ctx, key, seq, time.Minute); err != nil {
+					t.Fatalf("SetKeySeq(%d) error(%v)", seq, err)
+				}
+			}
+			seq, err := d.KeySeq(ctx, key)
+			if err != nil {
+				t.Fatalf("KeySeq() error(%v)", err)
+			}
+			if seq != tt.want {
+				t.Fatalf("KeySeq() = %d, want %d", seq, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_prefixResumeInfo  = "rinfo_%s" // key -> resume slot info
+	_prefixResumeSlot  = "rslot_%s" // key -> resume slot entries, seq:data
+	_prefixResumeRoom  = "rroom_%s" // room -> detached keys
+	_prefixResumeToken = "rjti_%s"  // token id -> used
+	_keyResumeAll      = "rall"     // detached keys
+	_defaultResumeSlot = 32
+)
+
+var (
+	// append a frame to an existing slot accepting the op if not zero,
+	// trimmed to its max entries.
+	_appendResumeScript = redis.NewScript(2, `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+if ARGV[3] ~= '0' then
+	local accepts = redis.call('HGET', KEYS[1], 'accepts') or ''
+	if not string.find(',' .. accepts .. ',', ',' .. ARGV[3] .. ',', 1, true) then
+		return 0
+	end
+end
+local seq = redis.call('HINCRBY', KEYS[1], 'seq', ARGV[2])
+local max = tonumber(redis.call('HGET', KEYS[1], 'max'))
+redis.call('RPUSH', KEYS[2], seq .. ':' .. ARGV[1])
+redis.call('LTRIM', KEYS[2], -max, -1)
+redis.call('PEXPIRE', KEYS[2], redis.call('PTTL', KEYS[1]))
+return seq
+`)
+	// take a slot if the token matches, the key is no longer detached.
+	_takeResumeScript = redis.NewScript(3, `
+if redis.call('HGET', KEYS[1], 'token') ~= ARGV[1] then
+	return false
+end
+local info = redis.call('HGETALL', KEYS[1])
+local entries = redis.call('LRANGE', KEYS[2], 0, -1)
+local room = redis.call('HGET', KEYS[1], 'room')
+if room and room ~= '' then
+	redis.call('SREM', ARGV[2] .. room, ARGV[3])
+end
+redis.call('SREM', KEYS[3], ARGV[3])
+redis.call('DEL', KEYS[1], KEYS[2])
+return {info, entries}
+`)
+	// sync the protos written to a conn to its slot if the token matches, a
+	// stale sync racing the detach is ignored.
+	_syncResumeScript = redis.NewScript(2, `
+if redis.call('HGET', KEYS[1], 'token') ~= ARGV[1] then
+	return 0
+end
+if tonumber(redis.call('HGET', KEYS[1], 'seq') or '0') >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'seq', ARGV[2], 'room', ARGV[3], 'accepts', ARGV[4], 'kseq', ARGV[5])
+if #ARGV > 5 then
+	local max = tonumber(redis.call('HGET', KEYS[1], 'max'))
+	redis.call('RPUSH', KEYS[2], unpack(ARGV, 6))
+	redis.call('LTRIM', KEYS[2], -max, -1)
+	redis.call('PEXPIRE', KEYS[2], redis.call('PTTL', KEYS[1]))
+end
+return 1
+`)
+)
+
+func keyResumeInfo(key string) string {
+	return fmt.Sprintf(_prefixResumeInfo, key)
+}
+
+func keyResumeSlot(key string) string {
+	return fmt.Sprintf(_prefixResumeSlot, key)
+}
+
+func keyResumeToken(id string) string {
+	return fmt.Sprintf(_prefixResumeToken, id)
+}
+
+func keyResumeRoom(room string) string {
+	return fmt.Sprintf(_prefixResumeRoom, room)
+}
+
+func resumeEntry(e *model.ResumeEntry) []byte {
+	return append([]byte(strconv.FormatInt(e.Seq, 10)+":"), e.Data...)
+}
+
+func encodeAccepts(accepts []int32) string {
+	strs := make([]string, 0, len(accepts))
+	for _, op := range accepts {
+		strs = append(strs, strconv.FormatInt(int64(op), 10))
+	}
+	return strings.Join(strs, ",")
+}
+
+func decodeAccepts(s string) (accepts []int32) {
+	for _, str := range strings.Split(s, ",") {
+		if op, err := strconv.ParseInt(str, 10, 32); err == nil {
+			accepts = append(accepts, int32(op))
+		}
+	}
+	return
+}
+
+// ClaimResumeToken mark a resume token used, ok is false if it was already
+// used. The mark lives as long as the slot the token could resume.
+func (d *Dao) ClaimResumeToken(c context.Context, id string) (ok bool, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	key := keyResumeToken(id)
+	reply, err := redis.String(conn.Do("SET", key, "1", "EX", d.redisExpire, "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			err = nil
+			return
+		}
+		log.Errorf("conn.Do(SET %s NX) error(%v)", key, err)
+		return
+	}
+	ok = reply == "OK"
+	return
+}
+
+// OpenResumeSlot open the resume slot of a connected key, it lives as long as
+// the mapping of the key and the protos written to the conn are synced to it.
+func (d *Dao) OpenResumeSlot(c context.Context, key string, slot *model.ResumeSlot) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	max := slot.Max
+	if max <= 0 {
+		max = _defaultResumeSlot
+	}
+	if err = conn.Send("DEL", keyResumeInfo(key), keyResumeSlot(key)); err != nil {
+		log.Errorf("conn.Send(DEL %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("HSET", keyResumeInfo(key), "token", slot.Token, "mid", slot.Mid, "room", slot.Room,
+		"accepts", encodeAccepts(slot.Accepts), "seq", slot.Seq, "kseq", slot.KeySeq, "max", max); err != nil {
+		log.Errorf("conn.Send(HSET %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", keyResumeInfo(key), d.redisExpire); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// ExpireResumeSlot renew the resume slot of a connected key with its mapping.
+func (d *Dao) ExpireResumeSlot(c context.Context, key string) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if err = conn.Send("EXPIRE", keyResumeInfo(key), d.redisExpire); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", keyResumeSlot(key), d.redisExpire); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// DelResumeSlot del the resume slot of a key closed for good.
+func (d *Dao) DelResumeSlot(c context.Context, key string) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = conn.Do("DEL", keyResumeInfo(key), keyResumeSlot(key)); err != nil {
+		log.Errorf("conn.Do(DEL %s) error(%v)", key, err)
+	}
+	return
+}
+
+// SyncResumeSlots append the protos written to the conns to the slots of their
+// keys, the slots gone or reopened are skipped.
+func (d *Dao) SyncResumeSlots(c context.Context, slots map[string]*model.ResumeSlot) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	for key, slot := range slots {
+		args := redis.Args{keyResumeInfo(key), keyResumeSlot(key), slot.Token, slot.Seq, slot.Room, encodeAccepts(slot.Accepts), slot.KeySeq}
+		for _, e := range slot.Entries {
+			args = args.Add(resumeEntry(e))
+		}
+		if err = _syncResumeScript.Send(conn, args...); err != nil {
+			log.Errorf("syncResume(%s) error(%v)", key, err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for range slots {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// DetachResumeSlot detach a key into its resume slot for ttl, the slot is
+// replaced by the last protos written to the conn. The key is mapped to
+// model.ServerResume meanwhile so the msgs pushed to it, its room and all are
+// kept in the slot.
+func (d *Dao) DetachResumeSlot(c context.Context, key string, slot *model.ResumeSlot, ttl time.Duration) (err error) {
+	var (
+		conn = d.redis.Get()
+		sec  = int64(ttl / time.Second)
+		max  = slot.Max
+		n    = 5
+	)
+	defer conn.Close()
+	if max <= 0 {
+		max = _defaultResumeSlot
+	}
+	if err = conn.Send("DEL", keyResumeInfo(key), keyResumeSlot(key)); err != nil {
+		log.Errorf("conn.Send(DEL %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("HSET", keyResumeInfo(key), "token", slot.Token, "mid", slot.Mid, "room", slot.Room,
+		"accepts", encodeAccepts(slot.Accepts), "seq", slot.Seq, "kseq", slot.KeySeq, "max", max); err != nil {
+		log.Errorf("conn.Send(HSET %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", keyResumeInfo(key), sec); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if len(slot.Entries) > 0 {
+		args := redis.Args{keyResumeSlot(key)}
+		for _, e := range slot.Entries {
+			args = args.Add(resumeEntry(e))
+		}
+		if err = conn.Send("RPUSH", args...); err != nil {
+			log.Errorf("conn.Send(RPUSH %s) error(%v)", key, err)
+			return
+		}
+		if err = conn.Send("EXPIRE", keyResumeSlot(key), sec); err != nil {
+			log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+			return
+		}
+		n += 2
+	}
+	if slot.Mid > 0 {
+		if err = conn.Send("HSET", keyMidServer(slot.Mid), key, model.ServerResume); err != nil {
+			log.Errorf("conn.Send(HSET %d,%s) error(%v)", slot.Mid, key, err)
+			return
+		}
+		n++
+	}
+	if slot.Room != "" {
+		if err = conn.Send("SADD", keyResumeRoom(slot.Room), key); err != nil {
+			log.Errorf("conn.Send(SADD %s,%s) error(%v)", slot.Room, key, err)
+			return
+		}
+		if err = conn.Send("EXPIRE", keyResumeRoom(slot.Room), sec); err != nil {
+			log.Errorf("conn.Send(EXPIRE %s) error(%v)", slot.Room, err)
+			return
+		}
+		n += 2
+	}
+	if err = conn.Send("SADD", _keyResumeAll, key); err != nil {
+		log.Errorf("conn.Send(SADD %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", _keyResumeAll, sec); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", _keyResumeAll, err)
+		return
+	}
+	n += 2
+	if err = conn.Send("SET", keyKeyServer(key), model.ServerResume, "EX", sec); err != nil {
+		log.Errorf("conn.Send(SET %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("EXPIRE", keyKeyInfo(key), sec); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < n; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// ResumeSlotToken get the token of the resume slot of a key, empty if none.
+func (d *Dao) ResumeSlotToken(c context.Context, key string) (token string, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if token, err = redis.String(conn.Do("HGET", keyResumeInfo(key), "token")); err != nil {
+		if err == redis.ErrNil {
+			return "", nil
+		}
+		log.Errorf("conn.Do(HGET %s) error(%v)", key, err)
+	}
+	return
+}
+
+// AppendResume append a frame of count protos to the resume slot of a key,
+// ignored if the slot is gone or does not accept the op unless it is zero.
+func (d *Dao) AppendResume(c context.Context, key string, data []byte, count int, op int32) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	if _, err = _appendResumeScript.Do(conn, keyResumeInfo(key), keyResumeSlot(key), data, count, op); err != nil {
+		log.Errorf("appendResume(%s) error(%v)", key, err)
+	}
+	return
+}
+
+// ResumeKeys get the keys detached from a room, or from all if empty.
+func (d *Dao) ResumeKeys(c context.Context, room string) (keys []string, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	key := _keyResumeAll
+	if room != "" {
+		key = keyResumeRoom(room)
+	}
+	if keys, err = redis.Strings(conn.Do("SMEMBERS", key)); err != nil {
+		log.Errorf("conn.Do(SMEMBERS %s) error(%v)", key, err)
+	}
+	return
+}
+
+// TakeResumeSlot take the resume slot of a key, nil if it's gone or the token
+// does not match.
+func (d *Dao) TakeResumeSlot(c context.Context, key, token string) (slot *model.ResumeSlot, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	reply, err := redis.Values(_takeResumeScript.Do(conn, keyResumeInfo(key), keyResumeSlot(key), _keyResumeAll, token, keyResumeRoom(""), key))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		log.Errorf("takeResume(%s) error(%v)", key, err)
+		return
+	}
+	var (
+		info    map[string]string
+		entries [][]byte
+	)
+	if info, err = redis.StringMap(reply[0], nil); err != nil {
+		return
+	}
+	if entries, err = redis.ByteSlices(reply[1], nil); err != nil {
+		return
+	}
+	slot = &model.ResumeSlot{
+		Token:   info["token"],
+		Room:    info["room"],
+		Accepts: decodeAccepts(info["accepts"]),
+	}
+	slot.Mid, _ = strconv.ParseInt(info["mid"], 10, 64)
+	slot.Seq, _ = strconv.ParseInt(info["seq"], 10, 64)
+	kseq, _ := strconv.ParseInt(info["kseq"], 10, 32)
+	slot.KeySeq = int32(kseq)
+	slot.Max, _ = strconv.Atoi(info["max"])
+	for _, e := range entries {
+		idx := bytes.IndexByte(e, ':')
+		if idx <= 0 {
+			continue
+		}
+		seq, _ := strconv.ParseInt(string(e[:idx]), 10, 64)
+		slot.Entries = append(slot.Entries, &model.ResumeEntry{Seq: seq, Data: e[idx+1:]})
+	}
+	return
+}
@@ -0,0 +1,185 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_prefixAckStats  = "sack_%s"  // server -> push ack counters
+	_prefixSlowStats = "sslow_%s" // server -> slow consumer counters
+	_keyAckServers   = "sack"     // servers reporting acks, by last report
+	_keySlowServers  = "sslow"    // servers reporting slow consumers, by last report
+)
+
+func keyAckStats(server string) string {
+	return fmt.Sprintf(_prefixAckStats, server)
+}
+
+func keySlowStats(server string) string {
+	return fmt.Sprintf(_prefixSlowStats, server)
+}
+
+// IncrAckStats add the push ack counters reported by a server, shared by all
+// the logic instances.
+func (d *Dao) IncrAckStats(c context.Context, server string, delivered, dropped, expired int64) (err error) {
+	key := keyAckStats(server)
+	return d.incrStats(c, _keyAckServers, server, key, []interface{}{
+		"delivered", delivered,
+		"dropped", dropped,
+		"expired", expired,
+	}, nil)
+}
+
+// IncrSlowStats add the slow consumer counters reported by a server, keys are
+// the drops by key of the last report.
+func (d *Dao) IncrSlowStats(c context.Context, server string, keys map[string]int64, dropped, spilled, disconnected int64) (err error) {
+	key := keySlowStats(server)
+	b, _ := json.Marshal(keys)
+	return d.incrStats(c, _keySlowServers, server, key, []interface{}{
+		"dropped", dropped,
+		"spilled", spilled,
+		"disconnected", disconnected,
+	}, b)
+}
+
+func (d *Dao) incrStats(c context.Context, servers, server, key string, counters []interface{}, keys []byte) (err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	now := time.Now().Unix()
+	n := 0
+	for i := 0; i < len(counters); i += 2 {
+		if err = conn.Send("HINCRBY", key, counters[i], counters[i+1]); err != nil {
+			log.Errorf("conn.Send(HINCRBY %s %s) error(%v)", key, counters[i], err)
+			return
+		}
+		n++
+	}
+	if err = conn.Send("HSET", key, "updated", now); err != nil {
+		log.Errorf("conn.Send(HSET %s) error(%v)", key, err)
+		return
+	}
+	n++
+	if keys != nil {
+		if err = conn.Send("HSET", key, "keys", keys); err != nil {
+			log.Errorf("conn.Send(HSET %s) error(%v)", key, err)
+			return
+		}
+		n++
+	}
+	if err = conn.Send("EXPIRE", key, d.redisExpire); err != nil {
+		log.Errorf("conn.Send(EXPIRE %s) error(%v)", key, err)
+		return
+	}
+	if err = conn.Send("ZADD", servers, now, server); err != nil {
+		log.Errorf("conn.Send(ZADD %s %s) error(%v)", servers, server, err)
+		return
+	}
+	n += 2
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	for i := 0; i < n; i++ {
+		if _, err = conn.Receive(); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+	}
+	return
+}
+
+// AckStats get the push ack counters of the servers reported within the
+// redis expire.
+func (d *Dao) AckStats(c context.Context) (res map[string]*model.AckStats, err error) {
+	stats, err := d.stats(c, _keyAckServers, keyAckStats)
+	if err != nil {
+		return
+	}
+	res = make(map[string]*model.AckStats, len(stats))
+	for server, st := range stats {
+		res[server] = &model.AckStats{
+			Delivered: parseStat(st["delivered"]),
+			Dropped:   parseStat(st["dropped"]),
+			Expired:   parseStat(st["expired"]),
+			Updated:   parseStat(st["updated"]),
+		}
+	}
+	return
+}
+
+// SlowStats get the slow consumer counters of the servers reported within
+// the redis expire.
+func (d *Dao) SlowStats(c context.Context) (res map[string]*model.SlowStats, err error) {
+	stats, err := d.stats(c, _keySlowServers, keySlowStats)
+	if err != nil {
+		return
+	}
+	res = make(map[string]*model.SlowStats, len(stats))
+	for server, st := range stats {
+		ss := &model.SlowStats{
+			Dropped:      parseStat(st["dropped"]),
+			Spilled:      parseStat(st["spilled"]),
+			Disconnected: parseStat(st["disconnected"]),
+			Updated:      parseStat(st["updated"]),
+		}
+		if b := st["keys"]; b != "" {
+			if err := json.Unmarshal([]byte(b), &ss.Keys); err != nil {
+				log.Errorf("SlowStats json.Unmarshal(%s) error(%v)", b, err)
+			}
+		}
+		res[server] = ss
+	}
+	return
+}
+
+// stats get the counters of the servers reported within the redis expire,
+// the servers no longer reporting are removed.
+func (d *Dao) stats(c context.Context, servers string, keyFn func(string) string) (res map[string]map[string]string, err error) {
+	conn := d.redis.Get()
+	defer conn.Close()
+	since := time.Now().Unix() - int64(d.redisExpire)
+	if _, err = conn.Do("ZREMRANGEBYSCORE", servers, "-inf", since); err != nil {
+		log.Errorf("conn.Do(ZREMRANGEBYSCORE %s) error(%v)", servers, err)
+		return
+	}
+	names, err := redis.Strings(conn.Do("ZRANGE", servers, 0, -1))
+	if err != nil {
+		log.Errorf("conn.Do(ZRANGE %s) error(%v)", servers, err)
+		return
+	}
+	for _, server := range names {
+		if err = conn.Send("HGETALL", keyFn(server)); err != nil {
+			log.Errorf("conn.Send(HGETALL %s) error(%v)", keyFn(server), err)
+			return
+		}
+	}
+	if err = conn.Flush(); err != nil {
+		log.Errorf("conn.Flush() error(%v)", err)
+		return
+	}
+	res = make(map[string]map[string]string, len(names))
+	for _, server := range names {
+		var st map[string]string
+		if st, err = redis.StringMap(conn.Receive()); err != nil {
+			log.Errorf("conn.Receive() error(%v)", err)
+			return
+		}
+		if len(st) > 0 {
+			res[server] = st
+		}
+	}
+	return
+}
+
+func parseStat(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
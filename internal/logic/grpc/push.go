@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/logic"
+	"github.com/wcaqrl/chime/internal/logic/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unavailable the message bus or redis failed, the caller may retry.
+func unavailable(err error) error {
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// pushError the push of an invalid schedule is not retried, a retry of a
+// pending idempotency key is aborted.
+func pushError(err error) error {
+	if errors.Is(err, logic.ErrPushExpired) || errors.Is(err, logic.ErrPushSchedule) || errors.Is(err, logic.ErrPushPriority) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, logic.ErrPushPending) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return unavailable(err)
+}
+
+// priority the priority of the push options, empty for the default.
+func priority(p pb.PushMsg_Priority) string {
+	switch p {
+	case pb.PushMsg_REALTIME:
+		return model.PriorityRealtime
+	case pb.PushMsg_BULK:
+		return model.PriorityBulk
+	}
+	return ""
+}
+
+// PushKeys push a message by keys.
+func (s *server) PushKeys(ctx context.Context, req *pb.PushKeysReq) (*pb.PushKeysReply, error) {
+	if len(req.Keys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "keys required")
+	}
+	id, err := s.srv.PushKeys(ctx, req.Op, req.Keys, req.Msg, &model.PushOptions{Ack: req.Ack, IdempotencyKey: req.IdempotencyKey, NotBefore: req.NotBefore, ExpireAt: req.ExpireAt, Priority: priority(req.Priority)})
+	if err != nil {
+		return nil, pushError(err)
+	}
+	return &pb.PushKeysReply{Id: id}, nil
+}
+
+// PushMids push a message by mids.
+func (s *server) PushMids(ctx context.Context, req *pb.PushMidsReq) (*pb.PushMidsReply, error) {
+	if len(req.Mids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "mids required")
+	}
+	id, err := s.srv.PushMids(ctx, req.Op, req.Mids, req.Msg, &model.PushOptions{Ack: req.Ack, Offline: req.Offline, IdempotencyKey: req.IdempotencyKey, NotBefore: req.NotBefore, ExpireAt: req.ExpireAt, Priority: priority(req.Priority)})
+	if err != nil {
+		return nil, pushError(err)
+	}
+	return &pb.PushMidsReply{Id: id}, nil
+}
+
+// PushRoom push a message to a room.
+func (s *server) PushRoom(ctx context.Context, req *pb.PushRoomReq) (*pb.PushRoomReply, error) {
+	if req.Op == 0 || req.Type == "" || req.Room == "" {
+		return nil, status.Error(codes.InvalidArgument, "op, type and room required")
+	}
+	id, err := s.srv.PushRoom(ctx, req.Op, req.Type, req.Room, req.Msg, &model.PushOptions{IdempotencyKey: req.IdempotencyKey, NotBefore: req.NotBefore, ExpireAt: req.ExpireAt, Priority: priority(req.Priority)})
+	if err != nil {
+		return nil, pushError(err)
+	}
+	return &pb.PushRoomReply{Id: id}, nil
+}
+
+// PushAll push a message to all.
+func (s *server) PushAll(ctx context.Context, req *pb.PushAllReq) (*pb.PushAllReply, error) {
+	if req.Op == 0 {
+		return nil, status.Error(codes.InvalidArgument, "op required")
+	}
+	id, err := s.srv.PushAll(ctx, req.Op, req.Speed, req.Msg, &model.PushOptions{IdempotencyKey: req.IdempotencyKey, NotBefore: req.NotBefore, ExpireAt: req.ExpireAt, Priority: priority(req.Priority)})
+	if err != nil {
+		return nil, pushError(err)
+	}
+	return &pb.PushAllReply{Id: id}, nil
+}
+
+// OnlineTop get the top online rooms.
+func (s *server) OnlineTop(ctx context.Context, req *pb.OnlineTopReq) (*pb.OnlineTopReply, error) {
+	if req.Type == "" || req.Limit <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "type and limit required")
+	}
+	tops, err := s.srv.OnlineTop(ctx, req.Type, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &pb.OnlineTopReply{Tops: make([]*pb.RoomTop, 0, len(tops))}
+	for _, top := range tops {
+		reply.Tops = append(reply.Tops, &pb.RoomTop{RoomID: top.RoomID, Count: top.Count})
+	}
+	return reply, nil
+}
+
+// OnlineRoom get the online of rooms.
+func (s *server) OnlineRoom(ctx context.Context, req *pb.OnlineRoomReq) (*pb.OnlineRoomReply, error) {
+	if req.Type == "" || len(req.Rooms) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "type and rooms required")
+	}
+	rooms, err := s.srv.OnlineRoom(ctx, req.Type, req.Rooms)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.OnlineRoomReply{Rooms: rooms}, nil
+}
+
+// OnlineTotal get the online ips and conns.
+func (s *server) OnlineTotal(ctx context.Context, req *pb.OnlineTotalReq) (*pb.OnlineTotalReply, error) {
+	ipCount, connCount := s.srv.OnlineTotal(ctx)
+	return &pb.OnlineTotalReply{IpCount: ipCount, ConnCount: connCount}, nil
+}
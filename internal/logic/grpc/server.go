@@ -2,20 +2,28 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"net"
 	"time"
 
 	pb "github.com/wcaqrl/chime/api/logic"
 	"github.com/wcaqrl/chime/internal/logic"
 	"github.com/wcaqrl/chime/internal/logic/conf"
+	"github.com/wcaqrl/chime/internal/logic/model"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	// use gzip decoder
 	_ "google.golang.org/grpc/encoding/gzip"
 )
 
+var grpcDuration = metric.NewGRPCHistogram("logic", "grpc_duration_seconds", "Latencies of the grpc calls served.")
+
 // New logic grpc server
 func New(c *conf.RPCServer, l *logic.Logic) *grpc.Server {
 	keepParams := grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -25,7 +33,7 @@ func New(c *conf.RPCServer, l *logic.Logic) *grpc.Server {
 		Timeout:               time.Duration(c.KeepAliveTimeout),
 		MaxConnectionAge:      time.Duration(c.MaxLifeTime),
 	})
-	srv := grpc.NewServer(keepParams)
+	srv := grpc.NewServer(keepParams, grpc.ChainUnaryInterceptor(metric.UnaryServerInterceptor(grpcDuration), tracing.UnaryServerInterceptor()))
 	pb.RegisterLogicServer(srv, &server{l})
 	lis, err := net.Listen(c.Network, c.Addr)
 	if err != nil {
@@ -47,16 +55,44 @@ var _ pb.LogicServer = &server{}
 
 // Connect connect a conn.
 func (s *server) Connect(ctx context.Context, req *pb.ConnectReq) (*pb.ConnectReply, error) {
-	mid, key, room, accepts, hb, err := s.srv.Connect(ctx, req.Server, req.Cookie, req.Token)
+	mid, key, room, accepts, hb, tk, offline, keySeq, err := s.srv.Connect(ctx, req.Server, req.Cookie, req.Token, req.LastSeq, resumeSlot(req.Resume))
 	if err != nil {
-		return &pb.ConnectReply{}, err
+		if errors.Is(err, logic.ErrUnauthenticated) {
+			return &pb.ConnectReply{}, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return &pb.ConnectReply{}, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.ConnectReply{Mid: mid, Key: key, RoomID: room, Accepts: accepts, Heartbeat: hb}, nil
+	return &pb.ConnectReply{Mid: mid, Key: key, RoomID: room, Accepts: accepts, Heartbeat: hb, ResumeToken: tk, Offline: offline, KeySeq: keySeq}, nil
+}
+
+// resumeSlot convert a resume slot, nil if none.
+func resumeSlot(s *pb.ResumeSlot) *model.ResumeSlot {
+	if s == nil {
+		return nil
+	}
+	slot := &model.ResumeSlot{
+		Token:   s.Token,
+		Room:    s.Room,
+		Accepts: s.Accepts,
+		Seq:     s.Seq,
+		KeySeq:  s.KeySeq,
+		Max:     int(s.Max),
+	}
+	for _, e := range s.Entries {
+		slot.Entries = append(slot.Entries, &model.ResumeEntry{Seq: e.Seq, Data: e.Data})
+	}
+	return slot
 }
 
 // Disconnect disconnect a conn.
 func (s *server) Disconnect(ctx context.Context, req *pb.DisconnectReq) (*pb.DisconnectReply, error) {
-	has, err := s.srv.Disconnect(ctx, req.Mid, req.Key, req.Server)
+	if req.Slot != nil {
+		if err := s.srv.Detach(ctx, req.Mid, req.Key, req.Server, resumeSlot(req.Slot), time.Duration(req.Slot.Ttl)*time.Second, req.KeySeq); err != nil {
+			return &pb.DisconnectReply{}, err
+		}
+		return &pb.DisconnectReply{Has: true}, nil
+	}
+	has, err := s.srv.Disconnect(ctx, req.Mid, req.Key, req.Server, req.KeySeq)
 	if err != nil {
 		return &pb.DisconnectReply{}, err
 	}
@@ -65,7 +101,7 @@ func (s *server) Disconnect(ctx context.Context, req *pb.DisconnectReq) (*pb.Dis
 
 // Heartbeat beartbeat a conn.
 func (s *server) Heartbeat(ctx context.Context, req *pb.HeartbeatReq) (*pb.HeartbeatReply, error) {
-	if err := s.srv.Heartbeat(ctx, req.Mid, req.Key, req.Server); err != nil {
+	if err := s.srv.Heartbeat(ctx, req.Mid, req.Key, req.Server, req.KeySeq); err != nil {
 		return &pb.HeartbeatReply{}, err
 	}
 	return &pb.HeartbeatReply{}, nil
@@ -82,13 +118,111 @@ func (s *server) RenewOnline(ctx context.Context, req *pb.OnlineReq) (*pb.Online
 
 // Receive receive a message.
 func (s *server) Receive(ctx context.Context, req *pb.ReceiveReq) (*pb.ReceiveReply, error) {
-	if err := s.srv.Receive(ctx, req.Mid, req.Proto); err != nil {
+	if err := s.srv.Receive(ctx, req.Mid, req.Key, req.Server, req.Room, req.Proto); err != nil {
+		if errors.Is(err, logic.ErrUpstreamFull) {
+			return &pb.ReceiveReply{}, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		return &pb.ReceiveReply{}, err
 	}
 	return &pb.ReceiveReply{}, nil
 }
 
+// AckReport report push ack counters.
+func (s *server) AckReport(ctx context.Context, req *pb.AckReportReq) (*pb.AckReportReply, error) {
+	if err := s.srv.AckReport(ctx, req.Server, req.Delivered, req.Dropped, req.Expired); err != nil {
+		return &pb.AckReportReply{}, err
+	}
+	return &pb.AckReportReply{}, nil
+}
+
+// SlowReport report slow consumer counters.
+func (s *server) SlowReport(ctx context.Context, req *pb.SlowReportReq) (*pb.SlowReportReply, error) {
+	if err := s.srv.SlowReport(ctx, req.Server, req.Keys, req.Dropped, req.Spilled, req.Disconnected); err != nil {
+		return &pb.SlowReportReply{}, err
+	}
+	return &pb.SlowReportReply{}, nil
+}
+
+// DeliveryReport report the deliveries of pushes.
+func (s *server) DeliveryReport(ctx context.Context, req *pb.DeliveryReportReq) (*pb.DeliveryReportReply, error) {
+	if err := s.srv.DeliveryReport(ctx, req.Server, req.Counts); err != nil {
+		return &pb.DeliveryReportReply{}, err
+	}
+	return &pb.DeliveryReportReply{}, nil
+}
+
+// OnlineMids get the presence of mids.
+func (s *server) OnlineMids(ctx context.Context, req *pb.OnlineMidsReq) (*pb.OnlineMidsReply, error) {
+	mps, err := s.srv.OnlineMids(ctx, req.Mids, req.Info)
+	if err != nil {
+		if errors.Is(err, logic.ErrPresenceBatch) {
+			return &pb.OnlineMidsReply{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return &pb.OnlineMidsReply{}, err
+	}
+	reply := &pb.OnlineMidsReply{Mids: make([]*pb.MidPresence, 0, len(mps))}
+	for _, mp := range mps {
+		reply.Mids = append(reply.Mids, &pb.MidPresence{Mid: mp.Mid, Online: mp.Online, Keys: keyPresences(mp.Keys)})
+	}
+	return reply, nil
+}
+
+// OnlineKeys get the presence of keys.
+func (s *server) OnlineKeys(ctx context.Context, req *pb.OnlineKeysReq) (*pb.OnlineKeysReply, error) {
+	kps, err := s.srv.OnlineKeys(ctx, req.Keys, req.Info)
+	if err != nil {
+		if errors.Is(err, logic.ErrPresenceBatch) {
+			return &pb.OnlineKeysReply{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return &pb.OnlineKeysReply{}, err
+	}
+	return &pb.OnlineKeysReply{Keys: keyPresences(kps)}, nil
+}
+
+func keyPresences(kps []*model.KeyPresence) []*pb.KeyPresence {
+	res := make([]*pb.KeyPresence, 0, len(kps))
+	for _, kp := range kps {
+		res = append(res, &pb.KeyPresence{
+			Key:       kp.Key,
+			Mid:       kp.Mid,
+			Online:    kp.Online,
+			Server:    kp.Server,
+			Room:      kp.Room,
+			Platform:  kp.Platform,
+			Connected: kp.Connected,
+		})
+	}
+	return res
+}
+
 // nodes return nodes.
 func (s *server) Nodes(ctx context.Context, req *pb.NodesReq) (*pb.NodesReply, error) {
 	return s.srv.NodesWeighted(ctx, req.Platform, req.ClientIP), nil
 }
+
+// SyncResume append the protos written to the conns to their resume slots.
+func (s *server) SyncResume(ctx context.Context, req *pb.SyncResumeReq) (*pb.SyncResumeReply, error) {
+	slots := make(map[string]*model.ResumeSlot, len(req.Slots))
+	for key, slot := range req.Slots {
+		slots[key] = resumeSlot(slot)
+	}
+	if err := s.srv.SyncResume(ctx, req.Server, slots); err != nil {
+		if errors.Is(err, logic.ErrResumeDisabled) {
+			return &pb.SyncResumeReply{}, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return &pb.SyncResumeReply{}, unavailable(err)
+	}
+	return &pb.SyncResumeReply{}, nil
+}
+
+// Resume reattach a detached session.
+func (s *server) Resume(ctx context.Context, req *pb.ResumeReq) (*pb.ResumeReply, error) {
+	mid, room, accepts, hb, seq, first, data, tk, keySeq, err := s.srv.Resume(ctx, req.Server, req.Key, req.Token, req.Seq, resumeSlot(req.Resume))
+	if err != nil {
+		if errors.Is(err, logic.ErrResumeSlot) || errors.Is(err, logic.ErrResumeDisabled) {
+			return &pb.ResumeReply{}, status.Error(codes.NotFound, err.Error())
+		}
+		return &pb.ResumeReply{}, unavailable(err)
+	}
+	return &pb.ResumeReply{Mid: mid, Key: req.Key, RoomID: room, Accepts: accepts, Heartbeat: hb, Seq: seq, Data: data, Token: tk, KeySeq: keySeq, First: first}, nil
+}
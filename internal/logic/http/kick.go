@@ -0,0 +1,66 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/api/protocol"
+)
+
+// kickReason default the reason to kicked by the backend.
+func kickReason(reason int32) int32 {
+	if reason == protocol.ReasonUnknown {
+		return protocol.ReasonKicked
+	}
+	return reason
+}
+
+func (s *Server) kickKeys(c *gin.Context) {
+	var arg struct {
+		Keys   []string `form:"keys" binding:"required"`
+		Reason int32    `form:"reason"`
+		Msg    string   `form:"msg"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	if err := s.logic.KickKeys(c.Request.Context(), arg.Keys, kickReason(arg.Reason), arg.Msg); err != nil {
+		errors(c, ServerErr, err.Error())
+		return
+	}
+	result(c, nil, OK)
+}
+
+func (s *Server) kickMids(c *gin.Context) {
+	var arg struct {
+		Mids   []int64 `form:"mids" binding:"required"`
+		Reason int32   `form:"reason"`
+		Msg    string  `form:"msg"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	if err := s.logic.KickMids(c.Request.Context(), arg.Mids, kickReason(arg.Reason), arg.Msg); err != nil {
+		errors(c, ServerErr, err.Error())
+		return
+	}
+	result(c, nil, OK)
+}
+
+func (s *Server) kickRoom(c *gin.Context) {
+	var arg struct {
+		Type   string `form:"type" binding:"required"`
+		Room   string `form:"room" binding:"required"`
+		Reason int32  `form:"reason"`
+		Msg    string `form:"msg"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	if err := s.logic.KickRoom(c.Request.Context(), arg.Type, arg.Room, kickReason(arg.Reason), arg.Msg); err != nil {
+		errors(c, ServerErr, err.Error())
+		return
+	}
+	result(c, nil, OK)
+}
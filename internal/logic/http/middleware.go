@@ -4,12 +4,25 @@ import (
 	"fmt"
 	"net/http/httputil"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/pkg/metric"
+	"github.com/wcaqrl/chime/pkg/tracing"
 )
 
+var httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metric.Namespace,
+	Subsystem: "logic",
+	Name:      "http_duration_seconds",
+	Help:      "Latencies of the http api by route and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "code"})
+
 func loggerHandler(c *gin.Context) {
 	// Start timer
 	start := time.Now()
@@ -26,12 +39,34 @@ func loggerHandler(c *gin.Context) {
 	statusCode := c.Writer.Status()
 	ecode := c.GetInt(contextErrCode)
 	clientIP := c.ClientIP()
+	// the route, not the path, keeps the labels bounded
+	httpDuration.WithLabelValues(method, c.FullPath(), strconv.Itoa(statusCode)).Observe(latency.Seconds())
 	if raw != "" {
 		path = path + "?" + raw
 	}
 	log.Infof("METHOD:%s | PATH:%s | CODE:%d | IP:%s | TIME:%d | ECODE:%d", method, path, statusCode, clientIP, latency/time.Millisecond, ecode)
 }
 
+// tracingHandler start a server span per request, the child of the
+// traceparent header if any, and echo the traceparent to the client.
+func tracingHandler(c *gin.Context) {
+	ctx := tracing.ContextWithRemote(c.Request.Context(), c.GetHeader(tracing.HeaderTraceparent))
+	ctx, span := tracing.Start(ctx, c.Request.Method+" "+c.FullPath(), tracing.KindServer)
+	if span == nil {
+		c.Next()
+		return
+	}
+	c.Request = c.Request.WithContext(ctx)
+	c.Header(tracing.HeaderTraceparent, span.Context().Traceparent())
+	c.Next()
+	span.SetAttr("http.status_code", c.Writer.Status())
+	if ecode := c.GetInt(contextErrCode); ecode != OK {
+		span.SetAttr("ecode", ecode)
+		span.SetError(fmt.Errorf("ecode: %d", ecode))
+	}
+	span.End()
+}
+
 func recoverHandler(c *gin.Context) {
 	defer func() {
 		if err := recover(); err != nil {
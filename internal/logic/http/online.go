@@ -40,6 +40,40 @@ func (s *Server) onlineRoom(c *gin.Context) {
 	result(c, res, OK)
 }
 
+func (s *Server) onlineMids(c *gin.Context) {
+	var arg struct {
+		Mids []int64 `form:"mids" binding:"required"`
+		Info bool    `form:"info"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	res, err := s.logic.OnlineMids(c, arg.Mids, arg.Info)
+	if err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	result(c, res, OK)
+}
+
+func (s *Server) onlineKeys(c *gin.Context) {
+	var arg struct {
+		Keys []string `form:"keys" binding:"required"`
+		Info bool     `form:"info"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	res, err := s.logic.OnlineKeys(c, arg.Keys, arg.Info)
+	if err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	result(c, res, OK)
+}
+
 func (s *Server) onlineTotal(c *gin.Context) {
 	ipCount, connCount := s.logic.OnlineTotal(context.TODO())
 	res := map[string]interface{}{
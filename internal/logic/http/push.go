@@ -1,16 +1,52 @@
 package http
 
 import (
-	"context"
 	"io/ioutil"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wcaqrl/chime/internal/logic"
+	"github.com/wcaqrl/chime/internal/logic/model"
 )
 
+const (
+	// headerIdempotencyKey the idempotency key of a push, if not in the query.
+	headerIdempotencyKey = "Idempotency-Key"
+)
+
+type pushReply struct {
+	ID string `json:"id"`
+}
+
+func idempotencyKey(c *gin.Context, key string) string {
+	if key != "" {
+		return key
+	}
+	return c.GetHeader(headerIdempotencyKey)
+}
+
+// pushError the push of an invalid schedule is a request error, a retry of a
+// pending idempotency key a conflict.
+func pushError(c *gin.Context, err error) {
+	if err == logic.ErrPushExpired || err == logic.ErrPushSchedule || err == logic.ErrPushPriority {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	if err == logic.ErrPushPending {
+		errors(c, Conflict, err.Error())
+		return
+	}
+	errors(c, ServerErr, err.Error())
+}
+
 func (s *Server) pushKeys(c *gin.Context) {
 	var arg struct {
-		Op   int32    `form:"operation"`
-		Keys []string `form:"keys"`
+		Op          int32    `form:"operation"`
+		Keys        []string `form:"keys"`
+		Ack         bool     `form:"ack"`
+		Idempotency string   `form:"idempotency_key"`
+		NotBefore   int64    `form:"not_before"`
+		ExpireAt    int64    `form:"expire_at"`
+		Priority    string   `form:"priority"`
 	}
 	if err := c.BindQuery(&arg); err != nil {
 		errors(c, RequestErr, err.Error())
@@ -22,17 +58,24 @@ func (s *Server) pushKeys(c *gin.Context) {
 		errors(c, RequestErr, err.Error())
 		return
 	}
-	if err = s.logic.PushKeys(context.TODO(), arg.Op, arg.Keys, msg); err != nil {
-		result(c, nil, RequestErr)
+	id, err := s.logic.PushKeys(c.Request.Context(), arg.Op, arg.Keys, msg, &model.PushOptions{Ack: arg.Ack, IdempotencyKey: idempotencyKey(c, arg.Idempotency), NotBefore: arg.NotBefore, ExpireAt: arg.ExpireAt, Priority: arg.Priority})
+	if err != nil {
+		pushError(c, err)
 		return
 	}
-	result(c, nil, OK)
+	result(c, &pushReply{ID: id}, OK)
 }
 
 func (s *Server) pushMids(c *gin.Context) {
 	var arg struct {
-		Op   int32   `form:"operation"`
-		Mids []int64 `form:"mids"`
+		Op          int32   `form:"operation"`
+		Mids        []int64 `form:"mids"`
+		Ack         bool    `form:"ack"`
+		Offline     bool    `form:"offline"`
+		Idempotency string  `form:"idempotency_key"`
+		NotBefore   int64   `form:"not_before"`
+		ExpireAt    int64   `form:"expire_at"`
+		Priority    string  `form:"priority"`
 	}
 	if err := c.BindQuery(&arg); err != nil {
 		errors(c, RequestErr, err.Error())
@@ -44,18 +87,23 @@ func (s *Server) pushMids(c *gin.Context) {
 		errors(c, RequestErr, err.Error())
 		return
 	}
-	if err = s.logic.PushMids(context.TODO(), arg.Op, arg.Mids, msg); err != nil {
-		errors(c, ServerErr, err.Error())
+	id, err := s.logic.PushMids(c.Request.Context(), arg.Op, arg.Mids, msg, &model.PushOptions{Ack: arg.Ack, Offline: arg.Offline, IdempotencyKey: idempotencyKey(c, arg.Idempotency), NotBefore: arg.NotBefore, ExpireAt: arg.ExpireAt, Priority: arg.Priority})
+	if err != nil {
+		pushError(c, err)
 		return
 	}
-	result(c, nil, OK)
+	result(c, &pushReply{ID: id}, OK)
 }
 
 func (s *Server) pushRoom(c *gin.Context) {
 	var arg struct {
-		Op   int32  `form:"operation" binding:"required"`
-		Type string `form:"type" binding:"required"`
-		Room string `form:"room" binding:"required"`
+		Op          int32  `form:"operation" binding:"required"`
+		Type        string `form:"type" binding:"required"`
+		Room        string `form:"room" binding:"required"`
+		Idempotency string `form:"idempotency_key"`
+		NotBefore   int64  `form:"not_before"`
+		ExpireAt    int64  `form:"expire_at"`
+		Priority    string `form:"priority"`
 	}
 	if err := c.BindQuery(&arg); err != nil {
 		errors(c, RequestErr, err.Error())
@@ -67,17 +115,22 @@ func (s *Server) pushRoom(c *gin.Context) {
 		errors(c, RequestErr, err.Error())
 		return
 	}
-	if err = s.logic.PushRoom(c, arg.Op, arg.Type, arg.Room, msg); err != nil {
-		errors(c, ServerErr, err.Error())
+	id, err := s.logic.PushRoom(c.Request.Context(), arg.Op, arg.Type, arg.Room, msg, &model.PushOptions{IdempotencyKey: idempotencyKey(c, arg.Idempotency), NotBefore: arg.NotBefore, ExpireAt: arg.ExpireAt, Priority: arg.Priority})
+	if err != nil {
+		pushError(c, err)
 		return
 	}
-	result(c, nil, OK)
+	result(c, &pushReply{ID: id}, OK)
 }
 
 func (s *Server) pushAll(c *gin.Context) {
 	var arg struct {
-		Op    int32 `form:"operation" binding:"required"`
-		Speed int32 `form:"speed"`
+		Op          int32  `form:"operation" binding:"required"`
+		Speed       int32  `form:"speed"`
+		Idempotency string `form:"idempotency_key"`
+		NotBefore   int64  `form:"not_before"`
+		ExpireAt    int64  `form:"expire_at"`
+		Priority    string `form:"priority"`
 	}
 	if err := c.BindQuery(&arg); err != nil {
 		errors(c, RequestErr, err.Error())
@@ -88,9 +141,30 @@ func (s *Server) pushAll(c *gin.Context) {
 		errors(c, RequestErr, err.Error())
 		return
 	}
-	if err = s.logic.PushAll(c, arg.Op, arg.Speed, msg); err != nil {
+	id, err := s.logic.PushAll(c.Request.Context(), arg.Op, arg.Speed, msg, &model.PushOptions{IdempotencyKey: idempotencyKey(c, arg.Idempotency), NotBefore: arg.NotBefore, ExpireAt: arg.ExpireAt, Priority: arg.Priority})
+	if err != nil {
+		pushError(c, err)
+		return
+	}
+	result(c, &pushReply{ID: id}, OK)
+}
+
+func (s *Server) pushStatus(c *gin.Context) {
+	var arg struct {
+		ID string `form:"id" binding:"required"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	st, err := s.logic.PushStatus(c.Request.Context(), arg.ID)
+	if err != nil {
 		errors(c, ServerErr, err.Error())
 		return
 	}
-	result(c, nil, OK)
+	if st == nil {
+		errors(c, NothingFound, "push not found or expired")
+		return
+	}
+	result(c, st, OK)
 }
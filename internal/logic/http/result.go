@@ -9,6 +9,10 @@ const (
 	OK = 0
 	// RequestErr request error
 	RequestErr = -400
+	// NothingFound nothing found
+	NothingFound = -404
+	// Conflict the request conflicts with one in progress
+	Conflict = -409
 	// ServerErr server error
 	ServerErr = -500
 
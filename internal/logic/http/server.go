@@ -16,7 +16,7 @@ type Server struct {
 // New new a http server.
 func New(c *conf.HTTPServer, l *logic.Logic) *Server {
 	engine := gin.New()
-	engine.Use(loggerHandler, recoverHandler)
+	engine.Use(loggerHandler, recoverHandler, tracingHandler)
 	go func() {
 		if err := engine.Run(c.Addr); err != nil {
 			panic(err)
@@ -36,9 +36,18 @@ func (s *Server) initRouter() {
 	group.POST("/push/mids", s.pushMids)
 	group.POST("/push/room", s.pushRoom)
 	group.POST("/push/all", s.pushAll)
+	group.GET("/push/status", s.pushStatus)
+	group.POST("/kick/keys", s.kickKeys)
+	group.POST("/kick/mids", s.kickMids)
+	group.POST("/kick/room", s.kickRoom)
 	group.GET("/online/top", s.onlineTop)
 	group.GET("/online/room", s.onlineRoom)
 	group.GET("/online/total", s.onlineTotal)
+	group.GET("/online/mids", s.onlineMids)
+	group.GET("/online/keys", s.onlineKeys)
+	group.GET("/ack/stats", s.ackStats)
+	group.GET("/slow/stats", s.slowStats)
+	group.POST("/trace", s.trace)
 	group.GET("/nodes/weighted", s.nodesWeighted)
 	group.GET("/nodes/instances", s.nodesInstances)
 }
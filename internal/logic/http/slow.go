@@ -0,0 +1,16 @@
+package http
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) slowStats(c *gin.Context) {
+	res, err := s.logic.SlowStats(context.TODO())
+	if err != nil {
+		result(c, nil, ServerErr)
+		return
+	}
+	result(c, res, OK)
+}
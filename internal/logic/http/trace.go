@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) trace(c *gin.Context) {
+	var arg struct {
+		Mid  int64  `form:"mid"`
+		Key  string `form:"key"`
+		TTL  string `form:"ttl"`
+		Stop bool   `form:"stop"`
+	}
+	if err := c.BindQuery(&arg); err != nil {
+		errors(c, RequestErr, err.Error())
+		return
+	}
+	if arg.Mid <= 0 && arg.Key == "" {
+		errors(c, RequestErr, "mid or key required")
+		return
+	}
+	var (
+		err error
+		ttl time.Duration
+	)
+	if arg.TTL != "" {
+		if ttl, err = time.ParseDuration(arg.TTL); err != nil || ttl < time.Second {
+			errors(c, RequestErr, "invalid ttl")
+			return
+		}
+	}
+	if arg.Stop {
+		err = s.logic.Untrace(context.TODO(), arg.Mid, arg.Key)
+	} else {
+		err = s.logic.Trace(context.TODO(), arg.Mid, arg.Key, ttl)
+	}
+	if err != nil {
+		errors(c, ServerErr, err.Error())
+		return
+	}
+	result(c, nil, OK)
+}
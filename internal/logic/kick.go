@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/wcaqrl/chime/internal/logic/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// KickKeys disconnect the keys.
+func (l *Logic) KickKeys(c context.Context, keys []string, reason int32, msg string) (err error) {
+	servers, err := l.dao.ServersByKeys(c, keys)
+	if err != nil {
+		return
+	}
+	kickKeys := make(map[string][]string)
+	for i, key := range keys {
+		server := servers[i]
+		if server != "" && key != "" {
+			kickKeys[server] = append(kickKeys[server], key)
+		}
+	}
+	for server := range kickKeys {
+		if err = l.dao.KickMsg(c, server, kickKeys[server], "", reason, msg); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// KickMids disconnect every key of the mids.
+func (l *Logic) KickMids(c context.Context, mids []int64, reason int32, msg string) (err error) {
+	keyServers, _, err := l.dao.KeysByMids(c, mids)
+	if err != nil {
+		return
+	}
+	keys := make(map[string][]string)
+	for key, server := range keyServers {
+		if key == "" || server == "" {
+			log.Warningf("kick key:%s server:%s is empty", key, server)
+			continue
+		}
+		keys[server] = append(keys[server], key)
+	}
+	for server, k := range keys {
+		if err = l.dao.KickMsg(c, server, k, "", reason, msg); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// KickRoom disconnect every key in the room.
+func (l *Logic) KickRoom(c context.Context, typ, room string, reason int32, msg string) (err error) {
+	return l.dao.KickMsg(c, "", nil, model.EncodeRoomKey(typ, room), reason, msg)
+}
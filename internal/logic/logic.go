@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/bilibili/discovery/naming"
@@ -22,10 +23,18 @@ type Logic struct {
 	c   *conf.Config
 	dis *naming.Discovery
 	dao *dao.Dao
-	// online
-	totalIPs   int64
-	totalConns int64
-	roomCount  map[string]int32
+	// auth
+	auth         Authenticator
+	resumeSecret []byte // nil if resume tokens disabled
+	// upstream
+	upstream *Upstream
+	// presence, nil if disabled
+	presence *Presence
+	// online, replaced as a whole under the mutex
+	onlineMutex sync.RWMutex
+	totalIPs    int64
+	totalConns  int64
+	roomCount   map[string]int32
 	// load balancer
 	nodes        []*naming.Instance
 	loadBalancer *LoadBalancer
@@ -41,10 +50,25 @@ func New(c *conf.Config) (l *Logic) {
 		loadBalancer: NewLoadBalancer(),
 		regions:      make(map[string]string),
 	}
+	auth, err := NewAuthenticator(c.Auth)
+	if err != nil {
+		panic(err)
+	}
+	l.auth = auth
+	if c.Resume.Secret != "" {
+		l.resumeSecret = []byte(c.Resume.Secret)
+	}
+	l.upstream = NewUpstream(c.Upstream, l.dao)
+	if c.Presence.Topic != "" {
+		l.presence = NewPresence(c.Presence, l.dao)
+	}
 	l.initRegions()
 	l.initNodes()
 	_ = l.loadOnline()
 	go l.onlineproc()
+	if c.Rebalance.Enable {
+		go l.rebalanceproc()
+	}
 	return l
 }
 
@@ -55,6 +79,10 @@ func (l *Logic) Ping(c context.Context) (err error) {
 
 // Close close resources.
 func (l *Logic) Close() {
+	l.upstream.Close()
+	if l.presence != nil {
+		l.presence.Close()
+	}
 	l.dao.Close()
 }
 
@@ -122,13 +150,36 @@ func (l *Logic) newNodes(res naming.Resolver) {
 				allIns = append(allIns, ins)
 			}
 		}
+		l.onlineMutex.Lock()
 		l.totalConns = totalConns
 		l.totalIPs = totalIPs
 		l.nodes = allIns
+		l.onlineMutex.Unlock()
 		l.loadBalancer.Update(allIns)
 	}
 }
 
+// onlineNodes get the online comets.
+func (l *Logic) onlineNodes() []*naming.Instance {
+	l.onlineMutex.RLock()
+	defer l.onlineMutex.RUnlock()
+	return l.nodes
+}
+
+// onlineRooms get the conns of every room, the map is read only.
+func (l *Logic) onlineRooms() map[string]int32 {
+	l.onlineMutex.RLock()
+	defer l.onlineMutex.RUnlock()
+	return l.roomCount
+}
+
+// onlineTotal get the ips and conns of all the comets.
+func (l *Logic) onlineTotal() (ips, conns int64) {
+	l.onlineMutex.RLock()
+	defer l.onlineMutex.RUnlock()
+	return l.totalIPs, l.totalConns
+}
+
 func (l *Logic) onlineproc() {
 	for {
 		time.Sleep(_onlineTick)
@@ -142,7 +193,7 @@ func (l *Logic) loadOnline() (err error) {
 	var (
 		roomCount = make(map[string]int32)
 	)
-	for _, server := range l.nodes {
+	for _, server := range l.onlineNodes() {
 		var online *model.Online
 		online, err = l.dao.ServerOnline(context.Background(), server.Hostname)
 		if err != nil {
@@ -156,6 +207,8 @@ func (l *Logic) loadOnline() (err error) {
 			roomCount[roomID] += count
 		}
 	}
+	l.onlineMutex.Lock()
 	l.roomCount = roomCount
+	l.onlineMutex.Unlock()
 	return
 }
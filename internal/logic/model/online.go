@@ -12,3 +12,49 @@ type Top struct {
 	RoomID string `json:"room_id"`
 	Count  int32  `json:"count"`
 }
+
+// KeyInfo the conn info recorded at connect time.
+type KeyInfo struct {
+	Mid       int64  `json:"mid"`
+	Room      string `json:"room"`
+	Platform  string `json:"platform"`
+	Connected int64  `json:"connected"`
+}
+
+// KeyPresence the presence of a key.
+type KeyPresence struct {
+	Key       string `json:"key"`
+	Mid       int64  `json:"mid"`
+	Online    bool   `json:"online"`
+	Server    string `json:"server"`
+	Room      string `json:"room,omitempty"`
+	Platform  string `json:"platform,omitempty"`
+	Connected int64  `json:"connected,omitempty"`
+}
+
+// MidPresence the presence of a mid and its online keys.
+type MidPresence struct {
+	Mid    int64          `json:"mid"`
+	Online bool           `json:"online"`
+	Keys   []*KeyPresence `json:"keys"`
+}
+
+const (
+	// PresenceOnline the first key of a mid connected.
+	PresenceOnline = "online"
+	// PresenceOffline the last key of a mid disconnected.
+	PresenceOffline = "offline"
+	// PresenceExpired the last key of a mid stopped heartbeating.
+	PresenceExpired = "expired"
+)
+
+// PresenceEvent a presence change of a mid.
+type PresenceEvent struct {
+	Event     string `json:"event"`
+	Mid       int64  `json:"mid"`
+	Key       string `json:"key"`
+	Server    string `json:"server"`
+	Platform  string `json:"platform"`
+	Room      string `json:"room"`
+	Timestamp int64  `json:"ts"`
+}
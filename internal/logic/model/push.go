@@ -0,0 +1,78 @@
+package model
+
+// PushOptions optional behaviours of a push.
+type PushOptions struct {
+	// Ack the client must ack the push, comet redelivers it on timeout.
+	Ack bool
+	// Offline store the push for the offline mids, replayed on connect.
+	Offline bool
+	// ID the id of the push, assigned by logic.
+	ID string
+	// IdempotencyKey the retries of the key within the window get the id of
+	// the first push and are not pushed again.
+	IdempotencyKey string
+	// NotBefore the unix seconds the push is held by job until, the servers
+	// of the keys and mids are resolved by job when it is sent.
+	NotBefore int64
+	// ExpireAt the unix seconds the push is discarded after, not yet delivered.
+	ExpireAt int64
+	// Priority the lane of the push, realtime or bulk. A push to all is bulk
+	// and the others realtime if empty.
+	Priority string
+}
+
+// the priorities of a push.
+const (
+	PriorityRealtime = "realtime"
+	PriorityBulk     = "bulk"
+)
+
+// the types of a push.
+const (
+	PushTypeKeys = "keys"
+	PushTypeMids = "mids"
+	PushTypeRoom = "room"
+	PushTypeAll  = "all"
+)
+
+// PushStatus the fan-out of a push and the deliveries reported by comet,
+// the targets of a broadcast are the conns online when it was pushed.
+type PushStatus struct {
+	ID        string           `json:"id"`
+	Type      string           `json:"type"`
+	Targets   int64            `json:"targets"`   // conns resolved
+	Comets    int64            `json:"comets"`    // comets dispatched to
+	Offline   int64            `json:"offline"`   // offline mids stored for
+	Delivered int64            `json:"delivered"` // conns delivered to, acked ones for an ack push
+	Dropped   int64            `json:"dropped"`   // conns dropped or expired for
+	Servers   map[string]int64 `json:"servers"`   // deliveries by comet reported
+	Created   int64            `json:"created"`
+}
+
+// OfflineMsg a push stored for an offline mid.
+type OfflineMsg struct {
+	Seq       int32  `json:"seq"`
+	Op        int32  `json:"op"`
+	Msg       []byte `json:"msg"`
+	Timestamp int64  `json:"ts"`
+	NotBefore int64  `json:"nbf,omitempty"` // not replayed before
+	ExpireAt  int64  `json:"exp,omitempty"` // dropped at
+}
+
+// AckStats push ack counters reported by comet.
+type AckStats struct {
+	Delivered int64 `json:"delivered"`
+	Dropped   int64 `json:"dropped"`
+	Expired   int64 `json:"expired"`
+	Updated   int64 `json:"updated"`
+}
+
+// SlowStats slow consumer counters reported by comet, keys are the drops by
+// key of the last report.
+type SlowStats struct {
+	Dropped      int64            `json:"dropped"`
+	Spilled      int64            `json:"spilled"`
+	Disconnected int64            `json:"disconnected"`
+	Keys         map[string]int64 `json:"keys"`
+	Updated      int64            `json:"updated"`
+}
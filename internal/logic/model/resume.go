@@ -0,0 +1,24 @@
+package model
+
+// ServerResume the server of a key detached into a resume slot, the msgs
+// pushed to it are appended to the slot.
+const ServerResume = "~resume"
+
+// ResumeEntry a server proto frame of a resume slot, seq is the number of
+// protos pushed to the session up to this frame.
+type ResumeEntry struct {
+	Seq  int64
+	Data []byte
+}
+
+// ResumeSlot a detached session waiting for its client to resume it.
+type ResumeSlot struct {
+	Token   string
+	Mid     int64
+	Room    string
+	Accepts []int32
+	Seq     int64
+	KeySeq  int32
+	Max     int
+	Entries []*ResumeEntry
+}
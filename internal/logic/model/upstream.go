@@ -0,0 +1,14 @@
+package model
+
+// UpstreamMsg a client message forwarded to the business backends.
+type UpstreamMsg struct {
+	Mid       int64  `json:"mid"`
+	Key       string `json:"key"`
+	Server    string `json:"server"`
+	Room      string `json:"room"`
+	Ver       int32  `json:"ver"`
+	Op        int32  `json:"op"`
+	Seq       int32  `json:"seq"`
+	Body      []byte `json:"body"`
+	Timestamp int64  `json:"ts"`
+}
@@ -12,7 +12,7 @@ import (
 
 // NodesInstances get servers info.
 func (l *Logic) NodesInstances(c context.Context) (res []*naming.Instance) {
-	return l.nodes
+	return l.onlineNodes()
 }
 
 // NodesWeighted get node list.
@@ -0,0 +1,41 @@
+package logic
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/pkg/bytes"
+)
+
+// offlineMids return the mids having no online key.
+func offlineMids(mids, olMids []int64) (res []int64) {
+	online := make(map[int64]struct{}, len(olMids))
+	for _, mid := range olMids {
+		online[mid] = struct{}{}
+	}
+	for _, mid := range mids {
+		if _, ok := online[mid]; !ok && mid > 0 {
+			res = append(res, mid)
+		}
+	}
+	return
+}
+
+// takeOffline take the offline messages of a mid after the last seq the
+// client received, packed as the frames the comet pushes to the new conn once
+// the key is registered. The frames carry the offline seq the client sends
+// back on its next connect, the msgs are kept until then.
+func (l *Logic) takeOffline(c context.Context, mid, lastSeq int64) []byte {
+	msgs, err := l.dao.TakeOfflineMsgs(c, mid, lastSeq)
+	if err != nil || len(msgs) == 0 {
+		return nil
+	}
+	buf := bytes.NewWriterSize(len(msgs) * 64)
+	for _, msg := range msgs {
+		p := &protocol.Proto{Ver: 1, Op: msg.Op, Seq: msg.Seq, Body: msg.Msg}
+		p.WriteTo(buf)
+	}
+	log.Infof("take offline mid:%d last seq:%d msgs:%d", mid, lastSeq, len(msgs))
+	return buf.Buffer()
+}
@@ -2,19 +2,27 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"sort"
 	"strings"
 
 	"github.com/wcaqrl/chime/internal/logic/model"
 )
 
+const (
+	_maxPresenceBatch = 1000
+)
+
 var (
 	_emptyTops = make([]*model.Top, 0)
+
+	// ErrPresenceBatch too many mids or keys in one presence query.
+	ErrPresenceBatch = errors.New("presence batch too large")
 )
 
 // OnlineTop get the top online.
 func (l *Logic) OnlineTop(c context.Context, typ string, n int) (tops []*model.Top, err error) {
-	for key, cnt := range l.roomCount {
+	for key, cnt := range l.onlineRooms() {
 		if strings.HasPrefix(key, typ) {
 			_, roomID, err := model.DecodeRoomKey(key)
 			if err != nil {
@@ -42,13 +50,30 @@ func (l *Logic) OnlineTop(c context.Context, typ string, n int) (tops []*model.T
 // OnlineRoom get rooms online.
 func (l *Logic) OnlineRoom(c context.Context, typ string, rooms []string) (res map[string]int32, err error) {
 	res = make(map[string]int32, len(rooms))
+	roomCount := l.onlineRooms()
 	for _, room := range rooms {
-		res[room] = l.roomCount[model.EncodeRoomKey(typ, room)]
+		res[room] = roomCount[model.EncodeRoomKey(typ, room)]
 	}
 	return
 }
 
 // OnlineTotal get all online.
 func (l *Logic) OnlineTotal(c context.Context) (int64, int64) {
-	return l.totalIPs, l.totalConns
+	return l.onlineTotal()
+}
+
+// OnlineMids get the presence of mids.
+func (l *Logic) OnlineMids(c context.Context, mids []int64, info bool) ([]*model.MidPresence, error) {
+	if len(mids) > _maxPresenceBatch {
+		return nil, ErrPresenceBatch
+	}
+	return l.dao.MidPresences(c, mids, info)
+}
+
+// OnlineKeys get the presence of keys.
+func (l *Logic) OnlineKeys(c context.Context, keys []string, info bool) ([]*model.KeyPresence, error) {
+	if len(keys) > _maxPresenceBatch {
+		return nil, ErrPresenceBatch
+	}
+	return l.dao.KeyPresences(c, keys, info)
 }
@@ -0,0 +1,155 @@
+package logic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/conf"
+	"github.com/wcaqrl/chime/internal/logic/dao"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_presenceSweepLimit = 1000
+)
+
+// Presence publish the presence events of mids, a mid only flips on its
+// first connect and last disconnect. An offline is held in redis for a
+// debounce window, dropped if the mid comes back within it and published by
+// the first logic sweeping it after. The events are published to the bus
+// asynchronously, in order.
+type Presence struct {
+	c      *conf.Presence
+	dao    *dao.Dao
+	events chan *model.PresenceEvent
+	wg     sync.WaitGroup
+	mutex  sync.RWMutex
+	closed chan struct{}
+}
+
+// NewPresence new a presence publisher and start the sweeper.
+func NewPresence(c *conf.Presence, d *dao.Dao) *Presence {
+	p := &Presence{
+		c:      c,
+		dao:    d,
+		events: make(chan *model.PresenceEvent, c.Buffer),
+		closed: make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go p.publishproc()
+	go p.sweepproc()
+	return p
+}
+
+// Online a key of the mid connected.
+func (p *Presence) Online(c context.Context, ev *model.PresenceEvent) {
+	if ev.Mid <= 0 {
+		return
+	}
+	ev.Event = model.PresenceOnline
+	ev.Timestamp = time.Now().Unix()
+	keys, err := p.dao.AddPresence(c, ev)
+	if err != nil || keys != 1 {
+		return
+	}
+	// came back within the debounce window
+	if po, err := p.dao.TakePresenceOffline(c, ev.Mid); err == nil && po != nil {
+		return
+	}
+	p.publish(ev)
+}
+
+// Heartbeat a key of the mid is still alive.
+func (p *Presence) Heartbeat(c context.Context, mid int64, key, server string) {
+	if mid <= 0 {
+		return
+	}
+	ok, err := p.dao.RenewPresence(c, key)
+	if err != nil || ok {
+		return
+	}
+	// swept as expired but the conn is alive
+	p.Online(c, &model.PresenceEvent{Mid: mid, Key: key, Server: server})
+}
+
+// Offline a key disconnected or expired.
+func (p *Presence) Offline(c context.Context, key, event string) {
+	ev, keys, err := p.dao.DelPresence(c, key)
+	if err != nil || ev == nil || keys > 0 {
+		return
+	}
+	ev.Event = event
+	ev.Timestamp = time.Now().Unix()
+	if p.c.Debounce <= 0 {
+		p.publish(ev)
+		return
+	}
+	deadline := time.Now().Add(time.Duration(p.c.Debounce)).Unix()
+	if err = p.dao.AddPresenceOffline(c, ev, deadline); err != nil {
+		p.publish(ev)
+	}
+}
+
+// Close stop the sweeper and wait the queued events published, the held
+// offlines are left to the other logics.
+func (p *Presence) Close() {
+	p.mutex.Lock()
+	close(p.closed)
+	close(p.events)
+	p.mutex.Unlock()
+	p.wg.Wait()
+}
+
+// publish queue an event for the bus, never blocks.
+func (p *Presence) publish(ev *model.PresenceEvent) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	select {
+	case <-p.closed:
+		return
+	default:
+	}
+	select {
+	case p.events <- ev:
+	default:
+		log.Errorf("presence buffer full, mid:%d key:%s %s dropped", ev.Mid, ev.Key, ev.Event)
+	}
+}
+
+func (p *Presence) publishproc() {
+	defer p.wg.Done()
+	for ev := range p.events {
+		if err := p.dao.PushPresence(context.Background(), ev); err != nil {
+			log.Errorf("p.dao.PushPresence(%+v) error(%v)", ev, err)
+		}
+	}
+}
+
+// sweepproc publish the keys which stopped heartbeating without a disconnect
+// and the held offlines which are due.
+func (p *Presence) sweepproc() {
+	ticker := time.NewTicker(time.Duration(p.c.Sweep))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.closed:
+			return
+		}
+		c := context.Background()
+		if keys, err := p.dao.ExpiredPresences(c, _presenceSweepLimit); err == nil {
+			for _, key := range keys {
+				p.Offline(c, key, model.PresenceExpired)
+			}
+		}
+		if mids, err := p.dao.DuePresenceOfflines(c, _presenceSweepLimit); err == nil {
+			for _, mid := range mids {
+				if ev, err := p.dao.TakePresenceOffline(c, mid); err == nil && ev != nil {
+					p.publish(ev)
+				}
+			}
+		}
+	}
+}
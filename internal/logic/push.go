@@ -2,39 +2,107 @@ package logic
 
 import (
 	"context"
+	"time"
 
 	"github.com/wcaqrl/chime/internal/logic/model"
+	"github.com/wcaqrl/chime/pkg/tracing"
 
 	log "github.com/sirupsen/logrus"
 )
 
-// PushKeys push a message by keys.
-func (l *Logic) PushKeys(c context.Context, op int32, keys []string, msg []byte) (err error) {
+// PushKeys push a message by keys, it returns the id of the push.
+func (l *Logic) PushKeys(c context.Context, op int32, keys []string, msg []byte, opts *model.PushOptions) (id string, err error) {
+	c, span := tracing.Start(c, "logic.PushKeys", tracing.KindInternal)
+	span.SetAttr("keys", len(keys))
+	defer func() {
+		l.endPush(c, opts, err != nil)
+		span.SetError(err)
+		span.End()
+	}()
+	var dup bool
+	if id, dup, err = l.beginPush(c, opts); err != nil || dup {
+		return
+	}
+	if delayed(opts) {
+		if err = l.addPushStatus(c, id, model.PushTypeKeys, int64(len(keys)), 0, 0); err != nil {
+			return
+		}
+		err = l.dao.PushDelayedMsg(c, op, keys, nil, msg, opts)
+		return
+	}
 	servers, err := l.dao.ServersByKeys(c, keys)
 	if err != nil {
 		return
 	}
+	var targets int64
 	pushKeys := make(map[string][]string)
 	for i, key := range keys {
 		server := servers[i]
 		if server != "" && key != "" {
 			pushKeys[server] = append(pushKeys[server], key)
+			targets++
 		}
 	}
+	resumeKeys, ok := pushKeys[model.ServerResume]
+	if ok {
+		delete(pushKeys, model.ServerResume)
+	}
+	if err = l.addPushStatus(c, id, model.PushTypeKeys, targets, int64(len(pushKeys)), 0); err != nil {
+		return
+	}
+	if ok {
+		l.appendResume(c, op, 0, resumeKeys, msg, 0)
+	}
 	for server := range pushKeys {
-		if err = l.dao.PushMsg(c, op, server, pushKeys[server], msg); err != nil {
+		if err = l.dao.PushMsg(c, op, server, pushKeys[server], msg, opts); err != nil {
 			return
 		}
 	}
 	return
 }
 
-// PushMids push a message by mid.
-func (l *Logic) PushMids(c context.Context, op int32, mids []int64, msg []byte) (err error) {
-	keyServers, _, err := l.dao.KeysByMids(c, mids)
+// PushMids push a message by mid, it returns the id of the push.
+func (l *Logic) PushMids(c context.Context, op int32, mids []int64, msg []byte, opts *model.PushOptions) (id string, err error) {
+	c, span := tracing.Start(c, "logic.PushMids", tracing.KindInternal)
+	span.SetAttr("mids", len(mids))
+	// once the offline msgs are stored the key is kept, a retry would store
+	// them again
+	var stored bool
+	defer func() {
+		l.endPush(c, opts, err != nil && !stored)
+		span.SetError(err)
+		span.End()
+	}()
+	var dup bool
+	if id, dup, err = l.beginPush(c, opts); err != nil || dup {
+		return
+	}
+	keyServers, olMids, err := l.dao.KeysByMids(c, mids)
 	if err != nil {
 		return
 	}
+	var offline []int64
+	if opts.Offline {
+		offline = offlineMids(mids, olMids)
+		if err = l.dao.AddOfflineMsgs(c, offline, op, msg, opts.NotBefore, opts.ExpireAt); err != nil {
+			return
+		}
+		stored = len(offline) > 0
+	}
+	if delayed(opts) {
+		// the mids stored offline are replayed on connect instead
+		if opts.Offline {
+			mids = olMids
+		}
+		if err = l.addPushStatus(c, id, model.PushTypeMids, int64(len(mids)), 0, int64(len(offline))); err != nil {
+			return
+		}
+		if len(mids) > 0 {
+			err = l.dao.PushDelayedMsg(c, op, nil, mids, msg, opts)
+		}
+		return
+	}
+	var targets int64
 	keys := make(map[string][]string)
 	for key, server := range keyServers {
 		if key == "" || server == "" {
@@ -42,21 +110,91 @@ func (l *Logic) PushMids(c context.Context, op int32, mids []int64, msg []byte)
 			continue
 		}
 		keys[server] = append(keys[server], key)
+		targets++
+	}
+	resumeKeys, ok := keys[model.ServerResume]
+	if ok {
+		delete(keys, model.ServerResume)
+	}
+	if err = l.addPushStatus(c, id, model.PushTypeMids, targets, int64(len(keys)), int64(len(offline))); err != nil {
+		return
+	}
+	if ok {
+		l.appendResume(c, op, 0, resumeKeys, msg, 0)
 	}
 	for server, k := range keys {
-		if err = l.dao.PushMsg(c, op, server, k, msg); err != nil {
+		if err = l.dao.PushMsg(c, op, server, k, msg, opts); err != nil {
 			return
 		}
 	}
 	return
 }
 
-// PushRoom push a message by room.
-func (l *Logic) PushRoom(c context.Context, op int32, typ, room string, msg []byte) (err error) {
-	return l.dao.BroadcastRoomMsg(c, op, model.EncodeRoomKey(typ, room), msg)
+// PushRoom push a message by room, it returns the id of the push.
+func (l *Logic) PushRoom(c context.Context, op int32, typ, room string, msg []byte, opts *model.PushOptions) (id string, err error) {
+	c, span := tracing.Start(c, "logic.PushRoom", tracing.KindInternal)
+	defer func() {
+		l.endPush(c, opts, err != nil)
+		span.SetError(err)
+		span.End()
+	}()
+	roomKey := model.EncodeRoomKey(typ, room)
+	span.SetAttr("room", roomKey)
+	var dup bool
+	if id, dup, err = l.beginPush(c, opts); err != nil || dup {
+		return
+	}
+	// the bulk lane may overtake the realtime one, bulk room msgs are unsequenced
+	var seq int32
+	if opts.Priority != model.PriorityBulk {
+		if seq, err = l.dao.IncrRoomSeq(c, roomKey, time.Duration(l.c.Push.RoomSeq)); err != nil {
+			return
+		}
+	}
+	if err = l.addPushStatus(c, id, model.PushTypeRoom, int64(l.onlineRooms()[roomKey]), int64(len(l.onlineNodes())), 0); err != nil {
+		return
+	}
+	if err = l.dao.BroadcastRoomMsg(c, op, roomKey, seq, msg, opts); err == nil && !delayed(opts) {
+		l.appendResumeRoom(c, op, seq, roomKey, msg)
+	}
+	return
+}
+
+// PushAll push a message to all, it returns the id of the push. It is bulk
+// unless asked otherwise.
+func (l *Logic) PushAll(c context.Context, op, speed int32, msg []byte, opts *model.PushOptions) (id string, err error) {
+	c, span := tracing.Start(c, "logic.PushAll", tracing.KindInternal)
+	defer func() {
+		l.endPush(c, opts, err != nil)
+		span.SetError(err)
+		span.End()
+	}()
+	var dup bool
+	if id, dup, err = l.beginPush(c, opts); err != nil || dup {
+		return
+	}
+	_, conns := l.onlineTotal()
+	if err = l.addPushStatus(c, id, model.PushTypeAll, conns, int64(len(l.onlineNodes())), 0); err != nil {
+		return
+	}
+	if opts.Priority == "" {
+		opts.Priority = model.PriorityBulk
+	}
+	if err = l.dao.BroadcastMsg(c, op, speed, msg, opts); err == nil && !delayed(opts) {
+		l.appendResumeRoom(c, op, 0, "", msg)
+	}
+	return
 }
 
-// PushAll push a message to all.
-func (l *Logic) PushAll(c context.Context, op, speed int32, msg []byte) (err error) {
-	return l.dao.BroadcastMsg(c, op, speed, msg)
+// addPushStatus record the fan-out of a push before it is sent, so the
+// deliveries reported by comet find it.
+func (l *Logic) addPushStatus(c context.Context, id, typ string, targets, comets, offline int64) error {
+	return l.dao.AddPushStatus(c, &model.PushStatus{
+		ID:      id,
+		Type:    typ,
+		Targets: targets,
+		Comets:  comets,
+		Offline: offline,
+		Created: time.Now().Unix(),
+	}, time.Duration(l.c.Push.Status))
 }
@@ -0,0 +1,95 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+type nodeLoad struct {
+	hostname string
+	addrs    []string
+	weight   int64
+	conns    int64
+}
+
+// rebalanceproc migrate conns off the comets loaded over their weighted share,
+// one logic a round.
+func (l *Logic) rebalanceproc() {
+	interval := time.Duration(l.c.Rebalance.Interval)
+	for {
+		time.Sleep(interval)
+		ok, err := l.dao.RebalanceLock(context.Background(), l.c.Env.Host, interval)
+		if err != nil || !ok {
+			continue
+		}
+		l.rebalance(context.Background())
+	}
+}
+
+func (l *Logic) rebalance(c context.Context) {
+	var (
+		loads       []*nodeLoad
+		totalWeight int64
+		totalConns  int64
+	)
+	for _, ins := range l.onlineNodes() {
+		weight, err := strconv.ParseInt(ins.Metadata[model.MetaWeight], 10, 64)
+		if err != nil || weight <= 0 {
+			continue
+		}
+		conns, err := strconv.ParseInt(ins.Metadata[model.MetaConnCount], 10, 64)
+		if err != nil {
+			continue
+		}
+		load := &nodeLoad{hostname: ins.Hostname, weight: weight, conns: conns}
+		if addrs := ins.Metadata[model.MetaAddrs]; addrs != "" {
+			load.addrs = strings.Split(addrs, ",")
+		}
+		loads = append(loads, load)
+		totalWeight += weight
+		totalConns += conns
+	}
+	if totalWeight == 0 || totalConns == 0 {
+		return
+	}
+	share := func(n *nodeLoad) float64 {
+		return float64(totalConns) * float64(n.weight) / float64(totalWeight)
+	}
+	// suggest the nodes under their share
+	var nodes []string
+	for _, n := range loads {
+		if float64(n.conns) < share(n) {
+			nodes = append(nodes, n.addrs...)
+		}
+	}
+	for _, n := range loads {
+		target := share(n)
+		if float64(n.conns) <= target*(1+l.c.Rebalance.Threshold) {
+			continue
+		}
+		migrated, err := l.dao.Migrated(c, n.hostname)
+		if err != nil {
+			continue
+		}
+		count := n.conns - int64(target)
+		if limit := n.conns*int64(l.c.Rebalance.MaxRatio)/100 - migrated; count > limit {
+			count = limit
+		}
+		if count <= 0 {
+			continue
+		}
+		if err = l.dao.AddMigrated(c, n.hostname, count); err != nil {
+			continue
+		}
+		if err = l.dao.MigrateMsg(c, n.hostname, int32(count), nodes); err != nil {
+			log.Errorf("l.dao.MigrateMsg(%s,%d) error(%v)", n.hostname, count, err)
+			continue
+		}
+		log.Infof("rebalance migrate server:%s conns:%d share:%.0f count:%d", n.hostname, n.conns, target, count)
+	}
+}
@@ -0,0 +1,164 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/api/protocol"
+	"github.com/wcaqrl/chime/internal/logic/model"
+	"github.com/wcaqrl/chime/pkg/bytes"
+	"github.com/wcaqrl/chime/pkg/token"
+)
+
+var (
+	// ErrResumeDisabled no resume secret configured.
+	ErrResumeDisabled = errors.New("resume token disabled")
+	// ErrResumeSlot the resume slot expired or the token does not match.
+	ErrResumeSlot = errors.New("resume slot not found")
+)
+
+// resumeClaims the payload of a resume token, the token is bound to a key and
+// resumes the slot it was opened with only.
+type resumeClaims struct {
+	Key string `json:"key"`
+	ID  string `json:"jti"`
+}
+
+// openResume open the resume slot of a session and sign its token, empty if
+// resume is disabled or the slot failed to open. The same token resumes the
+// session after a disconnect or a migration.
+func (l *Logic) openResume(c context.Context, mid int64, key, room string, accepts []int32, slot *model.ResumeSlot) string {
+	if l.resumeSecret == nil || slot == nil {
+		return ""
+	}
+	b, _ := json.Marshal(&resumeClaims{Key: key, ID: uuid.New().String()})
+	slot.Token = string(token.Sign(l.resumeSecret, b))
+	slot.Mid, slot.Room, slot.Accepts = mid, room, accepts
+	if err := l.dao.OpenResumeSlot(c, key, slot); err != nil {
+		log.Errorf("l.dao.OpenResumeSlot(%d,%s) error(%v)", mid, key, err)
+		return ""
+	}
+	return slot.Token
+}
+
+// verifyResume check a resume token was signed by logic for the key and
+// claim it, a token resumes once.
+func (l *Logic) verifyResume(c context.Context, key, tk string) error {
+	if l.resumeSecret == nil {
+		return ErrResumeDisabled
+	}
+	payload, err := token.Verify(l.resumeSecret, []byte(tk))
+	if err != nil {
+		return ErrResumeSlot
+	}
+	claims := new(resumeClaims)
+	if err = json.Unmarshal(payload, claims); err != nil || claims.Key != key || claims.ID == "" {
+		return ErrResumeSlot
+	}
+	ok, err := l.dao.ClaimResumeToken(c, claims.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrResumeSlot
+	}
+	return nil
+}
+
+// Resume reattach a session to a server, data are the frames pushed after the
+// seq the client received, a frame partly received is sent whole. First is
+// the seq before the first frame, lastSeq if none, past the seq of the client
+// if the slot lost the protos between. The resumed session gets a new slot
+// and token and its key pushes go on after keySeq.
+func (l *Logic) Resume(c context.Context, server, key, tk string, seq int64, next *model.ResumeSlot) (mid int64, roomID string, accepts []int32, hb, lastSeq, first int64, data [][]byte, newToken string, keySeq int32, err error) {
+	if err = l.verifyResume(c, key, tk); err != nil {
+		return
+	}
+	slot, err := l.dao.TakeResumeSlot(c, key, tk)
+	if err != nil {
+		return
+	}
+	if slot == nil {
+		err = ErrResumeSlot
+		return
+	}
+	var platform string
+	if presences, err1 := l.dao.KeyPresences(c, []string{key}, true); err1 == nil && len(presences) == 1 {
+		platform = presences[0].Platform
+	}
+	mid, roomID, accepts, lastSeq, keySeq = slot.Mid, slot.Room, slot.Accepts, slot.Seq, slot.KeySeq
+	hb = int64(l.c.Node.Heartbeat) * int64(l.c.Node.HeartbeatMax)
+	first = lastSeq
+	for _, e := range slot.Entries {
+		if e.Seq > seq {
+			if data == nil {
+				first = e.Seq - int64(protocol.CountProtos(e.Data))
+			}
+			data = append(data, e.Data)
+		}
+	}
+	info := &model.KeyInfo{
+		Mid:       mid,
+		Room:      roomID,
+		Platform:  platform,
+		Connected: time.Now().Unix(),
+	}
+	if err = l.dao.AddMapping(c, mid, key, server, info); err != nil {
+		log.Errorf("l.dao.AddMapping(%d,%s,%s) error(%v)", mid, key, server, err)
+		return
+	}
+	if next != nil {
+		next.Seq, next.KeySeq = lastSeq, keySeq
+		newToken = l.openResume(c, mid, key, roomID, accepts, next)
+	}
+	if l.presence != nil && mid > 0 {
+		l.presence.Online(c, &model.PresenceEvent{Mid: mid, Key: key, Server: server, Platform: platform, Room: roomID})
+	}
+	log.Infof("conn resumed key:%s server:%s mid:%d seq:%d/%d first:%d frames:%d", key, server, mid, seq, lastSeq, first, len(data))
+	return
+}
+
+// SyncResume append the protos written to the conns of a server to their
+// resume slots.
+func (l *Logic) SyncResume(c context.Context, server string, slots map[string]*model.ResumeSlot) (err error) {
+	if l.resumeSecret == nil {
+		return ErrResumeDisabled
+	}
+	if err = l.dao.SyncResumeSlots(c, slots); err != nil {
+		log.Errorf("l.dao.SyncResumeSlots(%s,%d) error(%v)", server, len(slots), err)
+	}
+	return
+}
+
+// appendResume keep a msg pushed to detached keys in their resume slots, only
+// the slots accepting the filter op unless it is zero.
+func (l *Logic) appendResume(c context.Context, op, seq int32, keys []string, msg []byte, filter int32) {
+	buf := bytes.NewWriterSize(len(msg) + 64)
+	p := &protocol.Proto{Ver: 1, Op: op, Seq: seq, Body: msg}
+	p.WriteTo(buf)
+	for _, key := range keys {
+		_ = l.dao.AppendResume(c, key, buf.Buffer(), 1, filter)
+	}
+}
+
+// appendResumeRoom keep a msg pushed to a room, or to all if the room is
+// empty, in the resume slots of the keys detached from it.
+func (l *Logic) appendResumeRoom(c context.Context, op, seq int32, room string, msg []byte) {
+	if l.resumeSecret == nil {
+		return
+	}
+	keys, err := l.dao.ResumeKeys(c, room)
+	if err != nil || len(keys) == 0 {
+		return
+	}
+	var filter int32
+	if room == "" {
+		// a broadcast reaches the conns watching its op only
+		filter = op
+	}
+	l.appendResume(c, op, seq, keys, msg, filter)
+}
@@ -0,0 +1,19 @@
+package logic
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+// SlowReport accumulate the slow consumer counters reported by a comet.
+func (l *Logic) SlowReport(c context.Context, server string, keys map[string]int64, dropped, spilled, disconnected int64) (err error) {
+	log.Infof("slow report server:%s dropped:%d spilled:%d disconnected:%d keys:%d", server, dropped, spilled, disconnected, len(keys))
+	return l.dao.IncrSlowStats(c, server, keys, dropped, spilled, disconnected)
+}
+
+// SlowStats get the slow consumer counters of all comets.
+func (l *Logic) SlowStats(c context.Context) (res map[string]*model.SlowStats, err error) {
+	return l.dao.SlowStats(c)
+}
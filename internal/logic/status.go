@@ -0,0 +1,95 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	pb "github.com/wcaqrl/chime/api/logic"
+	"github.com/wcaqrl/chime/internal/logic/model"
+	"github.com/wcaqrl/chime/pkg/tracing"
+)
+
+var (
+	// ErrPushExpired the push expired before it was sent.
+	ErrPushExpired = errors.New("push expired")
+	// ErrPushSchedule the push expires before its not before.
+	ErrPushSchedule = errors.New("push not_before must be before expire_at")
+	// ErrPushPriority the priority of the push is unknown.
+	ErrPushPriority = errors.New("push priority must be realtime or bulk")
+	// ErrPushPending the first push of the idempotency key is not done yet.
+	ErrPushPending = errors.New("push of the idempotency key in progress")
+)
+
+// beginPush assign the id of a push to opts, a retry of an idempotency key
+// within the window gets the id of the first push and dup, or ErrPushPending
+// while the first push is not done.
+func (l *Logic) beginPush(c context.Context, opts *model.PushOptions) (id string, dup bool, err error) {
+	switch opts.Priority {
+	case "", model.PriorityRealtime, model.PriorityBulk:
+	default:
+		return "", false, ErrPushPriority
+	}
+	if opts.ExpireAt > 0 {
+		if opts.ExpireAt <= time.Now().Unix() {
+			return "", false, ErrPushExpired
+		}
+		if opts.NotBefore >= opts.ExpireAt {
+			return "", false, ErrPushSchedule
+		}
+	}
+	id = uuid.New().String()
+	if opts.IdempotencyKey != "" {
+		var (
+			bound   string
+			pending bool
+		)
+		if bound, pending, err = l.dao.SetIdempotency(c, opts.IdempotencyKey, id, time.Duration(l.c.Push.Idempotency)); err != nil {
+			return
+		}
+		if bound != id {
+			if pending {
+				return "", false, ErrPushPending
+			}
+			dup, id = true, bound
+		}
+	}
+	opts.ID = id
+	tracing.SpanFromContext(c).SetAttr("push.id", id)
+	return
+}
+
+// delayed check the push is held by job, its servers are resolved when it is
+// sent.
+func delayed(opts *model.PushOptions) bool {
+	return opts.NotBefore > time.Now().Unix()
+}
+
+// endPush commit the idempotency key of a push once done, or release it if
+// the push failed so a retry is pushed.
+func (l *Logic) endPush(c context.Context, opts *model.PushOptions, failed bool) {
+	if opts.IdempotencyKey == "" || opts.ID == "" {
+		return
+	}
+	if failed {
+		_ = l.dao.DelIdempotency(c, opts.IdempotencyKey, opts.ID)
+		return
+	}
+	_ = l.dao.CommitIdempotency(c, opts.IdempotencyKey, opts.ID, time.Duration(l.c.Push.Idempotency))
+}
+
+// DeliveryReport count the deliveries of the pushes reported by a comet.
+func (l *Logic) DeliveryReport(c context.Context, server string, counts map[string]*pb.DeliveryCount) (err error) {
+	if err = l.dao.IncrPushDeliveries(c, server, counts); err != nil {
+		return
+	}
+	log.Infof("delivery report server:%s ids:%d", server, len(counts))
+	return
+}
+
+// PushStatus get the delivery status of a push, nil if unknown or expired.
+func (l *Logic) PushStatus(c context.Context, id string) (*model.PushStatus, error) {
+	return l.dao.PushStatus(c, id)
+}
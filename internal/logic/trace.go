@@ -0,0 +1,17 @@
+package logic
+
+import (
+	"context"
+	"time"
+)
+
+// Trace trace the conns of a mid or key on every comet for ttl, the default
+// ttl of the comets if zero.
+func (l *Logic) Trace(c context.Context, mid int64, key string, ttl time.Duration) (err error) {
+	return l.dao.TraceMsg(c, mid, key, int64(ttl/time.Second))
+}
+
+// Untrace stop the trace of a mid or key on every comet.
+func (l *Logic) Untrace(c context.Context, mid int64, key string) (err error) {
+	return l.dao.TraceMsg(c, mid, key, -1)
+}
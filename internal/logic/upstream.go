@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/internal/logic/conf"
+	"github.com/wcaqrl/chime/internal/logic/dao"
+	"github.com/wcaqrl/chime/internal/logic/model"
+)
+
+const (
+	_routeKafka   = "kafka"
+	_routeWebhook = "webhook"
+)
+
+var (
+	// ErrUpstreamFull upstream buffer full, message dropped.
+	ErrUpstreamFull = errors.New("upstream buffer full")
+)
+
+type upstreamMsg struct {
+	route string
+	msg   *model.UpstreamMsg
+}
+
+// Upstream route client messages to kafka or webhooks.
+type Upstream struct {
+	c      *conf.Upstream
+	dao    *dao.Dao
+	client *http.Client
+	msgs   chan *upstreamMsg
+	wg     sync.WaitGroup
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// NewUpstream new an upstream router and start the delivery workers.
+func NewUpstream(c *conf.Upstream, d *dao.Dao) *Upstream {
+	u := &Upstream{
+		c:      c,
+		dao:    d,
+		client: &http.Client{Timeout: time.Duration(c.Timeout)},
+		msgs:   make(chan *upstreamMsg, c.Buffer),
+	}
+	for i := 0; i < c.Worker; i++ {
+		u.wg.Add(1)
+		go u.deliverproc()
+	}
+	return u
+}
+
+// Route return the route of an operation, empty if not routed.
+func (u *Upstream) Route(op int32) string {
+	return u.c.Routes[op]
+}
+
+// Push enqueue a message to the route, never blocks.
+func (u *Upstream) Push(route string, msg *model.UpstreamMsg) (err error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	if u.closed {
+		return ErrUpstreamFull
+	}
+	select {
+	case u.msgs <- &upstreamMsg{route: route, msg: msg}:
+	default:
+		err = ErrUpstreamFull
+	}
+	return
+}
+
+// Close stop accepting messages and wait the buffered ones delivered.
+func (u *Upstream) Close() {
+	u.mutex.Lock()
+	u.closed = true
+	close(u.msgs)
+	u.mutex.Unlock()
+	u.wg.Wait()
+}
+
+func (u *Upstream) deliverproc() {
+	defer u.wg.Done()
+	for m := range u.msgs {
+		b, err := json.Marshal(m.msg)
+		if err != nil {
+			log.Errorf("upstream json.Marshal(%+v) error(%v)", m.msg, err)
+			continue
+		}
+		backoff := time.Duration(u.c.Backoff)
+		for i := 0; ; i++ {
+			if err = u.deliver(m.route, m.msg, b); err == nil {
+				break
+			}
+			if i >= u.c.Retry {
+				log.Errorf("upstream deliver(%s) mid:%d key:%s op:%d dropped after %d retries error(%v)", m.route, m.msg.Mid, m.msg.Key, m.msg.Op, i, err)
+				break
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+func (u *Upstream) deliver(route string, msg *model.UpstreamMsg, b []byte) (err error) {
+	switch {
+	case route == _routeKafka:
+		return u.dao.PushUpstream(context.Background(), u.c.Topic, strconv.FormatInt(msg.Mid, 10), b)
+	case route == _routeWebhook:
+		return u.post(u.c.Webhook, b)
+	case strings.HasPrefix(route, "http://"), strings.HasPrefix(route, "https://"):
+		return u.post(route, b)
+	}
+	return fmt.Errorf("unknown upstream route: %s", route)
+}
+
+func (u *Upstream) post(url string, b []byte) (err error) {
+	resp, err := u.client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("webhook %s status %d", url, resp.StatusCode)
+	}
+	return
+}
@@ -0,0 +1,124 @@
+// Package admin is the shared parts of the admin http servers of comet and
+// job: the result json, the middlewares and the bind address.
+package admin
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/wcaqrl/chime/pkg/ip"
+)
+
+const (
+	// OK ok
+	OK = 0
+	// RequestErr request error
+	RequestErr = -400
+	// Unauthorized token mismatch
+	Unauthorized = -401
+	// NothingFound nothing found
+	NothingFound = -404
+	// ServerErr server error
+	ServerErr = -500
+
+	contextErrCode = "context/err/code"
+)
+
+type resp struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Errors write an error result.
+func Errors(c *gin.Context, code int, msg string) {
+	c.Set(contextErrCode, code)
+	c.JSON(200, resp{
+		Code:    code,
+		Message: msg,
+	})
+}
+
+// Result write the data result.
+func Result(c *gin.Context, data interface{}, code int) {
+	c.Set(contextErrCode, code)
+	c.JSON(200, resp{
+		Code: code,
+		Data: data,
+	})
+}
+
+// Logger log the requests with their result code.
+func Logger(c *gin.Context) {
+	// Start timer
+	start := time.Now()
+	path := c.Request.URL.Path
+	raw := c.Request.URL.RawQuery
+	method := c.Request.Method
+
+	// Process request
+	c.Next()
+
+	// Stop timer
+	end := time.Now()
+	latency := end.Sub(start)
+	statusCode := c.Writer.Status()
+	ecode := c.GetInt(contextErrCode)
+	clientIP := c.ClientIP()
+	if raw != "" {
+		path = path + "?" + raw
+	}
+	log.Infof("METHOD:%s | PATH:%s | CODE:%d | IP:%s | TIME:%d | ECODE:%d", method, path, statusCode, clientIP, latency/time.Millisecond, ecode)
+}
+
+// Recover turn a panic of a request into a 500.
+func Recover(c *gin.Context) {
+	defer func() {
+		if err := recover(); err != nil {
+			const size = 64 << 10
+			buf := make([]byte, size)
+			buf = buf[:runtime.Stack(buf, false)]
+			httprequest, _ := httputil.DumpRequest(c.Request, false)
+			pnc := fmt.Sprintf("[Recovery] %s panic recovered:\n%s\n%s\n%s", time.Now().Format("2006-01-02 15:04:05"), string(httprequest), err, buf)
+			fmt.Print(pnc)
+			log.Error(pnc)
+			c.AbortWithStatus(500)
+		}
+	}()
+	c.Next()
+}
+
+// Auth check the token of the request, passed by the bearer authorization
+// header or the token query. An empty token lets every request in.
+func Auth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			return
+		}
+		tk := c.Query("token")
+		if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			tk = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(tk), []byte(token)) != 1 {
+			c.Set(contextErrCode, Unauthorized)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, resp{Code: Unauthorized, Message: "unauthorized"})
+		}
+	}
+}
+
+// BindAddr bind the internal ip when the addr has no host.
+func BindAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort(ip.InternalIP(), port)
+}
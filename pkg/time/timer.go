@@ -173,6 +173,14 @@ func (t *Timer) Set(td *TimerData, expire itime.Duration) {
 	t.lock.Unlock()
 }
 
+// Len get the number of pending timers.
+func (t *Timer) Len() (n int) {
+	t.lock.Lock()
+	n = len(t.timers)
+	t.lock.Unlock()
+	return
+}
+
 // start start the timer.
 func (t *Timer) start() {
 	for {
@@ -0,0 +1,56 @@
+package token
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var (
+	// ErrMalformed token is not in payload.signature form.
+	ErrMalformed = errors.New("token malformed")
+	// ErrSignature token signature mismatch.
+	ErrSignature = errors.New("token signature invalid")
+
+	_enc = base64.RawURLEncoding
+)
+
+// Sign sign a payload with HMAC-SHA256, the token looks like:
+// base64url(payload).base64url(signature).
+func Sign(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	sig := mac.Sum(nil)
+	buf := make([]byte, _enc.EncodedLen(len(payload))+1+_enc.EncodedLen(len(sig)))
+	n := _enc.EncodedLen(len(payload))
+	_enc.Encode(buf, payload)
+	buf[n] = '.'
+	_enc.Encode(buf[n+1:], sig)
+	return buf
+}
+
+// Verify verify a token signed by Sign and return the payload.
+func Verify(secret, token []byte) (payload []byte, err error) {
+	idx := bytes.IndexByte(token, '.')
+	if idx <= 0 || idx == len(token)-1 {
+		return nil, ErrMalformed
+	}
+	payload = make([]byte, _enc.DecodedLen(idx))
+	n, err := _enc.Decode(payload, token[:idx])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	payload = payload[:n]
+	sig := make([]byte, _enc.DecodedLen(len(token)-idx-1))
+	if n, err = _enc.Decode(sig, token[idx+1:]); err != nil {
+		return nil, ErrMalformed
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(sig[:n], mac.Sum(nil)) {
+		return nil, ErrSignature
+	}
+	return payload, nil
+}
@@ -0,0 +1,289 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ExporterStdout write the spans to stdout as json lines.
+	ExporterStdout = "stdout"
+	// ExporterOTLP post the spans to an otlp/http collector.
+	ExporterOTLP = "otlp"
+
+	_batchSize     = 256
+	_batchInterval = time.Second
+	_queueSize     = 4096
+)
+
+// Config is tracing config.
+type Config struct {
+	Exporter string  // stdout or otlp, disabled if empty
+	Endpoint string  // otlp/http traces endpoint
+	Ratio    float64 // sampled ratio of the new traces
+}
+
+// Exporter export the ended spans.
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+var tracer = &batcher{}
+
+// batcher queue the ended spans and export them in batches, the spans are
+// dropped while the queue is full.
+type batcher struct {
+	exporter Exporter
+	ratio    float64
+	queue    chan *Span
+	closed   chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (b *batcher) enabled() bool {
+	return b.exporter != nil
+}
+
+func (b *batcher) sample() bool {
+	return b.ratio >= 1 || rand.Float64() < b.ratio
+}
+
+func (b *batcher) export(s *Span) {
+	select {
+	case b.queue <- s:
+	default:
+	}
+}
+
+func (b *batcher) exportproc() {
+	var (
+		spans = make([]*Span, 0, _batchSize)
+		tick  = time.NewTicker(_batchInterval)
+	)
+	defer func() {
+		tick.Stop()
+		close(b.done)
+	}()
+	flush := func() {
+		if len(spans) == 0 {
+			return
+		}
+		if err := b.exporter.Export(spans); err != nil {
+			log.Errorf("tracing export spans:%d error(%v)", len(spans), err)
+		}
+		spans = make([]*Span, 0, _batchSize)
+	}
+	for {
+		select {
+		case s := <-b.queue:
+			if spans = append(spans, s); len(spans) >= _batchSize {
+				flush()
+			}
+		case <-tick.C:
+			flush()
+		case <-b.closed:
+			for n := len(b.queue); n > 0; n-- {
+				spans = append(spans, <-b.queue)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// Init enable tracing of the service by the config, a no-op if no exporter
+// is configured.
+func Init(service string, c *Config) {
+	var exporter Exporter
+	switch c.Exporter {
+	case ExporterStdout:
+		exporter = &streamExporter{w: os.Stdout, service: service}
+	case ExporterOTLP:
+		exporter = &otlpExporter{
+			endpoint: c.Endpoint,
+			service:  service,
+			client:   &http.Client{Timeout: 5 * time.Second},
+		}
+	case "":
+		return
+	default:
+		log.Errorf("unknown tracing exporter: %s", c.Exporter)
+		return
+	}
+	tracer = &batcher{
+		exporter: exporter,
+		ratio:    c.Ratio,
+		queue:    make(chan *Span, _queueSize),
+		closed:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go tracer.exportproc()
+	log.Infof("tracing %s exporter:%s ratio:%v", service, c.Exporter, c.Ratio)
+}
+
+// Close export the queued spans and wait for them.
+func Close() {
+	b := tracer
+	if !b.enabled() {
+		return
+	}
+	b.once.Do(func() {
+		close(b.closed)
+	})
+	<-b.done
+}
+
+// spanJSON a span in the otlp json encoding, the ids are hex and the times
+// are unix nanos as strings.
+type spanJSON struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []attributeJSON `json:"attributes,omitempty"`
+	Links             []linkJSON      `json:"links,omitempty"`
+	Status            statusJSON      `json:"status"`
+}
+
+type linkJSON struct {
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+}
+
+type attributeJSON struct {
+	Key   string            `json:"key"`
+	Value map[string]string `json:"value"`
+}
+
+type statusJSON struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func newAttributeJSON(key string, value interface{}) attributeJSON {
+	var v map[string]string
+	switch x := value.(type) {
+	case string:
+		v = map[string]string{"stringValue": x}
+	case bool:
+		v = map[string]string{"boolValue": strconv.FormatBool(x)}
+	case int:
+		v = map[string]string{"intValue": strconv.Itoa(x)}
+	case int32:
+		v = map[string]string{"intValue": strconv.FormatInt(int64(x), 10)}
+	case int64:
+		v = map[string]string{"intValue": strconv.FormatInt(x, 10)}
+	default:
+		b, _ := json.Marshal(x)
+		v = map[string]string{"stringValue": string(b)}
+	}
+	return attributeJSON{Key: key, Value: v}
+}
+
+func newSpanJSON(s *Span) *spanJSON {
+	sj := &spanJSON{
+		TraceID:           s.sc.TraceID.String(),
+		SpanID:            s.sc.SpanID.String(),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parent != (SpanID{}) {
+		sj.ParentSpanID = s.parent.String()
+	}
+	s.mutex.Lock()
+	for _, attr := range s.attrs {
+		sj.Attributes = append(sj.Attributes, newAttributeJSON(attr.Key, attr.Value))
+	}
+	for _, sc := range s.links {
+		sj.Links = append(sj.Links, linkJSON{TraceID: sc.TraceID.String(), SpanID: sc.SpanID.String()})
+	}
+	if s.err != "" {
+		// STATUS_CODE_ERROR
+		sj.Status = statusJSON{Code: 2, Message: s.err}
+	}
+	s.mutex.Unlock()
+	return sj
+}
+
+// streamExporter write a json line per span.
+type streamExporter struct {
+	w       io.Writer
+	service string
+}
+
+func (e *streamExporter) Export(spans []*Span) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, s := range spans {
+		if err := enc.Encode(struct {
+			Service string `json:"service"`
+			*spanJSON
+		}{e.service, newSpanJSON(s)}); err != nil {
+			return err
+		}
+	}
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+// otlpExporter post the spans to the traces endpoint of an otlp/http
+// collector in the json encoding.
+type otlpExporter struct {
+	endpoint string
+	service  string
+	client   *http.Client
+}
+
+func (e *otlpExporter) Export(spans []*Span) error {
+	sjs := make([]*spanJSON, 0, len(spans))
+	for _, s := range spans {
+		sjs = append(sjs, newSpanJSON(s))
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []attributeJSON{newAttributeJSON("service.name", e.service)},
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]string{"name": "chime"},
+				"spans": sjs,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return &exportError{code: resp.StatusCode}
+	}
+	return nil
+}
+
+type exportError struct {
+	code int
+}
+
+func (e *exportError) Error() string {
+	return "otlp export status: " + strconv.Itoa(e.code)
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor start a server span per call, the child of the
+// traceparent of the incoming metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !tracer.enabled() {
+			return handler(ctx, req)
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if vals := md.Get(HeaderTraceparent); len(vals) > 0 {
+				ctx = ContextWithRemote(ctx, vals[0])
+			}
+		}
+		ctx, span := Start(ctx, info.FullMethod, KindServer)
+		resp, err := handler(ctx, req)
+		span.SetError(err)
+		span.End()
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor start a client span per call and propagate it in
+// the outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !tracer.enabled() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, span := Start(ctx, method, KindClient)
+		ctx = metadata.AppendToOutgoingContext(ctx, HeaderTraceparent, span.Context().Traceparent())
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		span.SetError(err)
+		span.End()
+		return err
+	}
+}
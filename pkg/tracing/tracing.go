@@ -0,0 +1,211 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	mrand "math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderTraceparent the w3c trace context header, carried by the http
+	// requests, grpc metadata and bus headers.
+	HeaderTraceparent = "traceparent"
+)
+
+// the kinds of a span, as numbered by otlp.
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+	KindProducer = 4
+	KindConsumer = 5
+)
+
+// TraceID the id of a trace.
+type TraceID [16]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID the id of a span.
+type SpanID [8]byte
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext the part of a span propagated across the processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid check both ids are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Traceparent format the span context as a traceparent, empty if invalid.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent parse a traceparent of version 00.
+func ParseTraceparent(s string) (sc SpanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, sc.IsValid()
+}
+
+// Attr an attribute of a span.
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// Span a timed operation of a trace, the methods of a nil span do nothing so
+// the callers need not check tracing is enabled.
+type Span struct {
+	sc     SpanContext
+	parent SpanID
+	name   string
+	kind   int
+	start  time.Time
+	end    time.Time
+	mutex  sync.Mutex
+	attrs  []Attr
+	links  []SpanContext
+	err    string
+}
+
+// Context get the span context, zero for a nil span.
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttr set an attribute.
+func (s *Span) SetAttr(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.attrs = append(s.attrs, Attr{Key: key, Value: value})
+	s.mutex.Unlock()
+}
+
+// AddLink link the span to the span of a traceparent, the traceparent is
+// ignored if invalid.
+func (s *Span) AddLink(traceparent string) {
+	if s == nil {
+		return
+	}
+	if sc, ok := ParseTraceparent(traceparent); ok {
+		s.mutex.Lock()
+		s.links = append(s.links, sc)
+		s.mutex.Unlock()
+	}
+}
+
+// SetError mark the span failed if err is not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.err = err.Error()
+	s.mutex.Unlock()
+}
+
+// End end the span and export it if sampled.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	if s.sc.Sampled {
+		tracer.export(s)
+	}
+}
+
+type spanKey struct{}
+
+type remoteKey struct{}
+
+// ContextWithRemote set the remote parent of the spans started from ctx, the
+// traceparent is ignored if invalid.
+func ContextWithRemote(ctx context.Context, traceparent string) context.Context {
+	if sc, ok := ParseTraceparent(traceparent); ok {
+		return context.WithValue(ctx, remoteKey{}, sc)
+	}
+	return ctx
+}
+
+// SpanFromContext get the span of ctx, nil if none.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// spanContext get the parent of the spans started from ctx.
+func spanContext(ctx context.Context) SpanContext {
+	if s := SpanFromContext(ctx); s != nil {
+		return s.sc
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+// Traceparent get the traceparent to propagate from ctx, empty if none.
+func Traceparent(ctx context.Context) string {
+	return spanContext(ctx).Traceparent()
+}
+
+// Start start a span, the child of the span or remote parent of ctx, or a
+// new trace sampled by the ratio. It returns a nil span while disabled.
+func Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if !tracer.enabled() {
+		return ctx, nil
+	}
+	s := &Span{name: name, kind: kind, start: time.Now()}
+	if parent := spanContext(ctx); parent.IsValid() {
+		s.sc.TraceID = parent.TraceID
+		s.sc.Sampled = parent.Sampled
+		s.parent = parent.SpanID
+	} else {
+		_, _ = rand.Read(s.sc.TraceID[:])
+		s.sc.Sampled = tracer.sample()
+	}
+	binary.BigEndian.PutUint64(s.sc.SpanID[:], mrand.Uint64()|1)
+	return context.WithValue(ctx, spanKey{}, s), s
+}